@@ -0,0 +1,67 @@
+// Package backend defines the storage engine in which a config's settings
+// are stored, along with the default engine, which is backed by Viper, and a
+// simple map-based engine.
+package backend
+
+import (
+	"io"
+
+	"github.com/spf13/pflag"
+)
+
+// Backend stores a config's settings.
+type Backend interface {
+
+	// Get returns the value of the key.
+	Get(key string) interface{}
+
+	// Set sets an override value for the key.
+	Set(key string, val interface{})
+
+	// IsSet returns a flag indicating whether or not the key has a value.
+	IsSet(key string) bool
+
+	// AllSettings returns the settings as a tree of nested maps.
+	AllSettings() map[string]interface{}
+
+	// BindEnv binds the key to the environment variable so that the
+	// variable's value is used as the key's value if the key has no override
+	// value.
+	BindEnv(key, envVar string) error
+
+	// MergeReader merges the configuration stream into the settings. The
+	// format of the stream is json, toml, or yml.
+	MergeReader(r io.Reader, format string) error
+
+	// New returns a new backend of the same type with no settings. It is
+	// used to rebuild the settings when the config is reset.
+	New() Backend
+}
+
+// FlagBinder is implemented by backends that are able to read the values of
+// keys from command-line flags. A flag's value is used for its key if the
+// flag was changed and the key has no override value, and the flag's default
+// value is used if the key has no other value.
+type FlagBinder interface {
+
+	// BindFlag binds the key to the flag.
+	BindFlag(key string, flag *pflag.Flag) error
+}
+
+// KeyLister is implemented by backends that are able to list the names of
+// their keys more efficiently than by flattening the maps returned by
+// AllSettings.
+type KeyLister interface {
+
+	// AllKeys returns the dot-notation names of all the keys.
+	AllKeys() []string
+}
+
+// Unsetter is implemented by backends that are able to remove the override
+// value of a key so that the key's value is read from the other sources
+// again.
+type Unsetter interface {
+
+	// Unset removes the override value of the key and of the keys below it.
+	Unset(key string)
+}
@@ -0,0 +1,85 @@
+package backend
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBackend(t *testing.T, b Backend) {
+	assert.NoError(t, b.MergeReader(strings.NewReader(`
+test:
+  host: localhost
+  port: 8080
+`), "yml"))
+	assert.NoError(t, b.MergeReader(strings.NewReader(
+		`{"test": {"port": 9090}}`), "json"))
+	assert.Equal(t, "localhost", b.Get("test.host"))
+	assert.EqualValues(t, 9090, b.Get("Test.Port"))
+	assert.True(t, b.IsSet("test.host"))
+	assert.False(t, b.IsSet("test.missing"))
+	assert.Nil(t, b.Get("test.missing"))
+
+	assert.NoError(t, b.BindEnv("test.user", "BACKEND_TEST_USER"))
+	os.Setenv("BACKEND_TEST_USER", "akutz")
+	defer os.Unsetenv("BACKEND_TEST_USER")
+	assert.Equal(t, "akutz", b.Get("test.user"))
+
+	b.Set("test.host", "127.0.0.1")
+	assert.Equal(t, "127.0.0.1", b.Get("test.host"))
+
+	s, ok := b.AllSettings()["test"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1", s["host"])
+	assert.EqualValues(t, 9090, s["port"])
+
+	if u, ok := b.(Unsetter); ok {
+		b.Set("test.extra.name", "x")
+		assert.True(t, b.IsSet("test.extra"))
+		u.Unset("test.host")
+		u.Unset("test.extra.name")
+		assert.Equal(t, "localhost", b.Get("test.host"))
+		assert.False(t, b.IsSet("test.extra"))
+		assert.False(t, b.IsSet("test.extra.name"))
+		assert.Nil(t, b.Get("test.extra"))
+		s, ok := b.Get("test").(map[string]interface{})
+		assert.True(t, ok)
+		assert.Equal(t, "localhost", s["host"])
+		assert.NotContains(t, s, "extra")
+		assert.True(t, b.IsSet("test"))
+		b.Set("test.host", "127.0.0.1")
+		assert.Equal(t, "127.0.0.1", b.Get("test.host"))
+	}
+
+	fs := &pflag.FlagSet{}
+	fs.String("test-level", "info", "")
+	if fb, ok := b.(FlagBinder); ok {
+		assert.NoError(t, fb.BindFlag("test.level", fs.Lookup("test-level")))
+		assert.Equal(t, "info", b.Get("test.level"))
+		fs.Set("test-level", "debug")
+		assert.Equal(t, "debug", b.Get("test.level"))
+	}
+
+	nb := b.New()
+	assert.IsType(t, b, nb)
+	assert.Nil(t, nb.Get("test.host"))
+}
+
+func TestViperBackend(t *testing.T) {
+	b := NewViperBackend(nil)
+	assert.NotNil(t, b.Viper())
+	testBackend(t, b)
+}
+
+func TestMapBackend(t *testing.T) {
+	b := NewMapBackend()
+	testBackend(t, b)
+
+	b.Set("other", map[string]interface{}{"a": 1, "b": "two"})
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": "two"}, b.Get("other"))
+	assert.True(t, b.IsSet("other"))
+	assert.Len(t, b.AllKeys(), 6)
+}
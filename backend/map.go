@@ -0,0 +1,212 @@
+package backend
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// MapBackend is a simple backend that stores settings in maps. Values are
+// read with the following precedence: override values, changed flags,
+// environment variables, configuration streams, and flag defaults.
+type MapBackend struct {
+	config    map[string]interface{}
+	overrides map[string]interface{}
+	envVars   map[string]string
+	flags     map[string]*pflag.Flag
+}
+
+// NewMapBackend returns a new map backend with no settings.
+func NewMapBackend() *MapBackend {
+	return &MapBackend{
+		config:    map[string]interface{}{},
+		overrides: map[string]interface{}{},
+		envVars:   map[string]string{},
+		flags:     map[string]*pflag.Flag{},
+	}
+}
+
+func (b *MapBackend) Get(key string) interface{} {
+	lk := strings.ToLower(key)
+	if v, ok := b.leafValue(lk); ok {
+		return v
+	}
+	m := map[string]interface{}{}
+	for _, k := range b.AllKeys() {
+		if !strings.HasPrefix(k, lk+".") {
+			continue
+		}
+		if v, ok := b.leafValue(k); ok {
+			setNested(m, strings.TrimPrefix(k, lk+"."), v)
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (b *MapBackend) Set(key string, val interface{}) {
+	lk := strings.ToLower(key)
+	if m, ok := val.(map[string]interface{}); ok {
+		flatten(lk, m, b.overrides)
+		return
+	}
+	b.overrides[lk] = val
+}
+
+func (b *MapBackend) Unset(key string) {
+	lk := strings.ToLower(key)
+	for k := range b.overrides {
+		if k == lk || strings.HasPrefix(k, lk+".") {
+			delete(b.overrides, k)
+		}
+	}
+}
+
+func (b *MapBackend) IsSet(key string) bool {
+	lk := strings.ToLower(key)
+	for _, k := range b.AllKeys() {
+		if k != lk && !strings.HasPrefix(k, lk+".") {
+			continue
+		}
+		if _, ok := b.overrides[k]; ok {
+			return true
+		}
+		if f, ok := b.flags[k]; ok && f.Changed {
+			return true
+		}
+		if os.Getenv(b.envVars[k]) != "" {
+			return true
+		}
+		if _, ok := b.config[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *MapBackend) AllSettings() map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, k := range b.AllKeys() {
+		if v, ok := b.leafValue(k); ok && v != nil {
+			setNested(m, k, v)
+		}
+	}
+	return m
+}
+
+func (b *MapBackend) AllKeys() []string {
+	keys := map[string]bool{}
+	for k := range b.overrides {
+		keys[k] = true
+	}
+	for k := range b.config {
+		keys[k] = true
+	}
+	for k := range b.envVars {
+		keys[k] = true
+	}
+	for k := range b.flags {
+		keys[k] = true
+	}
+	ak := []string{}
+	for k := range keys {
+		ak = append(ak, k)
+	}
+	return ak
+}
+
+func (b *MapBackend) BindEnv(key, envVar string) error {
+	b.envVars[strings.ToLower(key)] = envVar
+	return nil
+}
+
+func (b *MapBackend) BindFlag(key string, flag *pflag.Flag) error {
+	b.flags[strings.ToLower(key)] = flag
+	return nil
+}
+
+func (b *MapBackend) MergeReader(r io.Reader, format string) error {
+	v := viper.New()
+	v.SetConfigType(format)
+	if err := v.ReadConfig(r); err != nil {
+		return err
+	}
+	flatten("", v.AllSettings(), b.config)
+	return nil
+}
+
+func (b *MapBackend) New() Backend {
+	return NewMapBackend()
+}
+
+// leafValue returns the value of the leaf key. The second return value is
+// false if the key has no value.
+func (b *MapBackend) leafValue(k string) (interface{}, bool) {
+	if v, ok := b.overrides[k]; ok {
+		return v, true
+	}
+	f, hasFlag := b.flags[k]
+	if hasFlag && f.Changed {
+		return flagValue(f), true
+	}
+	if ev := os.Getenv(b.envVars[k]); ev != "" {
+		return ev, true
+	}
+	if v, ok := b.config[k]; ok {
+		return v, true
+	}
+	if hasFlag {
+		return flagValue(f), true
+	}
+	return nil, false
+}
+
+// flagValue returns the flag's value converted to the flag's type.
+func flagValue(f *pflag.Flag) interface{} {
+	switch f.Value.Type() {
+	case "bool":
+		return cast.ToBool(f.Value.String())
+	case "int":
+		return cast.ToInt(f.Value.String())
+	}
+	return f.Value.String()
+}
+
+// flatten copies the leaf values of the tree of nested maps to the flat map,
+// keyed by their lower-case, dot-notation names.
+func flatten(
+	prefix string, m map[string]interface{}, flat map[string]interface{}) {
+
+	for k, v := range m {
+		kk := strings.ToLower(k)
+		if prefix != "" {
+			kk = prefix + "." + kk
+		}
+		if tv, ok := v.(map[string]interface{}); ok {
+			flatten(kk, tv, flat)
+			continue
+		}
+		flat[kk] = v
+	}
+}
+
+// setNested sets the value of the dot-notation key in the tree of nested
+// maps.
+func setNested(m map[string]interface{}, k string, v interface{}) {
+	parts := strings.Split(k, ".")
+	for _, p := range parts[:len(parts)-1] {
+		pm, ok := m[p].(map[string]interface{})
+		if !ok {
+			pm = map[string]interface{}{}
+			m[p] = pm
+		}
+		m = pm
+	}
+	m[parts[len(parts)-1]] = v
+}
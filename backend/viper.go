@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// ViperBackend is a backend that stores settings in a Viper instance.
+type ViperBackend struct {
+	v *viper.Viper
+
+	// unset is the set of keys whose override values were removed. Viper
+	// cannot remove an override value, so the value is set to nil instead,
+	// which Viper treats as unset for the key itself but not for the keys
+	// above it.
+	unset map[string]bool
+}
+
+// NewViperBackend returns a backend that stores settings in the specified
+// Viper instance. A new Viper instance is created if v is nil.
+func NewViperBackend(v *viper.Viper) *ViperBackend {
+	if v == nil {
+		v = viper.New()
+	}
+	return &ViperBackend{v: v, unset: map[string]bool{}}
+}
+
+// Viper returns the backend's Viper instance.
+func (b *ViperBackend) Viper() *viper.Viper {
+	return b.v
+}
+
+func (b *ViperBackend) Get(key string) interface{} {
+	if !b.hasUnsetBelow(key) {
+		return b.v.Get(key)
+	}
+	var v interface{} = b.v.AllSettings()
+	for _, p := range strings.Split(strings.ToLower(key), ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
+
+func (b *ViperBackend) Set(key string, val interface{}) {
+	b.removeUnset(key)
+	b.v.Set(key, val)
+}
+
+func (b *ViperBackend) Unset(key string) {
+	b.removeUnset(key)
+	b.unset[strings.ToLower(key)] = true
+	b.v.Set(key, nil)
+}
+
+func (b *ViperBackend) IsSet(key string) bool {
+	if !b.hasUnsetBelow(key) {
+		return b.v.IsSet(key)
+	}
+	lp := strings.ToLower(key) + "."
+	for _, k := range b.AllKeys() {
+		if strings.HasPrefix(k, lp) && b.v.IsSet(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *ViperBackend) AllSettings() map[string]interface{} {
+	return b.v.AllSettings()
+}
+
+func (b *ViperBackend) AllKeys() []string {
+	if len(b.unset) == 0 {
+		return b.v.AllKeys()
+	}
+	keys := []string{}
+	for _, k := range b.v.AllKeys() {
+		if !b.unset[k] || b.v.Get(k) != nil {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (b *ViperBackend) BindEnv(key, envVar string) error {
+	return b.v.BindEnv(key, envVar)
+}
+
+func (b *ViperBackend) BindFlag(key string, flag *pflag.Flag) error {
+	return b.v.BindPFlag(key, flag)
+}
+
+func (b *ViperBackend) MergeReader(r io.Reader, format string) error {
+	if format != "" {
+		b.v.SetConfigType(format)
+	}
+	return b.v.MergeConfig(r)
+}
+
+func (b *ViperBackend) New() Backend {
+	return NewViperBackend(nil)
+}
+
+// hasUnsetBelow returns a flag indicating whether or not the override value
+// of a key below the specified key was removed.
+func (b *ViperBackend) hasUnsetBelow(key string) bool {
+	if len(b.unset) == 0 {
+		return false
+	}
+	lp := strings.ToLower(key) + "."
+	for k := range b.unset {
+		if strings.HasPrefix(k, lp) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeUnset forgets the removed override values of the key and of the keys
+// below it.
+func (b *ViperBackend) removeUnset(key string) {
+	lk := strings.ToLower(key)
+	for k := range b.unset {
+		if k == lk || strings.HasPrefix(k, lk+".") {
+			delete(b.unset, k)
+		}
+	}
+}
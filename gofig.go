@@ -5,11 +5,14 @@ package gofig
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -19,7 +22,8 @@ import (
 	"github.com/akutz/gofig/types"
 	"github.com/akutz/goof"
 	"github.com/akutz/gotil"
-	log "github.com/sirupsen/logrus"
+	toml "github.com/pelletier/go-toml"
+	"github.com/spf13/cast"
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -50,6 +54,40 @@ var (
 	// able to disable/enable the feature for that instance.
 	DisableEnvVarSubstitution, _ = strconv.ParseBool(
 		os.Getenv("GOFIG_DISABLE_ENVVAR_SUBSTITUTION"))
+
+	// ErrorOnDeprecated determines whether or not reading a deprecated key
+	// is an error instead of a logged warning. When enabled, ReadConfig and
+	// ReadConfigFile return an error if the stream contains a deprecated key,
+	// Validate returns an error for each deprecated key that is set, and the
+	// Get and IsSet functions log an error if the key is deprecated.
+	ErrorOnDeprecated, _ = strconv.ParseBool(
+		os.Getenv("GOFIG_ERROR_ON_DEPRECATED"))
+
+	// StrictMode determines whether or not ReadConfig and ReadConfigFile
+	// return an error when the config stream contains keys that do not belong
+	// to any registration.
+	//
+	// New Config instances inherit this value at the time of the instance
+	// creation. However, this value has no effect on existing config instances.
+	// Those instances have a function named StrictMode that is able to
+	// disable/enable the feature for that instance.
+	StrictMode, _ = strconv.ParseBool(os.Getenv("GOFIG_STRICT_MODE"))
+
+	// ValidateOnLoad determines whether or not the constraints registered
+	// with MutuallyExclusive and AtLeastOne are checked when a config is
+	// created and when ReadConfig and ReadConfigFile are called. ReadConfig
+	// and ReadConfigFile return the error returned by Validate, and the
+	// error is logged when a config is created.
+	ValidateOnLoad, _ = strconv.ParseBool(
+		os.Getenv("GOFIG_VALIDATE_ON_LOAD"))
+
+	// AllowDuplicateKeys determines whether or not a key may be registered by
+	// more than one registration. When disabled, the registrations are
+	// processed only until a key is registered twice, so the order in which
+	// the key's bindings are created is never ambiguous, and a
+	// *DuplicateKeyError is logged when a config is created and returned when
+	// a config is reset. Duplicate keys are allowed by default.
+	AllowDuplicateKeys = true
 )
 
 var (
@@ -57,18 +95,28 @@ var (
 	etcDirPath       string
 	usrDirPath       string
 	envVarRx         *regexp.Regexp
+	yamlKeyValRx     *regexp.Regexp
+	tomlKeyValRx     *regexp.Regexp
+	tomlTableRx      *regexp.Regexp
 	registrations    []types.ConfigRegistration
 	registrationsRWL *sync.RWMutex
 	secureKeys       map[string]types.ConfigRegistrationKey
 	secureKeysRWL    *sync.RWMutex
+	enumKeys         map[string]types.ConfigRegistrationKey
+	enumKeysRWL      *sync.RWMutex
 	prefix           string
 )
 
 func init() {
 	envVarRx = regexp.MustCompile(`^\s*([^#=]+?)=(.+)$`)
+	yamlKeyValRx = regexp.MustCompile(`^[^=]+?:(\s|$)`)
+	tomlKeyValRx = regexp.MustCompile(`^[\w\-\."]+\s*=`)
+	tomlTableRx = regexp.MustCompile(`^\[{1,2}[^\[\]]+\]{1,2}$`)
 	registrationsRWL = &sync.RWMutex{}
 	secureKeys = map[string]types.ConfigRegistrationKey{}
 	secureKeysRWL = &sync.RWMutex{}
+	enumKeys = map[string]types.ConfigRegistrationKey{}
+	enumKeysRWL = &sync.RWMutex{}
 	loadEtcEnvironment()
 
 	// tell the yaml package to presrve JSON compatibility by using a string
@@ -90,24 +138,103 @@ func FromJSON(from string) (types.Config, error) {
 		return nil, err
 	}
 	for k, v := range m {
-		c.v.Set(k, v)
+		c.b.Set(k, v)
+	}
+	c.reads = append(c.reads, configRead{buf: []byte(from), format: "json"})
+	c.publishSnapshot()
+	return c, nil
+}
+
+// FromYAML initializes a new Config instance from a YAML string
+func FromYAML(from string) (types.Config, error) {
+	c := newConfig()
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(from), &m); err != nil {
+		return nil, err
+	}
+	for k, v := range m {
+		c.b.Set(k, v)
 	}
+	c.reads = append(c.reads, configRead{buf: []byte(from), format: "yml"})
+	c.publishSnapshot()
+	return c, nil
+}
+
+// FromTOML initializes a new Config instance from a TOML string
+func FromTOML(from string) (types.Config, error) {
+	c := newConfig()
+	t, err := toml.Load(from)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range t.ToMap() {
+		c.b.Set(k, v)
+	}
+	c.reads = append(c.reads, configRead{buf: []byte(from), format: "toml"})
+	c.publishSnapshot()
 	return c, nil
 }
 
 // SetGlobalConfigPath sets the path of the directory from which the global
-// configuration file is read.
-func SetGlobalConfigPath(path string) {
+// configuration file is read. An error is returned if the path is not
+// absolute or, if StrictMode is true, if the directory does not exist. If
+// StrictMode is false a missing directory is logged as a warning.
+func SetGlobalConfigPath(path string) error {
+	if err := checkConfigDirPath(path); err != nil {
+		return err
+	}
 	etcDirPath = path
+	return nil
+}
+
+// MustSetGlobalConfigPath is like SetGlobalConfigPath but panics if the path
+// is invalid. It is intended for use in init functions.
+func MustSetGlobalConfigPath(path string) {
+	if err := SetGlobalConfigPath(path); err != nil {
+		panic(err)
+	}
 }
 
 // SetUserConfigPath sets the path of the directory from which the user
-// configuration file is read.
-func SetUserConfigPath(path string) {
+// configuration file is read. An error is returned if the path is not
+// absolute or, if StrictMode is true, if the directory does not exist. If
+// StrictMode is false a missing directory is logged as a warning.
+func SetUserConfigPath(path string) error {
+	if err := checkConfigDirPath(path); err != nil {
+		return err
+	}
 	usrDirPath = path
+	return nil
+}
+
+// MustSetUserConfigPath is like SetUserConfigPath but panics if the path is
+// invalid. It is intended for use in init functions.
+func MustSetUserConfigPath(path string) {
+	if err := SetUserConfigPath(path); err != nil {
+		panic(err)
+	}
+}
+
+// checkConfigDirPath returns an error if the path of the config directory is
+// not absolute or if the directory does not exist and StrictMode is true.
+func checkConfigDirPath(path string) error {
+	if !filepath.IsAbs(path) {
+		return goof.WithField("path", path, "config path is not absolute")
+	}
+	if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+		return goof.WithField("path", path, "config path is not a directory")
+	} else if os.IsNotExist(err) {
+		if StrictMode {
+			return goof.WithFieldE(
+				"path", path, "config directory does not exist", err)
+		}
+		logger().Warn("config directory does not exist", "path", path)
+	}
+	return nil
 }
 
-// Register registers a new configuration with the config package.
+// Register registers a new configuration with the config package. A
+// registration with the same name is replaced.
 func Register(r types.ConfigRegistration) {
 	registrationsRWL.Lock()
 	defer registrationsRWL.Unlock()
@@ -120,18 +247,161 @@ func Register(r types.ConfigRegistration) {
 	registrations = append(registrations, r)
 }
 
+// UnregisterByName removes the registration with the specified name from the
+// config package. A flag is returned indicating whether or not the
+// registration was found.
+func UnregisterByName(name string) bool {
+	registrationsRWL.Lock()
+	defer registrationsRWL.Unlock()
+	for x, r := range registrations {
+		if r.Name() == name {
+			registrations = append(registrations[:x], registrations[x+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ClearRegistrations removes all of the registrations, secure keys, enum
+// keys, registered key names, migrations, and constraints from the config
+// package.
+func ClearRegistrations() {
+	registrationsRWL.Lock()
+	defer registrationsRWL.Unlock()
+	secureKeysRWL.Lock()
+	defer secureKeysRWL.Unlock()
+	enumKeysRWL.Lock()
+	defer enumKeysRWL.Unlock()
+	normalizedKeysRWL.Lock()
+	defer normalizedKeysRWL.Unlock()
+	migrationsRWL.Lock()
+	defer migrationsRWL.Unlock()
+	constraintsRWL.Lock()
+	defer constraintsRWL.Unlock()
+	registrations = nil
+	migrations = map[int]*migration{}
+	constraints = nil
+	secureKeys = map[string]types.ConfigRegistrationKey{}
+	enumKeys = map[string]types.ConfigRegistrationKey{}
+	regKeyNames = map[string]bool{}
+	normalizedKeys = map[string]string{}
+}
+
+// TestHook saves the config package's registrations, secure keys, enum keys,
+// registered key names, key normalizer, key transformer, migrations, and
+// constraints and registers a cleanup function with the test that restores
+// them when the test and its subtests complete. The t argument is typically a
+// *testing.T.
+func TestHook(t interface {
+	Cleanup(func())
+}) {
+	registrationsRWL.RLock()
+	regs := append([]types.ConfigRegistration{}, registrations...)
+	registrationsRWL.RUnlock()
+
+	secureKeysRWL.RLock()
+	keys := map[string]types.ConfigRegistrationKey{}
+	for k, v := range secureKeys {
+		keys[k] = v
+	}
+	secureKeysRWL.RUnlock()
+
+	enumKeysRWL.RLock()
+	enums := map[string]types.ConfigRegistrationKey{}
+	for k, v := range enumKeys {
+		enums[k] = v
+	}
+	enumKeysRWL.RUnlock()
+
+	normalizedKeysRWL.RLock()
+	normalizer := keyNormalizer
+	transformer := keyTransformer
+	names := map[string]bool{}
+	for k := range regKeyNames {
+		names[k] = true
+	}
+	normalized := map[string]string{}
+	for k, v := range normalizedKeys {
+		normalized[k] = v
+	}
+	normalizedKeysRWL.RUnlock()
+
+	migrationsRWL.RLock()
+	migs := map[int]*migration{}
+	for k, v := range migrations {
+		migs[k] = v
+	}
+	migrationsRWL.RUnlock()
+
+	constraintsRWL.RLock()
+	cons := append([]*constraint{}, constraints...)
+	constraintsRWL.RUnlock()
+
+	aliasesRWL.RLock()
+	als := map[string]string{}
+	for k, v := range aliases {
+		als[k] = v
+	}
+	aliasesRWL.RUnlock()
+
+	errorOnDeprecated := ErrorOnDeprecated
+
+	t.Cleanup(func() {
+		ErrorOnDeprecated = errorOnDeprecated
+
+		registrationsRWL.Lock()
+		defer registrationsRWL.Unlock()
+		secureKeysRWL.Lock()
+		defer secureKeysRWL.Unlock()
+		enumKeysRWL.Lock()
+		defer enumKeysRWL.Unlock()
+		registrations = regs
+		secureKeys = keys
+		enumKeys = enums
+
+		normalizedKeysRWL.Lock()
+		defer normalizedKeysRWL.Unlock()
+		keyNormalizer = normalizer
+		keyTransformer = transformer
+		regKeyNames = names
+		normalizedKeys = normalized
+
+		migrationsRWL.Lock()
+		defer migrationsRWL.Unlock()
+		migrations = migs
+
+		constraintsRWL.Lock()
+		defer constraintsRWL.Unlock()
+		constraints = cons
+
+		aliasesRWL.Lock()
+		defer aliasesRWL.Unlock()
+		aliases = als
+	})
+}
+
 // New initializes a new instance of a types.Config struct
-func New() types.Config {
-	return newConfig()
+func New(opts ...ConfigOption) types.Config {
+	return newConfig(opts...)
 }
 
-// NewConfig initialies a new instance of a Config object with the specified
-// options.
-func NewConfig(
+// NewConfig initializes a new instance of a Config object with the specified
+// options. Without any options the config is the same as one returned by New:
+// the global and user config files named config.yml are read.
+func NewConfig(opts ...ConfigOption) types.Config {
+	return newConfig(opts...)
+}
+
+// NewConfigLegacy initializes a new instance of a Config object with the
+// specified options.
+//
+// Deprecated: Use NewConfig with the WithGlobalConfig, WithUserConfig,
+// WithConfigName, and WithConfigType options instead.
+func NewConfigLegacy(
 	loadGlobalConfig, loadUserConfig bool,
-	configName, configType string) types.Config {
+	configName, configType string, opts ...ConfigOption) types.Config {
 	return newConfigWithOptions(
-		loadGlobalConfig, loadUserConfig, configName, configType)
+		loadGlobalConfig, loadUserConfig, configName, configType, opts...)
 }
 
 func (c *config) DisableEnvVarSubstitution(disable bool) {
@@ -159,7 +429,7 @@ func toString(i interface{}) string {
 
 func (c *scopedConfig) Scope(scope interface{}) types.Config {
 	szScope := toString(scope)
-	if log.GetLevel() == log.DebugLevel {
+	if logger().Enabled(context.Background(), slog.LevelDebug) {
 		scopes := []string{}
 		var p types.Config = c
 		for {
@@ -169,10 +439,10 @@ func (c *scopedConfig) Scope(scope interface{}) types.Config {
 				break
 			}
 		}
-		log.WithFields(log.Fields{
-			"new":          szScope,
-			"parentScopes": strings.Join(scopes, ","),
-		}).Debug("created scoped scope")
+		logger().Debug("created scoped scope",
+			"new", szScope,
+			"parentScopes", strings.Join(scopes, ","),
+		)
 	}
 	return &scopedConfig{Config: c, scope: szScope}
 }
@@ -188,6 +458,13 @@ func (c *config) GetScope() string {
 	return ""
 }
 
+func (c *scopedConfig) GetScopeChain() []string {
+	return append(c.Config.GetScopeChain(), c.scope)
+}
+func (c *config) GetScopeChain() []string {
+	return []string{}
+}
+
 func (c *scopedConfig) Copy() (types.Config, error) {
 	cc, err := c.Config.Copy()
 	if err != nil {
@@ -196,12 +473,40 @@ func (c *scopedConfig) Copy() (types.Config, error) {
 	return &scopedConfig{Config: cc, scope: c.scope}, nil
 }
 func (c *config) Copy() (types.Config, error) {
-	newC := newConfig()
-	m := map[string]interface{}{}
-	c.v.Unmarshal(&m)
-	for k, v := range m {
-		newC.v.Set(k, v)
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	newC := newConfig(
+		WithEnvKeyPrefix(c.envKeyPrefix),
+		WithKeyTransformer(c.keyTransformer),
+		WithMergeStrategy(c.mergeStrategy),
+		WithBackend(c.b.New()),
+		withRegistrations(c.registrations...))
+	for k, v := range c.b.AllSettings() {
+		newC.b.Set(k, v)
+	}
+	c.deprecationsRWL.RLock()
+	defer c.deprecationsRWL.RUnlock()
+	for k, msg := range c.deprecations {
+		newC.Deprecate(k, msg)
+	}
+	newC.frozen.Store(c.frozen.Load())
+	for k := range c.overrideKeys {
+		newC.overrideKeys[k] = true
 	}
+	for k := range c.readKeys {
+		newC.readKeys[k] = true
+	}
+	newC.profile = c.profile
+	newC.subParent = c.subParent
+	newC.subPrefix = c.subPrefix
+	newC.keyPrefix = c.keyPrefix
+	newC.reads = append([]configRead(nil), c.reads...)
+	c.mergedSecureKeysRWL.RLock()
+	for k := range c.mergedSecureKeys {
+		newC.mergedSecureKeys[k] = true
+	}
+	c.mergedSecureKeysRWL.RUnlock()
+	newC.publishSnapshot()
 	return newC, nil
 }
 
@@ -221,23 +526,201 @@ func (c *config) ToJSONCompact() (string, error) {
 	return string(buf), nil
 }
 
+func (c *config) ToYAML() (string, error) {
+	buf, err := marshalConfig(c.nestedSecureSettings(), "yml")
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func (c *config) ToTOML() (string, error) {
+	buf, err := marshalConfig(c.nestedSecureSettings(), "toml")
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
 func (c *config) MarshalJSON() ([]byte, error) {
 	return c.marshalJSON(true)
 }
 
 func (c *config) ReadConfig(in io.Reader) error {
+	return c.readConfigStream(in, "")
+}
+
+func (c *config) ReadConfigFromReader(in io.Reader, format string) error {
+	format = strings.ToLower(format)
+	if !isSupportedConfigFormat(format) {
+		return goof.WithField(
+			"format", format, "unsupported config format")
+	}
+	return c.readConfigStream(in, format)
+}
+
+// readConfigStream reads the configuration stream into the config. The format
+// of the stream is detected from its content if format is empty.
+func (c *config) readConfigStream(in io.Reader, format string) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
 	if in == nil {
 		return goof.New("config reader is nil")
 	}
-	return c.v.MergeConfig(in)
+	buf, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	if buf, err = decompressIfCompressed(buf); err != nil {
+		return err
+	}
+	if format == "" {
+		format = detectConfigFormat(buf, c.configType)
+	}
+	if err := c.checkSettings(buf, format); err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	err = c.mergeConfig(buf, format)
+	c.rwl.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.validateOnLoad()
 }
 
 func (c *config) ReadConfigFile(filePath string) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	filePath = c.findConfigFile(filePath)
+	buf, format, err := c.readConfigFileBuf(filePath)
+	if err != nil {
+		return err
+	}
+	return c.mergeConfigFile(filePath, buf, format)
+}
+
+// readConfigFileBuf returns the content of the config file, merged with the
+// content of the files it includes, and the file's format.
+func (c *config) readConfigFileBuf(filePath string) ([]byte, string, error) {
 	buf, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(filePath), "."))
+	if !isSupportedConfigFormat(format) {
+		format = detectConfigFormat(buf, c.configType)
+	}
+	if buf, err = expandIncludes(filePath, buf, format); err != nil {
+		return nil, "", err
+	}
+	return buf, format, nil
+}
+
+// mergeConfigFile verifies the settings in the content of the config file
+// and merges them into the config.
+func (c *config) mergeConfigFile(
+	filePath string, buf []byte, format string) error {
+
+	if err := c.checkSettings(buf, format); err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	err := c.mergeConfigRead(
+		configRead{buf: buf, format: format, filePath: filePath})
+	if err == nil && c.configFilePath == "" {
+		c.configFilePath = filePath
+	}
+	c.rwl.Unlock()
 	if err != nil {
 		return err
 	}
-	return c.ReadConfig(bytes.NewBuffer(buf))
+	return c.validateOnLoad()
+}
+
+// checkSettings parses the configuration stream and verifies its keys are
+// neither deprecated, if ErrorOnDeprecated is true, nor unknown, if the config
+// is in strict mode.
+func (c *config) checkSettings(buf []byte, format string) error {
+	m, err := readSettings(buf, format)
+	if err != nil {
+		return NewConfigError(ErrCodeParseFailed, "", err)
+	}
+	if err := c.checkDeprecatedSettings(m); err != nil {
+		return err
+	}
+	if err := c.checkUnknownSettings(m); err != nil {
+		return err
+	}
+	return checkEnumSettings(m)
+}
+
+// mergeConfig merges the configuration stream into the current config
+// instance and records the stream so that it is read again when the config
+// instance is reset.
+func (c *config) mergeConfig(buf []byte, format string) error {
+	return c.mergeConfigRead(configRead{buf: buf, format: format})
+}
+
+// mergeConfigRead resolves the configuration stream's keys that were already
+// read according to the config's merge strategy, merges the stream into the
+// current config instance, and records the resolved stream so that it is read
+// again when the config instance is reset.
+func (c *config) mergeConfigRead(r configRead) error {
+	r, err := c.applyMergeStrategy(r)
+	if err != nil {
+		return err
+	}
+	if err := c.readConfig(bytes.NewReader(r.buf), r.format); err != nil {
+		return err
+	}
+	c.reads = append(c.reads, r)
+	c.addReadKeys(r.buf, r.format)
+	c.publishSnapshot()
+	return nil
+}
+
+// rereadConfig merges the new contents of a configuration stream previously
+// read into the config instance and replaces the recorded stream's contents so
+// that the new contents are read when the config instance is reset.
+func (c *config) rereadConfig(i int, buf []byte) error {
+	r := &c.reads[i]
+	if err := c.readConfig(bytes.NewReader(buf), r.format); err != nil {
+		return err
+	}
+	r.buf = buf
+	c.addReadKeys(buf, r.format)
+	return nil
+}
+
+// readConfig merges the configuration stream into the current config
+// instance using the specified format. The config's lock must be held.
+func (c *config) readConfig(in io.Reader, format string) error {
+	if in == nil {
+		return goof.New("config reader is nil")
+	}
+	if hasAliases() {
+		var err error
+		if in, format, err = c.resolveAliases(in, format); err != nil {
+			return err
+		}
+	}
+	if format == "" {
+		format = c.configType
+	}
+	if !c.hasChangeCallbacks() {
+		return c.b.MergeReader(in, format)
+	}
+	before := c.flatSettingsLocked()
+	if err := c.b.MergeReader(in, format); err != nil {
+		return err
+	}
+	after := c.flatSettingsLocked()
+	c.notifyChanges(before, after)
+	c.recordChanges(before, after, types.File)
+	return nil
 }
 
 func (c *config) EnvVars() []string {
@@ -269,9 +752,27 @@ func (c *config) AllKeys() []string {
 	return ak
 }
 
+func (c *scopedConfig) AllKeys() []string {
+	ak := []string{}
+	scopePrefix := fmt.Sprintf("%s.", strings.ToLower(c.scope))
+	for _, k := range c.Config.AllKeys() {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, scopePrefix) {
+			ak = append(ak, lk[len(scopePrefix):])
+		}
+	}
+	return ak
+}
+
 func (c *config) AllSettings() map[string]interface{} {
 	return c.allSettings()
 }
+func (c *scopedConfig) AllSettings() map[string]interface{} {
+	flat := map[string]interface{}{}
+	for _, k := range c.AllKeys() {
+		flat[k] = c.Config.Get(fmt.Sprintf("%s.%s", c.scope, k))
+	}
+	return unflattenMapKeys(flat)
+}
 
 func (c *config) replaceEnvVars(s string, envVars []string) string {
 	if c.disableEnvVarSubstitution {
@@ -288,16 +789,22 @@ func (c *config) replaceEnvVars(s string, envVars []string) string {
 }
 
 func (c *config) GetString(k interface{}) string {
-	szK := toString(k)
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
 	if LogGetAndSet {
-		log.WithField("key", szK).Debug("config.GetString")
+		logger().Debug("config.GetString", "key", szK)
 	}
-	return c.replaceEnvVars(c.v.GetString(szK), os.Environ())
+	if v, ok := resolveValue(szK); ok {
+		return v
+	}
+	return enumValue(szK, c.replaceEnvVars(
+		c.expandString(szK, cast.ToString(c.get(szK))), os.Environ()))
 }
 func (c *scopedConfig) GetString(k interface{}) string {
 	szK := toString(k)
 	sk := fmt.Sprintf("%s.%s", c.scope, szK)
-	if c.Config.IsSet(sk) {
+	if c.Config.IsSet(sk) || isResolvedKey(sk) {
 		return c.Config.GetString(sk)
 	}
 	if c.Parent() != nil {
@@ -307,11 +814,13 @@ func (c *scopedConfig) GetString(k interface{}) string {
 }
 
 func (c *config) GetBool(k interface{}) bool {
-	szK := toString(k)
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
 	if LogGetAndSet {
-		log.WithField("key", szK).Debug("config.GetBool")
+		logger().Debug("config.GetBool", "key", szK)
 	}
-	return c.v.GetBool(szK)
+	return cast.ToBool(c.get(szK))
 }
 func (c *scopedConfig) GetBool(k interface{}) bool {
 	szK := toString(k)
@@ -326,17 +835,17 @@ func (c *scopedConfig) GetBool(k interface{}) bool {
 }
 
 func (c *config) GetStringSlice(k interface{}) []string {
-	szK := toString(k)
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
 	if LogGetAndSet {
-		log.WithField("key", szK).Debug("config.GetStringSlice")
+		logger().Debug("config.GetStringSlice", "key", szK)
 	}
-	ss := c.v.GetStringSlice(szK)
-	rss := []string{}
-	envVars := os.Environ()
-	for _, s := range ss {
-		rss = append(rss, c.replaceEnvVars(s, envVars))
+	ss, ok := c.envSliceValue(szK, c.keyDelim(szK))
+	if !ok {
+		ss = cast.ToStringSlice(c.get(szK))
 	}
-	return rss
+	return c.replaceSliceEnvVars(ss)
 }
 func (c *scopedConfig) GetStringSlice(k interface{}) []string {
 	szK := toString(k)
@@ -351,11 +860,13 @@ func (c *scopedConfig) GetStringSlice(k interface{}) []string {
 }
 
 func (c *config) GetInt(k interface{}) int {
-	szK := toString(k)
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
 	if LogGetAndSet {
-		log.WithField("key", szK).Debug("config.GetInt")
+		logger().Debug("config.GetInt", "key", szK)
 	}
-	return c.v.GetInt(szK)
+	return cast.ToInt(c.get(szK))
 }
 func (c *scopedConfig) GetInt(k interface{}) int {
 	szK := toString(k)
@@ -370,11 +881,13 @@ func (c *scopedConfig) GetInt(k interface{}) int {
 }
 
 func (c *config) Get(k interface{}) interface{} {
-	szK := toString(k)
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
 	if LogGetAndSet {
-		log.WithField("key", szK).Debug("config.Get")
+		logger().Debug("config.Get", "key", szK)
 	}
-	return c.v.Get(szK)
+	return c.get(szK)
 }
 func (c *scopedConfig) Get(k interface{}) interface{} {
 	szK := toString(k)
@@ -389,11 +902,15 @@ func (c *scopedConfig) Get(k interface{}) interface{} {
 }
 
 func (c *config) IsSet(k interface{}) bool {
-	szK := toString(k)
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
 	if LogGetAndSet {
-		log.WithField("key", szK).Debug("config.IsSet")
+		logger().Debug("config.IsSet", "key", szK)
 	}
-	return c.v.IsSet(szK)
+	c.expireTTLs()
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	return c.b.IsSet(szK)
 }
 func (c *scopedConfig) IsSet(k interface{}) bool {
 	szK := toString(k)
@@ -407,60 +924,109 @@ func (c *scopedConfig) IsSet(k interface{}) bool {
 }
 
 func (c *config) Set(k interface{}, v interface{}) {
-	szK := toString(k)
-	c.v.Set(szK, v)
+	if c.frozen.Load() {
+		panic(ErrFrozen)
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	szK := c.realKey(toString(k))
+	warnInvalidEnumValue(szK, v)
+	c.overrideKeys[strings.ToLower(szK)] = true
+	c.removeTTL(szK)
+	if !c.hasChangeCallbacks() {
+		c.b.Set(szK, v)
+		c.publishSnapshot()
+		return
+	}
+	oldVal := c.b.Get(szK)
+	c.b.Set(szK, v)
+	newVal := c.b.Get(szK)
+	c.publishSnapshot()
+	c.notifyChange(szK, oldVal, newVal)
+	c.recordChange(szK, oldVal, newVal, types.Override)
 }
 func (c *scopedConfig) Set(k interface{}, v interface{}) {
 	szK := toString(k)
 	c.Config.Set(fmt.Sprintf("%s.%s", c.scope, szK), v)
 }
 
-func newConfig() *config {
-	return newConfigWithOptions(true, true, "config", "yml")
-}
-
-func newConfigWithOptions(
-	loadGlobalConfig, loadUserConfig bool,
-	configName, configType string) *config {
-
+func newConfig(opts ...ConfigOption) *config {
 	c := newConfigObj()
+	c.loadGlobalConfig = true
+	c.loadUserConfig = true
+	c.configName = "config"
+	c.configType = "yml"
+	for _, o := range opts {
+		o(c)
+	}
 
-	log.Debug("initializing configuration")
+	logger().Debug("initializing configuration")
 
-	c.v.SetTypeByDefaultValue(false)
-	c.v.SetConfigName(configName)
-	c.v.SetConfigType(configType)
+	c.loadEnvFiles()
+	if err := c.processRegistrations(); err != nil {
+		logger().Error("error processing registrations", "error", err)
+	}
 
-	c.processRegistrations()
+	if c.loadGlobalConfig {
+		c.loadConfigFiles(c.globalConfigPath, "global")
+	}
 
-	cfgFile := fmt.Sprintf("%s.%s", configName, configType)
-	etcConfigFile := fmt.Sprintf("%s/%s", etcDirPath, cfgFile)
-	usrConfigFile := fmt.Sprintf("%s/%s", usrDirPath, cfgFile)
+	if c.loadUserConfig {
+		c.loadConfigFiles(c.userConfigPath, "user")
+	}
 
-	if loadGlobalConfig && gotil.FileExists(etcConfigFile) {
-		log.WithField("path", etcConfigFile).Debug("loading global config file")
-		if err := c.ReadConfigFile(etcConfigFile); err != nil {
-			log.WithField("path", etcConfigFile).WithError(err).Debug(
-				"error reading global config file")
-		}
+	if err := c.migrate(); err != nil {
+		logger().Error("error migrating config", "error", err)
 	}
 
-	if loadUserConfig && gotil.FileExists(usrConfigFile) {
-		log.WithField("path", usrConfigFile).Debug("loading user config file")
-		if err := c.ReadConfigFile(usrConfigFile); err != nil {
-			log.WithField("path", usrConfigFile).WithError(err).Debug(
-				"error reading user config file")
-		}
+	if err := c.validateOnLoad(); err != nil {
+		logger().Error("invalid config", "error", err)
 	}
 
+	c.publishSnapshot()
 	return c
 }
 
+func newConfigWithOptions(
+	loadGlobalConfig, loadUserConfig bool,
+	configName, configType string, opts ...ConfigOption) *config {
+	return newConfig(append([]ConfigOption{
+		WithGlobalConfig(loadGlobalConfig),
+		WithUserConfig(loadUserConfig),
+		WithConfigName(configName),
+		WithConfigType(configType),
+	}, opts...)...)
+}
+
+// loadConfigFiles reads the config file from the specified directory followed
+// by the config file for the config's profile, if a profile is set.
+func (c *config) loadConfigFiles(dirPath, desc string) {
+	filePaths := []string{
+		fmt.Sprintf("%s/%s.%s", dirPath, c.configName, c.configType),
+	}
+	if c.profile != "" {
+		filePaths = append(filePaths, fmt.Sprintf(
+			"%s/%s.%s.%s", dirPath, c.configName, c.profile, c.configType))
+	}
+	for _, filePath := range filePaths {
+		if !gotil.FileExists(filePath) {
+			continue
+		}
+		logger().Debug(
+			fmt.Sprintf("loading %s config file", desc), "path", filePath)
+		if err := c.ReadConfigFile(filePath); err != nil {
+			logger().Debug(
+				fmt.Sprintf("error reading %s config file", desc),
+				"path", filePath, "error", err)
+		}
+	}
+}
+
 func (c *config) marshalJSON(secure bool) ([]byte, error) {
 	var m map[string]interface{}
 	if secure {
 		var err error
-		if m, err = c.allSecureSettings(); err != nil {
+		if m, err = c.allSecureSettings(secureValuesDelete); err != nil {
 			return nil, err
 		}
 	} else {
@@ -473,7 +1039,7 @@ func (c *config) marshalIndentJSON(secure bool) ([]byte, error) {
 	var m map[string]interface{}
 	if secure {
 		var err error
-		if m, err = c.allSecureSettings(); err != nil {
+		if m, err = c.allSecureSettings(secureValuesDelete); err != nil {
 			return nil, err
 		}
 	} else {
@@ -482,7 +1048,8 @@ func (c *config) marshalIndentJSON(secure bool) ([]byte, error) {
 	return json.MarshalIndent(m, "", "  ")
 }
 
-func (c *config) allSecureSettings() (map[string]interface{}, error) {
+func (c *config) allSecureSettings(
+	mode secureValuesMode) (map[string]interface{}, error) {
 	buf, err := json.Marshal(c.allSettings())
 	if err != nil {
 		return nil, err
@@ -492,38 +1059,77 @@ func (c *config) allSecureSettings() (map[string]interface{}, error) {
 		return nil, err
 	}
 
-	c.deleteSecureValues("", m)
+	c.deleteSecureValues("", m, mode)
 
 	return m, err
 }
 
-func (c *config) deleteSecureValues(prefix string, m map[string]interface{}) {
+// secureValuesMode is how the values of secure keys are removed from settings.
+type secureValuesMode int
+
+const (
+	// secureValuesDelete deletes the secure keys.
+	secureValuesDelete secureValuesMode = iota
+
+	// secureValuesMask replaces the values of the secure keys with
+	// types.RedactedValue.
+	secureValuesMask
+)
+
+func (c *config) deleteSecureValues(
+	prefix string, m map[string]interface{}, mode secureValuesMode) {
 	for k, v := range m {
 		kk := k
 		if prefix != "" {
 			kk = fmt.Sprintf("%s.%s", prefix, k)
 		}
 		if c.isSecureKey(kk) {
+			if mode == secureValuesMask {
+				m[k] = types.RedactedValue
+				continue
+			}
 			delete(m, k)
 		}
 		switch tv := v.(type) {
 		case map[string]interface{}:
-			c.deleteSecureValues(kk, tv)
+			c.deleteSecureValues(kk, tv, mode)
 		}
 	}
 }
 
-func (c *config) processRegistrations() {
+// processRegistrations binds the registrations' keys and reads their default
+// settings. If AllowDuplicateKeys is false a *DuplicateKeyError is returned
+// for the first key registered by more than one registration.
+func (c *config) processRegistrations() error {
 	registrationsRWL.RLock()
-	defer registrationsRWL.RUnlock()
-
-	for _, r := range registrations {
+	regs := append([]types.ConfigRegistration{}, registrations...)
+	registrationsRWL.RUnlock()
+
+	keys := map[string]string{}
+	for _, r := range append(regs, c.registrations...) {
+		if !AllowDuplicateKeys {
+			if err := checkDuplicateKeys(r, keys); err != nil {
+				return err
+			}
+		}
+		for k, msg := range r.Deprecations() {
+			c.Deprecate(k, msg)
+		}
 		c.processRegKeys(r)
 		if y := r.YAML(); y != "" {
-			log.Debugf("loading yaml for %s", r.Name())
-			c.ReadConfig(bytes.NewReader([]byte(y)))
+			logger().Debug(fmt.Sprintf("loading yaml for %s", r.Name()))
+			c.readConfig(bytes.NewReader([]byte(y)), "yml")
+		}
+		if c.profile == "" {
+			continue
+		}
+		if y := r.ProfileYAML(c.profile); y != "" {
+			logger().Debug(fmt.Sprintf(
+				"loading %s yaml for %s", c.profile, r.Name()))
+			c.readConfig(bytes.NewReader([]byte(y)), "yml")
 		}
 	}
+	return nil
 }
 
 // flattenEnvVars returns a map of configuration keys coming from a config
@@ -539,13 +1145,14 @@ func (c *config) flattenEnvVars(
 		} else {
 			kk = fmt.Sprintf("%s.%s", prefix, k)
 		}
-		ek := strings.ToUpper(strings.Replace(kk, ".", "_", -1))
+		ek := c.envVarName(
+			strings.ToUpper(strings.Replace(kk, ".", "_", -1)))
 
 		if LogFlattenEnvVars {
-			log.WithFields(log.Fields{
-				"key":   kk,
-				"value": v,
-			}).Debug("flattening env vars")
+			logger().Debug("flattening env vars",
+				"key", kk,
+				"value", v,
+			)
 		}
 
 		switch vt := v.(type) {
@@ -556,7 +1163,9 @@ func (c *config) flattenEnvVars(
 			for _, iv := range vt {
 				vArr = append(vArr, iv.(string))
 			}
-			envVars[ek] = strings.Join(vArr, " ")
+			envVars[ek] = strings.Join(vArr, c.keyDelim(kk))
+		case []string:
+			envVars[ek] = strings.Join(vt, c.keyDelim(kk))
 		case map[string]interface{}:
 			c.flattenEnvVars(kk, vt, envVars)
 		case bool:
@@ -568,11 +1177,21 @@ func (c *config) flattenEnvVars(
 	return
 }
 
+// allSettings returns the config's settings while holding the config's read
+// lock.
 func (c *config) allSettings() map[string]interface{} {
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	return c.allSettingsLocked()
+}
+
+// allSettingsLocked returns the config's settings. The config's lock must be
+// held.
+func (c *config) allSettingsLocked() map[string]interface{} {
 	as := map[string]interface{}{}
 	ms := map[string]map[string]interface{}{}
 
-	for k, v := range c.v.AllSettings() {
+	for k, v := range c.b.AllSettings() {
 		switch tv := v.(type) {
 		case nil:
 			continue
@@ -589,11 +1208,11 @@ func (c *config) allSettings() map[string]interface{} {
 		for fk, fv := range flat {
 			if asv, ok := as[fk]; ok && reflect.DeepEqual(asv, fv) {
 				if LogFlattenEnvVars {
-					log.WithFields(log.Fields{
-						"key":     fk,
-						"valAll":  asv,
-						"valFlat": fv,
-					}).Debug("deleting duplicate flat val")
+					logger().Debug("deleting duplicate flat val",
+						"key", fk,
+						"valAll", asv,
+						"valFlat", fv,
+					)
 				}
 				delete(as, fk)
 			}
@@ -617,10 +1236,16 @@ func flattenArrayKeys(
 	}
 }
 
+// flattenMapKeys adds the leaf values from a tree of nested maps to the flat
+// map, keyed by their dot-notation names. If the prefix is not empty it is
+// prepended to each of the keys.
 func flattenMapKeys(
 	prefix string, m map[string]interface{}, flat map[string]interface{}) {
 	for k, v := range m {
-		kk := fmt.Sprintf("%s.%s", prefix, k)
+		kk := k
+		if prefix != "" {
+			kk = fmt.Sprintf("%s.%s", prefix, k)
+		}
 		switch vt := v.(type) {
 		case map[string]interface{}:
 			flattenMapKeys(kk, vt, flat)
@@ -630,34 +1255,140 @@ func flattenMapKeys(
 	}
 }
 
-func loadEtcEnvironment() {
-	lr, _ := gotil.LineReaderFrom("/etc/environment")
-	if lr == nil {
-		return
-	}
-	for l := range lr {
-		m := envVarRx.FindStringSubmatch(l)
-		if m == nil || len(m) < 3 || os.Getenv(m[1]) != "" {
-			continue
-		}
-		os.Setenv(m[1], m[2])
-	}
+// isSecureKey returns a flag indicating whether or not the key is secure once
+// it is qualified with the config's sub-config prefix and its parent's scope.
+func (c *config) isSecureKey(k string) bool {
+	return isSecureConfigKey(c, k)
 }
 
-func (c *config) isSecureKey(k string) bool {
+func isSecureKey(k string) bool {
 	secureKeysRWL.RLock()
 	defer secureKeysRWL.RUnlock()
 	kn := strings.ToLower(k)
 	_, ok := secureKeys[kn]
 	if LogSecureKey {
-		log.WithFields(log.Fields{
-			"keyName":  kn,
-			"isSecure": ok,
-		}).Debug("isSecureKey")
+		logger().Debug("isSecureKey",
+			"keyName", kn,
+			"isSecure", ok,
+		)
 	}
 	return ok
 }
 
+// isSecureConfigKey returns a flag indicating whether or not the key read from
+// the config is a secure key. The key is qualified with the scopes and the
+// sub-config prefixes of the config before it is checked.
+func isSecureConfigKey(c types.Config, k string) bool {
+	rc, qk := qualifyConfigKey(c, k)
+	return isSecureKey(qk) || (rc != nil && rc.isMergedSecureKey(qk))
+}
+
+// qualifiedConfigKey returns the name of the key read from the config
+// qualified with the scopes and the sub-config prefixes of the config.
+func qualifiedConfigKey(c types.Config, k string) string {
+	_, qk := qualifyConfigKey(c, k)
+	return qk
+}
+
+// qualifyConfigKey returns the config that stores the settings of the
+// specified config and the name of the key read from the specified config
+// qualified with its scopes and sub-config prefixes. The returned config is
+// nil if the settings are not stored in a config instance.
+func qualifyConfigKey(c types.Config, k string) (*config, string) {
+	for {
+		switch tc := c.(type) {
+		case *scopedConfig:
+			k = fmt.Sprintf("%s.%s", tc.scope, k)
+			c = tc.Config
+		case *scopedStrictConfig:
+			k = fmt.Sprintf("%s.%s", tc.scope, k)
+			c = tc.Config
+		case *readOnlyConfig:
+			c = tc.Config
+		case *childConfig:
+			c = tc.parent
+		case *config:
+			if tc.subParent == nil {
+				if tc.keyPrefix != "" {
+					k = fmt.Sprintf("%s.%s", tc.keyPrefix, k)
+				}
+				return tc, k
+			}
+			k = fmt.Sprintf("%s.%s", tc.subPrefix, k)
+			c = tc.subParent
+		default:
+			return nil, k
+		}
+	}
+}
+
+// redactSecureValues returns a copy of the key's value with the values of the
+// key's secure descendants replaced with the mask, or removed if the mask is
+// nil. A value that is not a map is returned unchanged.
+func redactSecureValues(
+	c types.Config, k string, v interface{}, mask interface{}) interface{} {
+
+	m, ok := toStringMap(v)
+	if !ok {
+		return v
+	}
+	redactSecureMap(c, k, m, mask)
+	return m
+}
+
+func redactSecureMap(
+	c types.Config, prefix string, m map[string]interface{}, mask interface{}) {
+
+	for k, v := range m {
+		kk := k
+		if prefix != "" {
+			kk = fmt.Sprintf("%s.%s", prefix, k)
+		}
+		if isSecureConfigKey(c, kk) {
+			if mask == nil {
+				delete(m, k)
+			} else {
+				m[k] = mask
+			}
+			continue
+		}
+		if tv, ok := v.(map[string]interface{}); ok {
+			redactSecureMap(c, kk, tv, mask)
+		}
+	}
+}
+
+// isSupportedConfigFormat returns a flag indicating whether or not the format
+// is one that may be read into a config instance.
+func isSupportedConfigFormat(format string) bool {
+	switch format {
+	case "yml", "yaml", "json", "toml":
+		return true
+	}
+	return false
+}
+
+// detectConfigFormat inspects the first significant line of the buffer to
+// determine whether it contains JSON, TOML, or YAML. If the format cannot be
+// determined the default format is returned.
+func detectConfigFormat(buf []byte, defaultFormat string) string {
+	for _, l := range strings.Split(string(buf), "\n") {
+		l = strings.TrimSpace(l)
+		switch {
+		case l == "", strings.HasPrefix(l, "#"):
+			continue
+		case strings.HasPrefix(l, "{"):
+			return "json"
+		case tomlTableRx.MatchString(l), tomlKeyValRx.MatchString(l):
+			return "toml"
+		case l == "---", strings.HasPrefix(l, "- "), yamlKeyValRx.MatchString(l):
+			return "yml"
+		}
+		return defaultFormat
+	}
+	return defaultFormat
+}
+
 // ValidateYAML verifies the YAML in the stream is valid.
 func ValidateYAML(r io.Reader) (map[interface{}]interface{}, error) {
 	b, err := ioutil.ReadAll(r)
@@ -677,3 +1408,34 @@ func ValidateYAMLString(s string) (map[interface{}]interface{}, error) {
 	b.WriteString(s)
 	return ValidateYAML(b)
 }
+
+// ValidateJSON verifies the JSON in the stream is a valid JSON object. If the
+// JSON is malformed the returned error includes the byte offset of the syntax
+// error.
+func ValidateJSON(r io.Reader) (map[string]interface{}, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		if se, ok := err.(*json.SyntaxError); ok {
+			return nil, goof.WithFieldE(
+				"offset", se.Offset,
+				fmt.Sprintf("invalid json at byte offset %d", se.Offset),
+				err)
+		}
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, goof.WithField(
+			"type", fmt.Sprintf("%T", v), "json root is not an object")
+	}
+	return m, nil
+}
+
+// ValidateJSONString verifies the JSON string is a valid JSON object.
+func ValidateJSONString(s string) (map[string]interface{}, error) {
+	return ValidateJSON(strings.NewReader(s))
+}
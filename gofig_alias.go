@@ -0,0 +1,181 @@
+package gofig
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/akutz/gofig/types"
+)
+
+var (
+	aliases    = map[string]string{}
+	aliasesRWL = &sync.RWMutex{}
+)
+
+// Alias registers oldKey as an alias for newKey. Reading or setting oldKey
+// reads or sets newKey, and values for oldKey that appear in config files are
+// read as values for newKey. If newKey is a secure key then so is oldKey.
+func Alias(newKey, oldKey string) {
+	nk := strings.ToLower(newKey)
+	ok := strings.ToLower(oldKey)
+	if nk == ok {
+		return
+	}
+
+	aliasesRWL.Lock()
+	aliases[ok] = nk
+	aliasesRWL.Unlock()
+
+	if LogRegKey {
+		logger().Debug("aliasing key",
+			"newKey", nk,
+			"oldKey", ok,
+		)
+	}
+
+	if isSecureKey(nk) {
+		secureKey(&configRegKey{keyType: types.SecureString, keyName: ok})
+	}
+}
+
+// realKey returns the canonical name of the key if the key, once transformed
+// by the key transformer, is an alias or another spelling of a registered key,
+// otherwise the transformed key is returned.
+func realKey(k string) string {
+	return normalizeKey(aliasedKey(transformKey(k)))
+}
+
+// aliasedKey returns the canonical name of the key if the key is an alias,
+// otherwise the key is returned unchanged.
+func aliasedKey(k string) string {
+	aliasesRWL.RLock()
+	defer aliasesRWL.RUnlock()
+	lk := strings.ToLower(k)
+	nk, ok := aliases[lk]
+	if !ok {
+		return k
+	}
+	for x := 0; x < len(aliases); x++ {
+		if nnk, ok := aliases[nk]; ok && nnk != lk {
+			nk = nnk
+			continue
+		}
+		break
+	}
+	return nk
+}
+
+func hasAliases() bool {
+	aliasesRWL.RLock()
+	defer aliasesRWL.RUnlock()
+	return len(aliases) > 0
+}
+
+// resolveAliases returns a config stream in which the values of any aliased
+// keys have been moved to their canonical keys. If the stream contains no
+// aliased keys it is returned unchanged.
+func (c *config) resolveAliases(
+	in io.Reader, format string) (io.Reader, string, error) {
+
+	buf, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "" {
+		format = c.configType
+	}
+	tv := viper.New()
+	tv.SetConfigType(format)
+	if err := tv.ReadConfig(bytes.NewReader(buf)); err != nil {
+		return nil, "", err
+	}
+
+	m := tv.AllSettings()
+	moved := false
+	for _, ok := range aliasKeys() {
+		v, exists := deleteNestedValue(m, ok)
+		if !exists {
+			continue
+		}
+		nk := realKey(ok)
+		if _, exists := getNestedValue(m, nk); !exists {
+			setNestedValue(m, nk, v)
+		}
+		moved = true
+	}
+	if !moved {
+		return bytes.NewReader(buf), format, nil
+	}
+
+	if buf, err = yaml.Marshal(m); err != nil {
+		return nil, "", err
+	}
+	return bytes.NewReader(buf), "yml", nil
+}
+
+func aliasKeys() []string {
+	aliasesRWL.RLock()
+	defer aliasesRWL.RUnlock()
+	keys := []string{}
+	for k := range aliases {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// getNestedValue returns the value at the dot-notation path in a tree of
+// nested maps.
+func getNestedValue(
+	m map[string]interface{}, k string) (interface{}, bool) {
+	kp := strings.Split(k, ".")
+	for _, s := range kp[:len(kp)-1] {
+		pm, ok := m[s].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = pm
+	}
+	v, ok := m[kp[len(kp)-1]]
+	return v, ok
+}
+
+// setNestedValue sets the value at the dot-notation path in a tree of nested
+// maps, creating any intermediate maps as necessary.
+func setNestedValue(m map[string]interface{}, k string, v interface{}) {
+	kp := strings.Split(k, ".")
+	for _, s := range kp[:len(kp)-1] {
+		pm, ok := m[s].(map[string]interface{})
+		if !ok {
+			pm = map[string]interface{}{}
+			m[s] = pm
+		}
+		m = pm
+	}
+	m[kp[len(kp)-1]] = v
+}
+
+// deleteNestedValue removes and returns the value at the dot-notation path in
+// a tree of nested maps.
+func deleteNestedValue(
+	m map[string]interface{}, k string) (interface{}, bool) {
+	kp := strings.Split(k, ".")
+	for _, s := range kp[:len(kp)-1] {
+		pm, ok := m[s].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = pm
+	}
+	v, ok := m[kp[len(kp)-1]]
+	if ok {
+		delete(m, kp[len(kp)-1])
+	}
+	return v, ok
+}
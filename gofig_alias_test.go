@@ -0,0 +1,105 @@
+package gofig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestAlias(t *testing.T) {
+	newConfigDirs("TestAlias", t)
+	wipeEnv()
+	TestHook(t)
+
+	Alias("testAlias.requestTimeout", "testAlias.timeout")
+
+	c := New()
+	c.Set("testAlias.timeout", 5)
+	assert.Equal(t, 5, c.GetInt("testAlias.requestTimeout"))
+	assert.Equal(t, 5, c.GetInt("testAlias.timeout"))
+	assert.True(t, c.IsSet("testAlias.timeout"))
+
+	c.Set("testAlias.requestTimeout", 10)
+	assert.Equal(t, 10, c.GetInt("testAlias.timeout"))
+	assert.Equal(t, 10, c.Scope("testAlias").GetInt("timeout"))
+
+	ak := c.AllKeys()
+	assert.Contains(t, ak, "testalias.requesttimeout")
+	assert.NotContains(t, ak, "testalias.timeout")
+	for _, ev := range c.EnvVars() {
+		assert.False(t, strings.HasPrefix(ev, "TESTALIAS_TIMEOUT="))
+	}
+}
+
+func TestAliasReadConfig(t *testing.T) {
+	newConfigDirs("TestAliasReadConfig", t)
+	wipeEnv()
+	TestHook(t)
+
+	Alias("testAlias.logging.level", "testAlias.logLevel")
+
+	c := New()
+	err := c.ReadConfig(bytes.NewReader([]byte(`
+testAlias:
+  logLevel: debug
+  host: tcp://:7979
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertString(t, c, "testAlias.logging.level", "debug")
+	assertString(t, c, "testAlias.logLevel", "debug")
+	assertString(t, c, "testAlias.host", "tcp://:7979")
+	assert.NotContains(t, c.AllKeys(), "testalias.loglevel")
+
+	err = c.ReadConfig(bytes.NewReader([]byte(`
+testAlias:
+  logLevel: info
+  logging:
+    level: warn
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c, "testAlias.logLevel", "warn")
+}
+
+func TestAliasRegistration(t *testing.T) {
+	newConfigDirs("TestAliasRegistration", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Alias")
+	r.Key(types.SecureString, "", "", "", "testAlias.credentials.password",
+		"testAliasCredentialsPassword", "TEST_ALIAS_CREDENTIALS_PASSWORD",
+		"testAlias.password")
+
+	for k := range r.Keys() {
+		assert.Equal(t, []string{"testAlias.password"}, k.Aliases())
+	}
+	assert.True(t, isSecureKey("testAlias.password"))
+
+	c := New()
+	c.Set("testAlias.password", "i should be hidden")
+	assertString(t, c, "testAlias.credentials.password", "i should be hidden")
+
+	jsonStr, err := c.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, strings.Contains(jsonStr, "i should be hidden"))
+}
+
+func TestAliasTestHook(t *testing.T) {
+	t.Run("alias", func(t *testing.T) {
+		TestHook(t)
+		Alias("testAlias.hook.new", "testAlias.hook.old")
+		assert.Equal(t, "testalias.hook.new", aliasedKey("testAlias.hook.old"))
+	})
+	assert.Equal(t, "testAlias.hook.old", aliasedKey("testAlias.hook.old"))
+}
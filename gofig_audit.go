@@ -0,0 +1,184 @@
+package gofig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akutz/gofig/types"
+)
+
+// pkgPath is the import path of this package.
+var pkgPath = reflect.TypeOf(config{}).PkgPath()
+
+func (c *config) SetAuditLog(al types.AuditLog) {
+	c.auditLogRWL.Lock()
+	defer c.auditLogRWL.Unlock()
+	c.auditLog = al
+}
+func (c *scopedConfig) SetAuditLog(al types.AuditLog) {
+	c.Config.SetAuditLog(al)
+}
+
+func (c *config) getAuditLog() types.AuditLog {
+	c.auditLogRWL.RLock()
+	defer c.auditLogRWL.RUnlock()
+	return c.auditLog
+}
+
+func (c *config) hasAuditLog() bool {
+	return c.getAuditLog() != nil
+}
+
+// recordChange records the change to the key with the config's audit log if
+// the old and new values differ.
+func (c *config) recordChange(
+	k string, oldVal, newVal interface{}, src types.ConfigSource) {
+
+	al := c.getAuditLog()
+	if al == nil || reflect.DeepEqual(oldVal, newVal) {
+		return
+	}
+	k = strings.ToLower(k)
+	if isSecureKey(k) {
+		if oldVal != nil {
+			oldVal = types.SecureValue
+		}
+		if newVal != nil {
+			newVal = types.SecureValue
+		}
+	}
+	al.Record(types.AuditEvent{
+		Timestamp:  time.Now(),
+		Key:        k,
+		OldValue:   oldVal,
+		NewValue:   newVal,
+		Source:     src,
+		CallerInfo: auditCallerInfo(),
+	})
+}
+
+// recordChanges compares two flattened views of the config's settings and
+// records every key that was added, removed, or modified with the config's
+// audit log.
+func (c *config) recordChanges(
+	before, after map[string]interface{}, src types.ConfigSource) {
+
+	if !c.hasAuditLog() {
+		return
+	}
+	for k, v := range after {
+		c.recordChange(k, before[k], v, src)
+	}
+	for k, v := range before {
+		if _, ok := after[k]; !ok {
+			c.recordChange(k, v, nil, src)
+		}
+	}
+}
+
+// auditCallerInfo returns the file and line of the first caller on the stack
+// outside of this package. Test files are treated as callers outside of this
+// package.
+func auditCallerInfo() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, pkgPath+".") ||
+			strings.HasSuffix(f.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// MemoryAuditLog is an audit log that stores events in memory. Once the
+// log's capacity is reached the oldest event is discarded for each new
+// event.
+type MemoryAuditLog struct {
+	capacity int
+	events   []types.AuditEvent
+	rwl      *sync.RWMutex
+}
+
+// NewMemoryAuditLog returns a new audit log that stores up to the specified
+// number of events in memory. A capacity less than or equal to zero means
+// the number of stored events is not limited.
+func NewMemoryAuditLog(capacity int) *MemoryAuditLog {
+	return &MemoryAuditLog{capacity: capacity, rwl: &sync.RWMutex{}}
+}
+
+// Record stores the event.
+func (l *MemoryAuditLog) Record(event types.AuditEvent) {
+	l.rwl.Lock()
+	defer l.rwl.Unlock()
+	if l.capacity > 0 && len(l.events) >= l.capacity {
+		l.events = l.events[len(l.events)-l.capacity+1:]
+	}
+	l.events = append(l.events, event)
+}
+
+// Events returns a copy of the stored events, oldest first.
+func (l *MemoryAuditLog) Events() []types.AuditEvent {
+	l.rwl.RLock()
+	defer l.rwl.RUnlock()
+	events := make([]types.AuditEvent, len(l.events))
+	copy(events, l.events)
+	return events
+}
+
+// JSONAuditLog is an audit log that writes each event to a writer as a line
+// of JSON.
+type JSONAuditLog struct {
+	w   io.Writer
+	rwl *sync.RWMutex
+}
+
+// jsonAuditEvent is the JSON representation of an audit event.
+type jsonAuditEvent struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	Key        string      `json:"key"`
+	OldValue   interface{} `json:"oldValue"`
+	NewValue   interface{} `json:"newValue"`
+	Source     string      `json:"source"`
+	CallerInfo string      `json:"callerInfo,omitempty"`
+}
+
+// NewJSONAuditLog returns a new audit log that writes newline-delimited JSON
+// to the writer.
+func NewJSONAuditLog(w io.Writer) *JSONAuditLog {
+	return &JSONAuditLog{w: w, rwl: &sync.RWMutex{}}
+}
+
+// Record writes the event to the log's writer.
+func (l *JSONAuditLog) Record(event types.AuditEvent) {
+	buf, err := json.Marshal(&jsonAuditEvent{
+		Timestamp:  event.Timestamp,
+		Key:        event.Key,
+		OldValue:   event.OldValue,
+		NewValue:   event.NewValue,
+		Source:     event.Source.String(),
+		CallerInfo: event.CallerInfo,
+	})
+	if err != nil {
+		logger().Error("error marshaling audit event",
+			"key", event.Key, "error", err)
+		return
+	}
+	buf = append(buf, '\n')
+	l.rwl.Lock()
+	defer l.rwl.Unlock()
+	if _, err := l.w.Write(buf); err != nil {
+		logger().Error("error writing audit event",
+			"key", event.Key, "error", err)
+	}
+}
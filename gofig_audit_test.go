@@ -0,0 +1,104 @@
+package gofig
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestAuditLog(t *testing.T) {
+	newConfigDirs("TestAuditLog", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Audit")
+	r.Key(types.String, "", "localhost", "The host", "testAudit.host")
+	r.Key(types.SecureString, "", "", "The password", "testAudit.password")
+	Register(r)
+
+	c := New()
+	al := NewMemoryAuditLog(0)
+	c.SetAuditLog(al)
+
+	c.Set("testAudit.host", "127.0.0.1")
+	c.Set("testAudit.host", "127.0.0.1")
+	c.Scope("testAudit").Set("password", "secret")
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(
+		"testAudit:\n  host: 10.0.0.1\n"))))
+
+	events := al.Events()
+	if !assert.Len(t, events, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, "testaudit.host", events[0].Key)
+	assert.Equal(t, "localhost", events[0].OldValue)
+	assert.Equal(t, "127.0.0.1", events[0].NewValue)
+	assert.Equal(t, types.Override, events[0].Source)
+	assert.False(t, events[0].Timestamp.IsZero())
+	assert.Contains(t, events[0].CallerInfo, "gofig_audit_test.go:")
+
+	assert.Equal(t, "testaudit.password", events[1].Key)
+	assert.Equal(t, types.SecureValue, events[1].NewValue)
+	assert.Contains(t, events[1].CallerInfo, "gofig_audit_test.go:")
+
+	c.SetAuditLog(nil)
+	c.Set("testAudit.host", "localhost")
+	assert.Len(t, al.Events(), 2)
+}
+
+func TestAuditLogReadConfig(t *testing.T) {
+	newConfigDirs("TestAuditLogReadConfig", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Audit")
+	r.Key(types.String, "", "localhost", "The host", "testAudit.host")
+	Register(r)
+
+	c := New()
+	buf := &bytes.Buffer{}
+	c.SetAuditLog(NewJSONAuditLog(buf))
+
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(
+		"testAudit:\n  host: 10.0.0.1\n"))))
+	assert.NoError(t, c.Transaction(func(tx types.ConfigTx) error {
+		tx.Set("testAudit.host", "10.0.0.2")
+		return nil
+	}))
+
+	var lines []map[string]interface{}
+	s := bufio.NewScanner(buf)
+	for s.Scan() {
+		m := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(s.Bytes(), &m))
+		lines = append(lines, m)
+	}
+	if !assert.Len(t, lines, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, "testaudit.host", lines[0]["key"])
+	assert.Equal(t, "localhost", lines[0]["oldValue"])
+	assert.Equal(t, "10.0.0.1", lines[0]["newValue"])
+	assert.Equal(t, "File", lines[0]["source"])
+	assert.True(t, strings.Contains(
+		lines[0]["callerInfo"].(string), "gofig_audit_test.go:"))
+	assert.Equal(t, "10.0.0.2", lines[1]["newValue"])
+	assert.Equal(t, "Override", lines[1]["source"])
+}
+
+func TestMemoryAuditLogCapacity(t *testing.T) {
+	al := NewMemoryAuditLog(2)
+	for _, k := range []string{"a", "b", "c"} {
+		al.Record(types.AuditEvent{Key: k})
+	}
+	events := al.Events()
+	assert.Len(t, events, 2)
+	assert.Equal(t, "b", events[0].Key)
+	assert.Equal(t, "c", events[1].Key)
+}
@@ -0,0 +1,34 @@
+package gofig
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/akutz/gofig/backend"
+)
+
+// WithBackend configures the config to store its settings in the specified
+// backend instead of the default backend, which is backed by Viper. Keys are
+// bound to flags only if the backend implements backend.FlagBinder.
+func WithBackend(b backend.Backend) ConfigOption {
+	return func(c *config) {
+		if b != nil {
+			c.b = b
+		}
+	}
+}
+
+// bindFlag binds the key to the flag if the config's backend supports flags.
+func (c *config) bindFlag(k string, f *pflag.Flag) {
+	if fb, ok := c.b.(backend.FlagBinder); ok {
+		fb.BindFlag(k, f)
+	}
+}
+
+// backendKeys returns the dot-notation names of the keys in the config's
+// backend.
+func (c *config) backendKeys() []string {
+	if kl, ok := c.b.(backend.KeyLister); ok {
+		return kl.AllKeys()
+	}
+	return flatMapKeys(c.b.AllSettings())
+}
@@ -0,0 +1,49 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/backend"
+	"github.com/akutz/gofig/types"
+)
+
+func TestWithBackend(t *testing.T) {
+	newConfigDirs("TestWithBackend", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Backend")
+	r.Key(types.String, "", "localhost", "The host", "testBackend.host")
+	r.Key(types.Int, "", 8080, "The port", "testBackend.port")
+	Register(r)
+
+	b := backend.NewMapBackend()
+	c := New(WithBackend(b))
+	assert.Equal(t, "localhost", c.GetString("testBackend.host"))
+	assert.Equal(t, 8080, c.GetInt("testBackend.port"))
+
+	assert.NoError(t, c.ReadConfig(
+		bytes.NewReader([]byte("testBackend:\n  port: 9090\n"))))
+	assert.Equal(t, 9090, c.GetInt("testBackend.port"))
+	assert.EqualValues(t, 9090, b.Get("testBackend.port"))
+
+	c.Set("testBackend.host", "127.0.0.1")
+	assert.Equal(t, "127.0.0.1", c.GetString("testBackend.host"))
+	assert.Equal(t, "127.0.0.1", b.Get("testBackend.host"))
+	assert.True(t, c.IsSet("testBackend.host"))
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", cc.GetString("testBackend.host"))
+	assert.IsType(t, b, cc.(*config).b)
+
+	assert.NoError(t, c.Reset())
+	assert.Equal(t, "localhost", c.GetString("testBackend.host"))
+	assert.IsType(t, b, c.(*config).b)
+
+	c = New(WithBackend(nil))
+	assert.IsType(t, &backend.ViperBackend{}, c.(*config).b)
+}
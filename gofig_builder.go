@@ -0,0 +1,142 @@
+package gofig
+
+import (
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// ConfigBuilder constructs Config instances. A ConfigBuilder is created with
+// Builder and its methods may be chained, for example:
+//
+//	c, err := gofig.Builder().
+//		UserConfigPath("/home/akutz/.myapp").
+//		ConfigName("myapp").
+//		Profile("prod").
+//		Build()
+type ConfigBuilder struct {
+	globalConfigPath string
+	userConfigPath   string
+	configName       string
+	configType       string
+	registrations    []types.ConfigRegistration
+	profile          *string
+	strictMode       *bool
+	err              error
+}
+
+// Builder returns a new ConfigBuilder. The config it builds reads the
+// registrations and uses the config name "config" and the config type "yml"
+// unless they are changed. The global and user config files are read only if
+// their directories are set with GlobalConfigPath and UserConfigPath.
+func Builder() *ConfigBuilder {
+	return &ConfigBuilder{configName: "config", configType: "yml"}
+}
+
+// GlobalConfigPath sets the path of the directory from which the global
+// config file is read.
+func (b *ConfigBuilder) GlobalConfigPath(p string) *ConfigBuilder {
+	b.globalConfigPath = p
+	return b
+}
+
+// UserConfigPath sets the path of the directory from which the user config
+// file is read.
+func (b *ConfigBuilder) UserConfigPath(p string) *ConfigBuilder {
+	b.userConfigPath = p
+	return b
+}
+
+// ConfigName sets the name of the config file without its extension.
+func (b *ConfigBuilder) ConfigName(n string) *ConfigBuilder {
+	b.configName = n
+	return b
+}
+
+// ConfigType sets the format of the config file: yml, yaml, json, or toml.
+func (b *ConfigBuilder) ConfigType(t string) *ConfigBuilder {
+	if !isSupportedConfigFormat(t) && b.err == nil {
+		b.err = goof.WithField("format", t, "unsupported config format")
+	}
+	b.configType = t
+	return b
+}
+
+// WithRegistration adds a registration that is read by the config in
+// addition to the registrations registered with the config package.
+func (b *ConfigBuilder) WithRegistration(
+	r types.ConfigRegistration) *ConfigBuilder {
+	if r == nil {
+		if b.err == nil {
+			b.err = goof.New("config registration is nil")
+		}
+		return b
+	}
+	b.registrations = append(b.registrations, r)
+	return b
+}
+
+// Profile sets the name of the config's profile instead of the profile set
+// with SetProfile.
+func (b *ConfigBuilder) Profile(p string) *ConfigBuilder {
+	b.profile = &p
+	return b
+}
+
+// StrictMode enables or disables strict mode for the config instead of using
+// the value of the StrictMode variable.
+func (b *ConfigBuilder) StrictMode(enabled bool) *ConfigBuilder {
+	b.strictMode = &enabled
+	return b
+}
+
+// Build returns a new Config instance with the builder's settings. An error
+// is returned if one of the builder's settings is invalid.
+func (b *ConfigBuilder) Build() (types.Config, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.configName == "" {
+		return nil, goof.New("config name is empty")
+	}
+
+	opts := []ConfigOption{
+		WithGlobalConfig(b.globalConfigPath != ""),
+		WithUserConfig(b.userConfigPath != ""),
+		WithConfigName(b.configName),
+		WithConfigType(b.configType),
+		withRegistrations(b.registrations...),
+	}
+	if b.globalConfigPath != "" {
+		opts = append(opts, withGlobalConfigPath(b.globalConfigPath))
+	}
+	if b.userConfigPath != "" {
+		opts = append(opts, withUserConfigPath(b.userConfigPath))
+	}
+	if b.profile != nil {
+		opts = append(opts, WithProfile(*b.profile))
+	}
+	if b.strictMode != nil {
+		opts = append(opts, withStrictMode(*b.strictMode))
+	}
+
+	return newConfig(opts...), nil
+}
+
+func withGlobalConfigPath(p string) ConfigOption {
+	return func(c *config) {
+		c.globalConfigPath = p
+	}
+}
+
+func withUserConfigPath(p string) ConfigOption {
+	return func(c *config) {
+		c.userConfigPath = p
+	}
+}
+
+func withRegistrations(regs ...types.ConfigRegistration) ConfigOption {
+	return func(c *config) {
+		c.registrations = append(c.registrations, regs...)
+	}
+}
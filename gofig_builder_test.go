@@ -0,0 +1,89 @@
+package gofig
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestBuilder(t *testing.T) {
+	newConfigDirs("TestBuilder", t)
+	wipeEnv()
+	TestHook(t)
+
+	dir, err := ioutil.TempDir("", "gofig-test-TestBuilder")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	etcDir := filepath.Join(dir, "etc")
+	usrDir := filepath.Join(dir, "usr")
+	assert.NoError(t, os.MkdirAll(etcDir, 0755))
+	assert.NoError(t, os.MkdirAll(usrDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(etcDir, "myapp.yml"),
+		[]byte("testBuilder:\n  host: global\n  port: 80\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(usrDir, "myapp.yml"),
+		[]byte("testBuilder:\n  host: user\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(
+		filepath.Join(usrDir, "myapp.prod.yml"),
+		[]byte("testBuilder:\n  user: admin\n"), 0644))
+
+	r := newRegistration("Test Builder")
+	r.Key(types.String, "", "localhost", "", "testBuilder.host")
+	r.Key(types.Int, "", 443, "", "testBuilder.port")
+	r.Key(types.String, "", "", "", "testBuilder.user")
+
+	c, err := Builder().
+		GlobalConfigPath(etcDir).
+		UserConfigPath(usrDir).
+		ConfigName("myapp").
+		ConfigType("yml").
+		WithRegistration(r).
+		Profile("prod").
+		StrictMode(true).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "user", c.GetString("testBuilder.host"))
+	assert.Equal(t, 80, c.GetInt("testBuilder.port"))
+	assert.Equal(t, "admin", c.GetString("testBuilder.user"))
+	assert.Equal(t, "prod", c.GetProfile())
+
+	assert.Error(t, c.ReadConfig(
+		bytes.NewReader([]byte("testBuilder:\n  other: true\n"))))
+	assert.NoError(t, c.ReadConfig(
+		bytes.NewReader([]byte("testBuilder:\n  port: 8080\n"))))
+	assert.Equal(t, 8080, c.GetInt("testBuilder.port"))
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cc.GetInt("testBuilder.port"))
+
+	// the registration belongs only to the built config
+	assert.NotContains(t, RegisteredKeys(), "testBuilder.host")
+	assert.Empty(t, New().GetString("testBuilder.host"))
+}
+
+func TestBuilderDefaults(t *testing.T) {
+	etcFilePath, _ := newConfigDirs("TestBuilderDefaults", t)
+	wipeEnv()
+	assert.NoError(t, ioutil.WriteFile(
+		etcFilePath, []byte("testBuilder:\n  host: global\n"), 0644))
+
+	c, err := Builder().Build()
+	assert.NoError(t, err)
+	assert.Empty(t, c.GetString("testBuilder.host"))
+	assert.Equal(t, "global", New().GetString("testBuilder.host"))
+}
+
+func TestBuilderErrors(t *testing.T) {
+	_, err := Builder().ConfigType("xml").Build()
+	assert.Error(t, err)
+	_, err = Builder().WithRegistration(nil).Build()
+	assert.Error(t, err)
+	_, err = Builder().ConfigName("").Build()
+	assert.Error(t, err)
+}
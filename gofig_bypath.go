@@ -0,0 +1,252 @@
+package gofig
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+var (
+	pathSegmentRx = regexp.MustCompile(`^([^\[\]]+)((?:\[\d+\])*)$`)
+	pathIndexRx   = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+func (c *config) GetByPath(path string) (interface{}, error) {
+	return getByPath(c, path)
+}
+func (c *scopedConfig) GetByPath(path string) (interface{}, error) {
+	return getByPath(c, path)
+}
+
+func (c *config) SetByPath(path string, v interface{}) error {
+	return setByPath(c, path, v)
+}
+func (c *scopedConfig) SetByPath(path string, v interface{}) error {
+	return setByPath(c, path, v)
+}
+
+func getByPath(c types.Config, path string) (interface{}, error) {
+	k, segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	v := c.Get(k)
+	if v == nil {
+		return nil, goof.WithField("path", path, "key not set")
+	}
+	for x, s := range segs {
+		if v, err = pathSegmentValue(v, s); err != nil {
+			return nil, pathError(path, segs[:x+1], err)
+		}
+	}
+	return v, nil
+}
+
+func setByPath(c types.Config, path string, v interface{}) error {
+	k, segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if len(segs) == 0 {
+		c.Set(k, v)
+		return nil
+	}
+
+	root := copyValue(c.Get(k))
+	if root == nil {
+		return goof.WithField("path", path, "key not set")
+	}
+	pv := root
+	for x, s := range segs[:len(segs)-1] {
+		if pv, err = pathSegmentValue(pv, s); err != nil {
+			return pathError(path, segs[:x+1], err)
+		}
+	}
+	if err := setPathSegmentValue(pv, segs[len(segs)-1], v); err != nil {
+		return pathError(path, segs, err)
+	}
+
+	c.Set(k, root)
+	return nil
+}
+
+// parsePath parses a path such as "a.items[0].name" into the config key that
+// precedes the first array index, "a.items", and the remaining path segments,
+// 0 and "name". Array indices are returned as ints and map keys as strings.
+func parsePath(path string) (string, []interface{}, error) {
+	keys := []string{}
+	segs := []interface{}{}
+	for _, p := range strings.Split(path, ".") {
+		m := pathSegmentRx.FindStringSubmatch(p)
+		if m == nil {
+			return "", nil, goof.WithFields(map[string]interface{}{
+				"path":    path,
+				"segment": p,
+			}, "invalid path segment")
+		}
+		if len(segs) == 0 {
+			keys = append(keys, m[1])
+		} else {
+			segs = append(segs, m[1])
+		}
+		for _, im := range pathIndexRx.FindAllStringSubmatch(m[2], -1) {
+			i, err := strconv.Atoi(im[1])
+			if err != nil {
+				return "", nil, goof.WithFields(map[string]interface{}{
+					"path":    path,
+					"segment": p,
+				}, "invalid path index")
+			}
+			segs = append(segs, i)
+		}
+	}
+	return strings.Join(keys, "."), segs, nil
+}
+
+func pathError(path string, segs []interface{}, err error) error {
+	return goof.WithFields(map[string]interface{}{
+		"path":    path,
+		"segment": formatPathSegments(segs),
+	}, err.Error())
+}
+
+func formatPathSegments(segs []interface{}) string {
+	buf := []string{}
+	for _, s := range segs {
+		switch ts := s.(type) {
+		case int:
+			buf = append(buf, fmt.Sprintf("[%d]", ts))
+		default:
+			buf = append(buf, fmt.Sprintf(".%s", ts))
+		}
+	}
+	return strings.TrimPrefix(strings.Join(buf, ""), ".")
+}
+
+// pathSegmentValue returns the array element or map value identified by the
+// path segment.
+func pathSegmentValue(v interface{}, seg interface{}) (interface{}, error) {
+	switch s := seg.(type) {
+	case int:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return nil, goof.New("value is not an array")
+		}
+		if s >= rv.Len() {
+			return nil, goof.New("index out of range")
+		}
+		return rv.Index(s).Interface(), nil
+	case string:
+		switch tv := v.(type) {
+		case map[string]interface{}:
+			if k, ok := mapKey(tv, s); ok {
+				return tv[k], nil
+			}
+			return nil, goof.New("key not found")
+		case map[interface{}]interface{}:
+			for k, mv := range tv {
+				if strings.EqualFold(fmt.Sprintf("%v", k), s) {
+					return mv, nil
+				}
+			}
+			return nil, goof.New("key not found")
+		}
+		return nil, goof.New("value is not a map")
+	}
+	return nil, goof.New("invalid path segment")
+}
+
+// setPathSegmentValue sets the array element or map value identified by the
+// path segment.
+func setPathSegmentValue(pv interface{}, seg interface{}, v interface{}) error {
+	switch s := seg.(type) {
+	case int:
+		rv := reflect.ValueOf(pv)
+		if rv.Kind() != reflect.Slice {
+			return goof.New("value is not an array")
+		}
+		if s >= rv.Len() {
+			return goof.New("index out of range")
+		}
+		ev := reflect.ValueOf(v)
+		et := rv.Type().Elem()
+		if !ev.IsValid() {
+			ev = reflect.Zero(et)
+		}
+		if !ev.Type().AssignableTo(et) {
+			return goof.New("value type does not match array element type")
+		}
+		rv.Index(s).Set(ev)
+		return nil
+	case string:
+		switch tpv := pv.(type) {
+		case map[string]interface{}:
+			if k, ok := mapKey(tpv, s); ok {
+				s = k
+			}
+			tpv[s] = v
+			return nil
+		case map[interface{}]interface{}:
+			for k := range tpv {
+				if strings.EqualFold(fmt.Sprintf("%v", k), s) {
+					tpv[k] = v
+					return nil
+				}
+			}
+			tpv[s] = v
+			return nil
+		}
+		return goof.New("value is not a map")
+	}
+	return goof.New("invalid path segment")
+}
+
+// mapKey returns the key in the map that matches k without regard to case.
+func mapKey(m map[string]interface{}, k string) (string, bool) {
+	if _, ok := m[k]; ok {
+		return k, true
+	}
+	for mk := range m {
+		if strings.EqualFold(mk, k) {
+			return mk, true
+		}
+	}
+	return "", false
+}
+
+// copyValue returns a deep copy of the maps and arrays in the value.
+func copyValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, mv := range tv {
+			m[k] = copyValue(mv)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := map[interface{}]interface{}{}
+		for k, mv := range tv {
+			m[k] = copyValue(mv)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(tv))
+		for x, av := range tv {
+			a[x] = copyValue(av)
+		}
+		return a
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		a := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(a, rv)
+		return a.Interface()
+	}
+	return v
+}
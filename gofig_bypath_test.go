@@ -0,0 +1,89 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var yamlEndpoints = []byte(`
+endpoints:
+  servers:
+  - name: primary
+    hosts:
+    - 10.0.0.1
+    - 10.0.0.2
+  - name: secondary
+    hosts:
+    - 10.0.1.1
+`)
+
+func TestGetByPath(t *testing.T) {
+	newConfigDirs("TestGetByPath", t)
+	wipeEnv()
+
+	c := New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlEndpoints)))
+
+	v, err := c.GetByPath("endpoints.servers[1].name")
+	assert.NoError(t, err)
+	assert.Equal(t, "secondary", v)
+
+	v, err = c.GetByPath("endpoints.servers[0].hosts[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", v)
+
+	v, err = c.GetByPath("rexray.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp://:7979", v)
+
+	v, err = c.Scope("endpoints").GetByPath("servers[0].name")
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", v)
+
+	_, err = c.GetByPath("endpoints.servers[2].name")
+	assert.Error(t, err)
+	_, err = c.GetByPath("endpoints.servers[0].name.first")
+	assert.Error(t, err)
+	_, err = c.GetByPath("endpoints.servers[0].port")
+	assert.Error(t, err)
+	_, err = c.GetByPath("rexray.host[0]")
+	assert.Error(t, err)
+	_, err = c.GetByPath("endpoints.servers[x]")
+	assert.Error(t, err)
+	_, err = c.GetByPath("endpoints.missing[0]")
+	assert.Error(t, err)
+}
+
+func TestSetByPath(t *testing.T) {
+	newConfigDirs("TestSetByPath", t)
+	wipeEnv()
+
+	c := New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlEndpoints)))
+	before, err := c.GetByPath("endpoints.servers[1].name")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.SetByPath("endpoints.servers[1].name", "backup"))
+	v, err := c.GetByPath("endpoints.servers[1].name")
+	assert.NoError(t, err)
+	assert.Equal(t, "backup", v)
+	assert.Equal(t, "secondary", before)
+
+	assert.NoError(t, c.SetByPath("endpoints.servers[0].hosts[0]", "10.0.0.9"))
+	v, err = c.GetByPath("endpoints.servers[0].hosts[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.9", v)
+
+	assert.NoError(t, c.Scope("endpoints").SetByPath("servers[0].port", 80))
+	v, err = c.GetByPath("endpoints.servers[0].port")
+	assert.NoError(t, err)
+	assert.Equal(t, 80, v)
+
+	assert.NoError(t, c.SetByPath("rexray.logLevel", "debug"))
+	assertString(t, c, "rexray.logLevel", "debug")
+
+	assert.Error(t, c.SetByPath("endpoints.servers[5].name", "x"))
+	assert.Error(t, c.SetByPath("rexray.host[0]", "x"))
+}
@@ -0,0 +1,128 @@
+package gofig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// changeCallback is a function registered to receive change notifications.
+// If key is empty the callback receives notifications for all keys.
+type changeCallback struct {
+	key string
+	fn  func(k string, oldVal, newVal interface{})
+}
+
+// changeHandle removes a change callback from its config when stopped.
+type changeHandle struct {
+	c  *config
+	id int
+}
+
+func (h *changeHandle) Stop() {
+	h.c.changeCallbacksRWL.Lock()
+	defer h.c.changeCallbacksRWL.Unlock()
+	delete(h.c.changeCallbacks, h.id)
+}
+
+func (c *config) OnChange(
+	k interface{}, fn func(oldVal, newVal interface{})) types.ChangeHandle {
+	szK := strings.ToLower(c.realKey(toString(k)))
+	return c.addChangeCallback(
+		szK,
+		func(k string, oldVal, newVal interface{}) { fn(oldVal, newVal) })
+}
+func (c *scopedConfig) OnChange(
+	k interface{}, fn func(oldVal, newVal interface{})) types.ChangeHandle {
+	szK := toString(k)
+	return c.Config.OnChange(fmt.Sprintf("%s.%s", c.scope, szK), fn)
+}
+
+func (c *config) OnAnyChange(
+	fn func(k string, oldVal, newVal interface{})) types.ChangeHandle {
+	return c.addChangeCallback("", fn)
+}
+func (c *scopedConfig) OnAnyChange(
+	fn func(k string, oldVal, newVal interface{})) types.ChangeHandle {
+	scopePrefix := fmt.Sprintf("%s.", strings.ToLower(c.scope))
+	return c.Config.OnAnyChange(func(k string, oldVal, newVal interface{}) {
+		if !strings.HasPrefix(k, scopePrefix) {
+			return
+		}
+		fn(strings.TrimPrefix(k, scopePrefix), oldVal, newVal)
+	})
+}
+
+func (c *config) addChangeCallback(
+	k string, fn func(k string, oldVal, newVal interface{})) *changeHandle {
+	c.changeCallbacksRWL.Lock()
+	defer c.changeCallbacksRWL.Unlock()
+	c.changeCallbackID++
+	c.changeCallbacks[c.changeCallbackID] = &changeCallback{key: k, fn: fn}
+	return &changeHandle{c: c, id: c.changeCallbackID}
+}
+
+func (c *config) hasChangeCallbacks() bool {
+	c.changeCallbacksRWL.RLock()
+	defer c.changeCallbacksRWL.RUnlock()
+	return len(c.changeCallbacks) > 0 || c.hasObservers() || c.hasAuditLog()
+}
+
+// notifyChange invokes the callbacks registered for the specified key, each
+// in its own goroutine, and the key's observers if the old and new values
+// differ.
+func (c *config) notifyChange(k string, oldVal, newVal interface{}) {
+	if reflect.DeepEqual(oldVal, newVal) {
+		return
+	}
+	k = strings.ToLower(k)
+	if LogGetAndSet {
+		logger().Debug("config.notifyChange",
+			"key", k,
+			"oldVal", oldVal,
+			"newVal", newVal,
+		)
+	}
+	c.notifyObservers(k, newVal)
+	c.changeCallbacksRWL.RLock()
+	defer c.changeCallbacksRWL.RUnlock()
+	for _, cb := range c.changeCallbacks {
+		if cb.key != "" && cb.key != k {
+			continue
+		}
+		go cb.fn(k, oldVal, newVal)
+	}
+}
+
+// notifyChanges compares two flattened views of the config's settings and
+// invokes the callbacks for every key that was added, removed, or modified.
+func (c *config) notifyChanges(before, after map[string]interface{}) {
+	for k, v := range after {
+		c.notifyChange(k, before[k], v)
+	}
+	for k, v := range before {
+		if _, ok := after[k]; !ok {
+			c.notifyChange(k, v, nil)
+		}
+	}
+}
+
+// flatSettings returns a map of the config's settings keyed by their
+// dot-notation key names while holding the config's read lock.
+func (c *config) flatSettings() map[string]interface{} {
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	return c.flatSettingsLocked()
+}
+
+// flatSettingsLocked returns a map of the config's settings keyed by their
+// dot-notation key names. The config's lock must be held.
+func (c *config) flatSettingsLocked() map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, k := range flatMapKeys(c.allSettingsLocked()) {
+		m[strings.ToLower(k)] = c.b.Get(k)
+	}
+	return m
+}
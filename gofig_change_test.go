@@ -0,0 +1,136 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type changeEvent struct {
+	key    string
+	oldVal interface{}
+	newVal interface{}
+}
+
+func waitForChange(t *testing.T, c <-chan changeEvent) changeEvent {
+	select {
+	case e := <-c:
+		return e
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for change")
+	}
+	return changeEvent{}
+}
+
+func assertNoChange(t *testing.T, c <-chan changeEvent) {
+	select {
+	case e := <-c:
+		t.Fatalf("unexpected change %v", e)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	newConfigDirs("TestOnChange", t)
+	wipeEnv()
+	c := New()
+
+	events := make(chan changeEvent, 10)
+	h := c.OnChange("rexray.logLevel", func(oldVal, newVal interface{}) {
+		events <- changeEvent{"", oldVal, newVal}
+	})
+
+	c.Set("rexray.logLevel", "debug")
+	e := waitForChange(t, events)
+	assert.Equal(t, "warn", e.oldVal)
+	assert.Equal(t, "debug", e.newVal)
+
+	c.Set("rexray.logLevel", "debug")
+	c.Set("rexray.host", "tcp://:7980")
+	assertNoChange(t, events)
+
+	h.Stop()
+	c.Set("rexray.logLevel", "info")
+	assertNoChange(t, events)
+}
+
+func TestOnChangeMultipleCallbacks(t *testing.T) {
+	newConfigDirs("TestOnChangeMultipleCallbacks", t)
+	wipeEnv()
+	c := New()
+
+	events := make(chan changeEvent, 10)
+	for x := 0; x < 2; x++ {
+		c.OnChange("rexray.logLevel", func(oldVal, newVal interface{}) {
+			events <- changeEvent{"", oldVal, newVal}
+		})
+	}
+
+	c.Set("rexray.logLevel", "debug")
+	waitForChange(t, events)
+	waitForChange(t, events)
+	assertNoChange(t, events)
+}
+
+func TestOnAnyChange(t *testing.T) {
+	newConfigDirs("TestOnAnyChange", t)
+	wipeEnv()
+	c := New()
+
+	events := make(chan changeEvent, 100)
+	c.OnAnyChange(func(k string, oldVal, newVal interface{}) {
+		events <- changeEvent{k, oldVal, newVal}
+	})
+
+	if err := c.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := map[string]changeEvent{}
+	for {
+		select {
+		case e := <-events:
+			changes[e.key] = e
+			continue
+		case <-time.After(time.Millisecond * 100):
+		}
+		break
+	}
+
+	e, ok := changes["rexray.loglevel"]
+	assert.True(t, ok)
+	assert.Equal(t, "warn", e.oldVal)
+	assert.Equal(t, "error", e.newVal)
+	_, ok = changes["rexray.host"]
+	assert.False(t, ok)
+}
+
+func TestOnChangeScoped(t *testing.T) {
+	newConfigDirs("TestOnChangeScoped", t)
+	wipeEnv()
+	c := New()
+	sc := c.Scope("rexray")
+
+	events := make(chan changeEvent, 10)
+	sc.OnChange("logLevel", func(oldVal, newVal interface{}) {
+		events <- changeEvent{"", oldVal, newVal}
+	})
+	sc.OnAnyChange(func(k string, oldVal, newVal interface{}) {
+		events <- changeEvent{k, oldVal, newVal}
+	})
+
+	c.Set("rexray.logLevel", "debug")
+	e1 := waitForChange(t, events)
+	e2 := waitForChange(t, events)
+	if e1.key == "" {
+		e1, e2 = e2, e1
+	}
+	assert.Equal(t, "loglevel", e1.key)
+	assert.Equal(t, "debug", e1.newVal)
+	assert.Equal(t, "debug", e2.newVal)
+
+	c.Set("mockProvider.userName", "root")
+	assertNoChange(t, events)
+}
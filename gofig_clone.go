@@ -0,0 +1,54 @@
+package gofig
+
+import (
+	"fmt"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) CloneWith(
+	overrides map[string]interface{}) (types.Config, error) {
+	m := unflattenMapKeys(overrides)
+	if err := c.checkUnknownSettings(m); err != nil {
+		return nil, err
+	}
+	return cloneWith(c, overrides)
+}
+func (c *scopedConfig) CloneWith(
+	overrides map[string]interface{}) (types.Config, error) {
+	scoped := map[string]interface{}{}
+	for k, v := range overrides {
+		scoped[fmt.Sprintf("%s.%s", c.scope, k)] = v
+	}
+	cc, err := c.Config.CloneWith(scoped)
+	if err != nil {
+		return nil, err
+	}
+	return &scopedConfig{Config: cc, scope: c.scope}, nil
+}
+func (c *childConfig) CloneWith(
+	overrides map[string]interface{}) (types.Config, error) {
+	m := unflattenMapKeys(overrides)
+	if err := c.checkUnknownSettings(m); err != nil {
+		return nil, err
+	}
+	return cloneWith(c, overrides)
+}
+
+// cloneWith copies the config and sets the overrides on the copy.
+func cloneWith(
+	c types.Config, overrides map[string]interface{}) (types.Config, error) {
+	cc, err := c.Copy()
+	if err != nil {
+		return nil, err
+	}
+	frozen := cc.IsFrozen()
+	cc.Unfreeze()
+	for k, v := range overrides {
+		cc.Set(k, v)
+	}
+	if frozen {
+		cc.Freeze()
+	}
+	return cc, nil
+}
@@ -0,0 +1,67 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneWith(t *testing.T) {
+	newConfigDirs("TestCloneWith", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testClone.host", "host0")
+	c.Set("testClone.port", 0)
+
+	cc, err := c.CloneWith(map[string]interface{}{
+		"testClone.host":    "host1",
+		"testClone.enabled": true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "host1", cc.GetString("testClone.host"))
+	assert.Equal(t, 0, cc.GetInt("testClone.port"))
+	assert.True(t, cc.GetBool("testClone.enabled"))
+	assert.Equal(t, "host0", c.GetString("testClone.host"))
+	assert.False(t, c.IsSet("testClone.enabled"))
+
+	sc, err := c.Scope("testClone").CloneWith(map[string]interface{}{
+		"port": 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sc.GetInt("port"))
+	assert.Equal(t, "host0", sc.GetString("host"))
+	assert.Equal(t, 0, c.GetInt("testClone.port"))
+
+	c.Freeze()
+	cc, err = c.CloneWith(map[string]interface{}{"testClone.port": 3})
+	assert.NoError(t, err)
+	assert.True(t, cc.IsFrozen())
+	assert.Equal(t, 3, cc.GetInt("testClone.port"))
+}
+
+func TestCloneWithStrictMode(t *testing.T) {
+	newConfigDirs("TestCloneWithStrictMode", t)
+	wipeEnv()
+
+	c := New()
+	c.StrictMode(true)
+
+	cc, err := c.CloneWith(map[string]interface{}{
+		"rexray.host":     "tcp://:8080",
+		"testClone.host":  "host1",
+		"rexray.loglevel": "debug",
+	})
+	assert.Nil(t, cc)
+	assert.IsType(t, &ValidationError{}, err)
+	verr := err.(*ValidationError)
+	assert.Len(t, verr.Errors, 1)
+	assert.Equal(t, ErrCodeUnknownKey, verr.Errors[0].Code())
+	assert.Equal(t, "testClone.host", verr.Errors[0].Key())
+
+	cc, err = c.CloneWith(map[string]interface{}{
+		"rexray.host": "tcp://:8080",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp://:8080", cc.GetString("rexray.host"))
+}
@@ -0,0 +1,123 @@
+package gofig
+
+import (
+	"fmt"
+
+	"github.com/akutz/goof"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/akutz/gofig/types"
+)
+
+// CobraOption is an option used to configure how BindCobraCommand binds a
+// config to a command.
+type CobraOption func(o *cobraOptions)
+
+type cobraOptions struct {
+	persistent bool
+}
+
+// WithPersistentFlags configures BindCobraCommand to add the config's flags
+// to the command's persistent flags so they are available to the command's
+// subcommands.
+func WithPersistentFlags() CobraOption {
+	return func(o *cobraOptions) {
+		o.persistent = true
+	}
+}
+
+// BindCobraCommand adds the flags from all of the config's flag sets to the
+// command's flags so that the values of the flags given on the command line
+// override the values of the keys bound to them. The command's other flags
+// are bound to the keys with the same names as the flags. Binding the same
+// command more than once has no effect. If one of the config's flags uses the
+// shorthand h then the command's help flag is added without a shorthand. An
+// error is returned if the shorthand of one of the config's flags is already
+// used by a different flag of the command.
+func BindCobraCommand(
+	c types.Config, cmd *cobra.Command, opts ...CobraOption) error {
+
+	if c == nil {
+		return goof.New("config is nil")
+	}
+	if cmd == nil {
+		return goof.New("command is nil")
+	}
+	o := &cobraOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	fs := cmd.Flags()
+	if o.persistent {
+		fs = cmd.PersistentFlags()
+	}
+
+	// the help flag is added before the shorthands are checked since the
+	// command adds it, with the shorthand h, when it is executed
+	if cmd.Flags().Lookup("help") == nil && usesShorthand(c, "h") {
+		cmd.Flags().Bool(
+			"help", false, fmt.Sprintf("help for %s", cmd.Name()))
+	}
+	cmd.InitDefaultHelpFlag()
+
+	var (
+		err         error
+		configFlags = map[*pflag.Flag]bool{}
+		addFlags    []*pflag.Flag
+	)
+	for _, cfs := range c.FlagSets() {
+		cfs.VisitAll(func(f *pflag.Flag) {
+			configFlags[f] = true
+			if err != nil || fs.Lookup(f.Name) != nil {
+				return
+			}
+			if f.Shorthand != "" {
+				sf := fs.ShorthandLookup(f.Shorthand)
+				if sf == nil {
+					sf = cmd.Flags().ShorthandLookup(f.Shorthand)
+				}
+				if sf != nil && sf.Name != f.Name {
+					err = goof.WithFields(map[string]interface{}{
+						"flag":      f.Name,
+						"shorthand": f.Shorthand,
+						"usedBy":    sf.Name,
+					}, "flag shorthand is already used")
+					return
+				}
+			}
+			addFlags = append(addFlags, f)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	for _, f := range addFlags {
+		fs.AddFlag(f)
+	}
+
+	cc, ok := unwrapConfig(c)
+	if !ok {
+		return goof.New("config does not support binding command flags")
+	}
+	cc.rwl.Lock()
+	defer cc.rwl.Unlock()
+	fs.VisitAll(func(f *pflag.Flag) {
+		if !configFlags[f] {
+			cc.bindFlag(f.Name, f)
+		}
+	})
+	return nil
+}
+
+// usesShorthand returns a flag indicating whether or not one of the config's
+// flags uses the specified shorthand.
+func usesShorthand(c types.Config, shorthand string) bool {
+	for _, fs := range c.FlagSets() {
+		if fs.ShorthandLookup(shorthand) != nil {
+			return true
+		}
+	}
+	return false
+}
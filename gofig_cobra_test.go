@@ -0,0 +1,163 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func registerCobraTestKeys() {
+	r := newRegistration("Test Cobra Server")
+	r.SetYAML("testCobra:\n  server:\n    host: yaml-host\n    port: 80\n")
+	r.Key(types.String, "", "localhost", "", "testCobra.server.host")
+	r.Key(types.Int, "", 8080, "", "testCobra.server.port")
+	Register(r)
+
+	r = newRegistration("Test Cobra Client")
+	r.SetYAML("testCobra:\n  client:\n    debug: false\n")
+	r.Key(types.Bool, "", false, "", "testCobra.client.debug")
+	Register(r)
+}
+
+func TestBindCobraCommand(t *testing.T) {
+	newConfigDirs("TestBindCobraCommand", t)
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+	registerCobraTestKeys()
+
+	c := New()
+	var host string
+	var port int
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(cmd *cobra.Command, args []string) {
+			host = c.GetString("testCobra.server.host")
+			port = c.GetInt("testCobra.server.port")
+		},
+	}
+	cmd.Flags().Bool("verbose", false, "")
+
+	assert.NoError(t, BindCobraCommand(c, cmd))
+	n := 0
+	cmd.Flags().VisitAll(func(f *pflag.Flag) { n++ })
+	assert.NoError(t, BindCobraCommand(c, cmd))
+	m := 0
+	cmd.Flags().VisitAll(func(f *pflag.Flag) { m++ })
+	assert.Equal(t, n, m)
+	assert.NotNil(t, cmd.Flags().Lookup("testCobraServerHost"))
+	assert.NotNil(t, cmd.Flags().Lookup("testCobraClientDebug"))
+
+	cmd.SetArgs([]string{"--testCobraServerHost", "flag-host", "--verbose"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "flag-host", host)
+	assert.Equal(t, 80, port)
+	assert.True(t, c.GetBool("verbose"))
+
+	assert.Error(t, BindCobraCommand(nil, cmd))
+	assert.Error(t, BindCobraCommand(c, nil))
+}
+
+func TestBindCobraCommandShorthand(t *testing.T) {
+	newConfigDirs("TestBindCobraCommandShorthand", t)
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+
+	r := newRegistration("Test Cobra Shorthand")
+	r.Key(types.String, "h", "localhost", "", "testCobra.host")
+	Register(r)
+
+	c := New()
+	cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+	assert.NoError(t, BindCobraCommand(c, cmd))
+	assert.Equal(t, "", cmd.Flags().Lookup("help").Shorthand)
+	cmd.SetArgs([]string{"-h", "flag-host"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "flag-host", c.GetString("testCobra.host"))
+
+	r.Key(types.Int, "p", 80, "", "testCobra.port")
+	cmd = &cobra.Command{Use: "test"}
+	cmd.Flags().IntP("parallel", "p", 1, "")
+	err := BindCobraCommand(New(), cmd)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "flag shorthand is already used")
+	assert.Nil(t, cmd.Flags().Lookup("testCobraHost"))
+	assert.Nil(t, cmd.Flags().Lookup("testCobraPort"))
+}
+
+func TestBindCobraCommandPersistent(t *testing.T) {
+	newConfigDirs("TestBindCobraCommandPersistent", t)
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+	registerCobraTestKeys()
+
+	c := New()
+	var debug bool
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{
+		Use: "sub",
+		Run: func(cmd *cobra.Command, args []string) {
+			debug = c.GetBool("testCobra.client.debug")
+		},
+	}
+	root.AddCommand(sub)
+
+	assert.NoError(t, BindCobraCommand(c, root, WithPersistentFlags()))
+	assert.NotNil(t, root.PersistentFlags().Lookup("testCobraClientDebug"))
+	root.SetArgs([]string{"sub", "--testCobraClientDebug"})
+	assert.NoError(t, root.Execute())
+	assert.True(t, debug)
+}
+
+func ExampleBindCobraCommand() {
+	r := NewRegistration("Example Server")
+	r.SetYAML("exampleCobra:\n  server:\n    host: yaml-host\n    port: 80\n")
+	r.Key(types.String, "", "localhost", "", "exampleCobra.server.host")
+	r.Key(types.Int, "", 8080, "", "exampleCobra.server.port")
+	Register(r)
+	defer UnregisterByName("Example Server")
+
+	r = NewRegistration("Example Client")
+	r.SetYAML("exampleCobra:\n  client:\n    timeout: 30\n")
+	r.Key(types.Int, "", 10, "", "exampleCobra.client.timeout")
+	Register(r)
+	defer UnregisterByName("Example Client")
+
+	c := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	cmd := &cobra.Command{
+		Use: "example",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(c.GetString("exampleCobra.server.host"))
+			fmt.Println(c.GetInt("exampleCobra.server.port"))
+			fmt.Println(c.GetInt("exampleCobra.client.timeout"))
+			return nil
+		},
+	}
+	if err := BindCobraCommand(c, cmd); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// the flag values override the YAML values
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{
+		"--exampleCobraServerHost", "flag-host",
+		"--exampleCobraClientTimeout", "60",
+	})
+	if err := cmd.Execute(); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// flag-host
+	// 80
+	// 60
+}
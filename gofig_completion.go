@@ -0,0 +1,199 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/akutz/gofig/types"
+)
+
+// completionFlag is the information about a flag required to generate its
+// shell completion logic.
+type completionFlag struct {
+	name   string
+	short  string
+	usage  string
+	isBool bool
+	values []string
+}
+
+var invalidFuncNameRX = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// completionCmdName returns the name of the command for which completion
+// logic is generated.
+func completionCmdName() string {
+	return filepath.Base(os.Args[0])
+}
+
+// completionFlags returns the flags of the config's flag sets sorted by name.
+// The allowed values of the flags that belong to StringEnum keys are included
+// as completion candidates.
+func completionFlags(c types.Config) []*completionFlag {
+	enumKeysRWL.RLock()
+	enumVals := map[string][]string{}
+	for _, rk := range enumKeys {
+		enumVals[rk.FlagName()] = rk.AllowedValues()
+	}
+	enumKeysRWL.RUnlock()
+
+	flags := map[string]*completionFlag{}
+	for _, fs := range c.FlagSets() {
+		fs.VisitAll(func(f *pflag.Flag) {
+			if f.Hidden {
+				return
+			}
+			flags[f.Name] = &completionFlag{
+				name:   f.Name,
+				short:  f.Shorthand,
+				usage:  f.Usage,
+				isBool: f.Value.Type() == "bool",
+				values: enumVals[f.Name],
+			}
+		})
+	}
+
+	names := []string{}
+	for n := range flags {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	sorted := []*completionFlag{}
+	for _, n := range names {
+		sorted = append(sorted, flags[n])
+	}
+	return sorted
+}
+
+// GenerateBashCompletion writes a bash completion script for the config's
+// flags to the writer.
+func GenerateBashCompletion(c types.Config, w io.Writer) error {
+	cmd := completionCmdName()
+	fn := fmt.Sprintf("_%s_completion", invalidFuncNameRX.ReplaceAllString(
+		cmd, "_"))
+	flags := completionFlags(c)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# bash completion for %s\n\n", cmd)
+	fmt.Fprintf(buf, "%s() {\n", fn)
+	buf.WriteString("    local cur prev\n")
+	buf.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	buf.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+
+	var words []string
+	var cases []string
+	for _, f := range flags {
+		opts := []string{"--" + f.name}
+		if f.short != "" {
+			opts = append(opts, "-"+f.short)
+		}
+		words = append(words, opts...)
+		if len(f.values) > 0 {
+			cases = append(cases, fmt.Sprintf(
+				"        %s)\n"+
+					"            COMPREPLY=($(compgen -W \"%s\" -- \"${cur}\"))\n"+
+					"            return 0\n"+
+					"            ;;\n",
+				strings.Join(opts, "|"), strings.Join(f.values, " ")))
+		}
+	}
+
+	if len(cases) > 0 {
+		buf.WriteString("    case \"${prev}\" in\n")
+		for _, cs := range cases {
+			buf.WriteString(cs)
+		}
+		buf.WriteString("    esac\n")
+	}
+	fmt.Fprintf(buf,
+		"    COMPREPLY=($(compgen -W \"%s\" -- \"${cur}\"))\n",
+		strings.Join(words, " "))
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(buf, "complete -F %s %s\n", fn, cmd)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// GenerateZshCompletion writes a zsh completion script for the config's
+// flags to the writer.
+func GenerateZshCompletion(c types.Config, w io.Writer) error {
+	cmd := completionCmdName()
+	flags := completionFlags(c)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "#compdef %s\n\n", cmd)
+	buf.WriteString("_arguments")
+	for _, f := range flags {
+		var arg string
+		if !f.isBool {
+			arg = fmt.Sprintf(":%s:", f.name)
+			if len(f.values) > 0 {
+				arg = fmt.Sprintf("%s(%s)", arg, strings.Join(f.values, " "))
+			}
+		}
+		desc := zshEscape(f.usage)
+		if f.short == "" {
+			fmt.Fprintf(buf, " \\\n    '--%s[%s]%s'", f.name, desc, arg)
+			continue
+		}
+		fmt.Fprintf(buf,
+			" \\\n    '(-%[1]s --%[2]s)'{-%[1]s,--%[2]s}'[%[3]s]%[4]s'",
+			f.short, f.name, desc, arg)
+	}
+	buf.WriteString("\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// GenerateFishCompletion writes a fish completion script for the config's
+// flags to the writer.
+func GenerateFishCompletion(c types.Config, w io.Writer) error {
+	cmd := completionCmdName()
+	flags := completionFlags(c)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# fish completion for %s\n\n", cmd)
+	for _, f := range flags {
+		fmt.Fprintf(buf, "complete -c %s -l %s", cmd, f.name)
+		if f.short != "" {
+			fmt.Fprintf(buf, " -s %s", f.short)
+		}
+		if f.usage != "" {
+			fmt.Fprintf(buf, " -d %s", shellQuote(f.usage))
+		}
+		if len(f.values) > 0 {
+			fmt.Fprintf(buf, " -x -a %s", shellQuote(
+				strings.Join(f.values, " ")))
+		} else if !f.isBool {
+			buf.WriteString(" -r")
+		}
+		buf.WriteString("\n")
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// shellQuote returns the text wrapped in single quotes with any embedded
+// single quotes escaped.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// zshEscape escapes the text so that it may be used as the description of a
+// zsh _arguments spec wrapped in single quotes.
+func zshEscape(s string) string {
+	s = strings.Replace(s, "'", `'\''`, -1)
+	s = strings.Replace(s, "[", `\[`, -1)
+	return strings.Replace(s, "]", `\]`, -1)
+}
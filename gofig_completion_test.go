@@ -0,0 +1,80 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func newCompletionConfig(t *testing.T) types.Config {
+	newConfigDirs("TestCompletion", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Completion")
+	r.Key(types.StringEnum, "l", "info", "The log level",
+		"testCompletion.logLevel", []string{"debug", "info"})
+	r.Key(types.Bool, "", false, "Enable [debug] mode",
+		"testCompletion.debug")
+	r.Key(types.String, "", "", "The user's name", "testCompletion.name")
+	Register(r)
+
+	return New()
+}
+
+func TestGenerateBashCompletion(t *testing.T) {
+	c := newCompletionConfig(t)
+	cmd := completionCmdName()
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, GenerateBashCompletion(c, buf))
+	s := buf.String()
+	t.Log(s)
+
+	assert.Contains(t, s, "        --testCompletionLogLevel|-l)\n"+
+		"            COMPREPLY=($(compgen -W \"debug info\" -- \"${cur}\"))\n")
+	assert.Contains(t, s,
+		"--testCompletionDebug --testCompletionLogLevel -l "+
+			"--testCompletionName")
+	assert.Contains(t, s, fmt.Sprintf("complete -F _%s_completion %s\n",
+		invalidFuncNameRX.ReplaceAllString(cmd, "_"), cmd))
+}
+
+func TestGenerateZshCompletion(t *testing.T) {
+	c := newCompletionConfig(t)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, GenerateZshCompletion(c, buf))
+	s := buf.String()
+	t.Log(s)
+
+	assert.Contains(t, s, "#compdef "+completionCmdName()+"\n")
+	assert.Contains(t, s, `'(-l --testCompletionLogLevel)'`+
+		`{-l,--testCompletionLogLevel}'[The log level]`+
+		`:testCompletionLogLevel:(debug info)'`)
+	assert.Contains(t, s, `'--testCompletionDebug[Enable \[debug\] mode]'`)
+	assert.Contains(t, s, `'--testCompletionName[The user'\''s name]`+
+		`:testCompletionName:'`)
+}
+
+func TestGenerateFishCompletion(t *testing.T) {
+	c := newCompletionConfig(t)
+	cmd := completionCmdName()
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, GenerateFishCompletion(c, buf))
+	s := buf.String()
+	t.Log(s)
+
+	assert.Contains(t, s, "complete -c "+cmd+
+		" -l testCompletionLogLevel -s l -d 'The log level'"+
+		" -x -a 'debug info'\n")
+	assert.Contains(t, s, "complete -c "+cmd+
+		" -l testCompletionDebug -d 'Enable [debug] mode'\n")
+	assert.Contains(t, s, "complete -c "+cmd+
+		` -l testCompletionName -d 'The user'\''s name' -r`+"\n")
+}
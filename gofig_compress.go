@@ -0,0 +1,188 @@
+package gofig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/akutz/goof"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionGzip is the name of the gzip compression algorithm.
+	CompressionGzip = "gzip"
+
+	// CompressionZstd is the name of the zstd compression algorithm.
+	CompressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// WithCompression configures the algorithm used to compress the files
+// written with WriteCompressedConfigFile when the algorithm cannot be
+// determined from the file's extension. The algorithm is either
+// CompressionGzip, the default, or CompressionZstd.
+func WithCompression(algo string) ConfigOption {
+	return func(c *config) {
+		c.compression = strings.ToLower(algo)
+	}
+}
+
+func (c *config) ReadCompressedConfigFile(filePath string) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	filePath = c.findConfigFile(filePath)
+	buf, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	algo, ext := compressedFileExt(filePath)
+	if a := compressionAlgo(buf); a != "" {
+		algo = a
+	}
+	if algo == "" {
+		algo = c.compression
+	}
+	if buf, err = decompress(buf, algo); err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(ext), "."))
+	if !isSupportedConfigFormat(format) {
+		format = detectConfigFormat(buf, c.configType)
+	}
+	if buf, err = expandIncludes(filePath, buf, format); err != nil {
+		return err
+	}
+	if err := c.checkSettings(buf, format); err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	err = c.mergeConfigRead(
+		configRead{buf: buf, format: format, filePath: filePath})
+	c.rwl.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.validateOnLoad()
+}
+
+func (c *config) WriteCompressedConfigFile(filePath string) error {
+	algo, ext := compressedFileExt(filePath)
+	if algo == "" {
+		algo = c.compression
+	}
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(ext), "."))
+	if !isSupportedConfigFormat(format) {
+		format = c.configType
+	}
+	buf, err := marshalConfig(c.nestedSettings(true), format)
+	if err != nil {
+		return err
+	}
+	if buf, err = compress(buf, algo); err != nil {
+		return err
+	}
+	logger().Debug("writing compressed config file",
+		"path", filePath,
+		"format", format,
+		"compression", algo,
+	)
+	return ioutil.WriteFile(filePath, buf, 0644)
+}
+
+// compressedFileExt returns the compression algorithm indicated by the file's
+// extension and the file's path without the compression extension. An empty
+// algorithm is returned if the extension does not indicate one.
+func compressedFileExt(filePath string) (string, string) {
+	ext := filepath.Ext(filePath)
+	switch strings.ToLower(ext) {
+	case ".gz", ".gzip":
+		return CompressionGzip, strings.TrimSuffix(filePath, ext)
+	case ".zst", ".zstd":
+		return CompressionZstd, strings.TrimSuffix(filePath, ext)
+	}
+	return "", filePath
+}
+
+// compressionAlgo returns the compression algorithm indicated by the magic
+// bytes at the start of the buffer. An empty string is returned if the
+// buffer is not compressed.
+func compressionAlgo(buf []byte) string {
+	switch {
+	case bytes.HasPrefix(buf, gzipMagic):
+		return CompressionGzip
+	case bytes.HasPrefix(buf, zstdMagic):
+		return CompressionZstd
+	}
+	return ""
+}
+
+// decompressIfCompressed returns the decompressed buffer if the buffer starts
+// with the magic bytes of a supported compression algorithm, otherwise the
+// buffer is returned unchanged.
+func decompressIfCompressed(buf []byte) ([]byte, error) {
+	algo := compressionAlgo(buf)
+	if algo == "" {
+		return buf, nil
+	}
+	return decompress(buf, algo)
+}
+
+// decompress returns the buffer decompressed with the specified algorithm.
+func decompress(buf []byte, algo string) ([]byte, error) {
+	switch algo {
+	case CompressionGzip, "":
+		r, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	case CompressionZstd:
+		r, err := zstd.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return nil, goof.WithField(
+		"algorithm", algo, "unsupported compression algorithm")
+}
+
+// compress returns the buffer compressed with the specified algorithm.
+func compress(buf []byte, algo string) ([]byte, error) {
+	out := &bytes.Buffer{}
+	switch algo {
+	case CompressionGzip, "":
+		w := gzip.NewWriter(out)
+		if _, err := w.Write(buf); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	case CompressionZstd:
+		w, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(buf); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, goof.WithField(
+			"algorithm", algo, "unsupported compression algorithm")
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompressTestConfig(keys int) *config {
+	c := newConfigWithOptions(false, false, "config", "yml")
+	for i := 0; i < keys; i++ {
+		c.Set(fmt.Sprintf("testCompress.k%04d", i), fmt.Sprintf("value%d", i))
+	}
+	return c
+}
+
+func TestCompressedConfigFile(t *testing.T) {
+	wipeEnv()
+
+	dir, err := ioutil.TempDir("", "gofig-test-TestCompressedConfigFile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := newCompressTestConfig(1000)
+	for _, name := range []string{
+		"config.yml.gz", "config.json.gz", "config.yml.zst"} {
+
+		filePath := filepath.Join(dir, name)
+		assert.NoError(t, c.WriteCompressedConfigFile(filePath))
+
+		buf, err := ioutil.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, compressionAlgo(buf))
+
+		rc := newConfigWithOptions(false, false, "config", "yml")
+		assert.NoError(t, rc.ReadCompressedConfigFile(filePath))
+		for i := 0; i < 1000; i++ {
+			k := fmt.Sprintf("testCompress.k%04d", i)
+			if !assert.Equal(t, fmt.Sprintf("value%d", i), rc.GetString(k)) {
+				break
+			}
+		}
+	}
+}
+
+func TestCompressedConfigStream(t *testing.T) {
+	wipeEnv()
+
+	dir, err := ioutil.TempDir("", "gofig-test-TestCompressedConfigStream")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := newConfigWithOptions(
+		false, false, "config", "yml", WithCompression(CompressionZstd))
+	c.Set("testCompress.host", "localhost")
+
+	filePath := filepath.Join(dir, "config.yml")
+	assert.NoError(t, c.WriteCompressedConfigFile(filePath))
+	buf, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, CompressionZstd, compressionAlgo(buf))
+
+	rc := newConfigWithOptions(false, false, "config", "yml")
+	assert.NoError(t, rc.ReadConfig(bytes.NewReader(buf)))
+	assert.Equal(t, "localhost", rc.GetString("testCompress.host"))
+
+	gz, err := compress([]byte("testCompress:\n  port: 8080\n"), "gzip")
+	assert.NoError(t, err)
+	assert.NoError(t, rc.ReadConfig(bytes.NewReader(gz)))
+	assert.Equal(t, 8080, rc.GetInt("testCompress.port"))
+
+	c = newConfigWithOptions(
+		false, false, "config", "yml", WithCompression("lz4"))
+	assert.Error(t, c.WriteCompressedConfigFile(filePath))
+}
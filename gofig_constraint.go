@@ -0,0 +1,114 @@
+package gofig
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// constraintKind is the kind of a constraint between keys.
+type constraintKind int
+
+const (
+	// mutuallyExclusive is a constraint that at most one of the keys is set.
+	mutuallyExclusive constraintKind = iota
+
+	// atLeastOne is a constraint that at least one of the keys is set.
+	atLeastOne
+)
+
+// constraint is a constraint between keys.
+type constraint struct {
+	kind constraintKind
+	keys []string
+}
+
+var (
+	constraints    []*constraint
+	constraintsRWL = &sync.RWMutex{}
+)
+
+// MutuallyExclusive registers a constraint that at most one of the keys is
+// set. The constraint is checked by the Validate function.
+func MutuallyExclusive(keys ...string) {
+	addConstraint(mutuallyExclusive, keys)
+}
+
+// AtLeastOne registers a constraint that at least one of the keys is set. The
+// constraint is checked by the Validate function.
+func AtLeastOne(keys ...string) {
+	addConstraint(atLeastOne, keys)
+}
+
+func addConstraint(kind constraintKind, keys []string) {
+	if len(keys) < 2 {
+		logger().Error("constraint requires at least two keys",
+			"keys", keys)
+		return
+	}
+	constraintsRWL.Lock()
+	defer constraintsRWL.Unlock()
+	constraints = append(
+		constraints,
+		&constraint{kind: kind, keys: append([]string{}, keys...)})
+}
+
+// Validate checks the config against the constraints registered with the
+// MutuallyExclusive and AtLeastOne functions and against the ranges of the
+// registered Int, Float64, and Duration keys. A key is set if its value does
+// not come from its default value. A *ValidationError is returned that lists
+// an error for each constraint that is violated, for each value that is out
+// of range, for each value of a Duration key that is not a valid duration,
+// for each value whose ${key} references cannot be expanded, and, if
+// ErrorOnDeprecated is true, for each deprecated key that is set. The key of
+// each constraint error is a comma-separated list of the keys involved in the
+// violation.
+func Validate(c types.Config) error {
+	if c == nil {
+		return goof.New("config is nil")
+	}
+
+	constraintsRWL.RLock()
+	cons := append([]*constraint{}, constraints...)
+	constraintsRWL.RUnlock()
+
+	verr := &ValidationError{}
+	for _, con := range cons {
+		set := []string{}
+		for _, k := range con.keys {
+			if c.GetSource(k) != types.Default {
+				set = append(set, k)
+			}
+		}
+		switch {
+		case con.kind == mutuallyExclusive && len(set) > 1:
+			verr.Errors = append(verr.Errors, NewConfigError(
+				ErrCodeMutuallyExclusive,
+				strings.Join(set, ", "),
+				goof.New("keys are mutually exclusive")))
+		case con.kind == atLeastOne && len(set) == 0:
+			verr.Errors = append(verr.Errors, NewConfigError(
+				ErrCodeNoneSet,
+				strings.Join(con.keys, ", "),
+				goof.New("at least one of the keys must be set")))
+		}
+	}
+	verr.Errors = append(verr.Errors, checkRanges(c)...)
+	verr.Errors = append(verr.Errors, checkTemplates(c)...)
+	verr.Errors = append(verr.Errors, checkDeprecatedKeys(c)...)
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// validateOnLoad validates the config if ValidateOnLoad is true.
+func (c *config) validateOnLoad() error {
+	if !ValidateOnLoad {
+		return nil
+	}
+	return Validate(c)
+}
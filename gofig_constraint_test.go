@@ -0,0 +1,88 @@
+package gofig
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func newConstraintConfig() types.Config {
+	r := newRegistration("Test Constraint")
+	r.Key(types.Bool, "", false, "", "testConstraint.usePassword")
+	r.Key(types.Bool, "", false, "", "testConstraint.useCert")
+	r.Key(types.String, "", "", "", "testConstraint.password")
+	r.Key(types.String, "", "", "", "testConstraint.certFile")
+	Register(r)
+	MutuallyExclusive("testConstraint.usePassword", "testConstraint.useCert")
+	AtLeastOne("testConstraint.password", "testConstraint.certFile")
+	return New()
+}
+
+func TestMutuallyExclusive(t *testing.T) {
+	newConfigDirs("TestMutuallyExclusive", t)
+	wipeEnv()
+	TestHook(t)
+
+	c := newConstraintConfig()
+	c.Set("testConstraint.password", "secret")
+	c.Set("testConstraint.usePassword", true)
+	assert.NoError(t, Validate(c))
+
+	os.Setenv("TESTCONSTRAINT_USECERT", "true")
+	defer os.Unsetenv("TESTCONSTRAINT_USECERT")
+	err := Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeMutuallyExclusive, verr.Errors[0].Code())
+		assert.Equal(t,
+			"testConstraint.usePassword, testConstraint.useCert",
+			verr.Errors[0].Key())
+	}
+}
+
+func TestAtLeastOne(t *testing.T) {
+	newConfigDirs("TestAtLeastOne", t)
+	wipeEnv()
+	TestHook(t)
+
+	c := newConstraintConfig()
+	err := Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeNoneSet, verr.Errors[0].Code())
+		assert.Equal(t,
+			"testConstraint.password, testConstraint.certFile",
+			verr.Errors[0].Key())
+	}
+
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(
+		"testConstraint:\n  certFile: /etc/cert.pem\n"))))
+	assert.NoError(t, Validate(c))
+}
+
+func TestValidateOnLoad(t *testing.T) {
+	newConfigDirs("TestValidateOnLoad", t)
+	wipeEnv()
+	TestHook(t)
+
+	defer func(v bool) { ValidateOnLoad = v }(ValidateOnLoad)
+	ValidateOnLoad = true
+
+	c := newConstraintConfig()
+	assert.IsType(t, &ValidationError{}, c.ReadConfig(bytes.NewReader(
+		[]byte("testConstraint:\n  useCert: true\n"))))
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(
+		[]byte("testConstraint:\n  certFile: /etc/cert.pem\n"))))
+	err := c.ReadConfig(bytes.NewReader(
+		[]byte("testConstraint:\n  usePassword: true\n")))
+	if assert.IsType(t, &ValidationError{}, err) {
+		assert.Equal(t, ErrCodeMutuallyExclusive,
+			err.(*ValidationError).Errors[0].Code())
+	}
+}
@@ -0,0 +1,40 @@
+package gofig
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/akutz/goof"
+)
+
+// Convert reads a config stream in the source format and writes it to dst in
+// the destination format. The supported formats are yaml, yml, json, and
+// toml. The values of secure keys are converted like any other value.
+func Convert(
+	src io.Reader, srcFmt string, dst io.Writer, dstFmt string) error {
+	srcFmt = strings.ToLower(srcFmt)
+	dstFmt = strings.ToLower(dstFmt)
+	if !isSupportedConfigFormat(srcFmt) {
+		return goof.WithField("format", srcFmt, "unsupported source format")
+	}
+	if !isSupportedConfigFormat(dstFmt) {
+		return goof.WithField(
+			"format", dstFmt, "unsupported destination format")
+	}
+	buf, err := ioutil.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	m, err := readSettings(buf, srcFmt)
+	if err != nil {
+		return goof.WithFieldE(
+			"format", srcFmt, fmt.Sprintf("failed to parse %s", srcFmt), err)
+	}
+	if buf, err = marshalConfig(m, dstFmt); err != nil {
+		return err
+	}
+	_, err = dst.Write(buf)
+	return err
+}
@@ -0,0 +1,52 @@
+package gofig
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	src := `
+service:
+  host: localhost
+  timeout: 30
+  names:
+  - a
+  - b
+`
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Convert(strings.NewReader(src), "yaml", buf, "json"))
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	svc := m["service"].(map[string]interface{})
+	assert.Equal(t, "localhost", svc["host"])
+	assert.EqualValues(t, 30, svc["timeout"])
+	assert.Equal(t, []interface{}{"a", "b"}, svc["names"])
+
+	toml := &bytes.Buffer{}
+	assert.NoError(t, Convert(buf, "json", toml, "toml"))
+	assert.Contains(t, toml.String(), "[service]")
+
+	yml := &bytes.Buffer{}
+	assert.NoError(t, Convert(toml, "TOML", yml, "yml"))
+	vm, err := ValidateYAMLString(yml.String())
+	assert.NoError(t, err)
+	assert.Contains(t, vm, "service")
+}
+
+func TestConvertErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := Convert(strings.NewReader(`{"hi": `), "json", buf, "yaml")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse json")
+
+	err = Convert(strings.NewReader(`{}`), "xml", buf, "yaml")
+	assert.Error(t, err)
+	err = Convert(strings.NewReader(`{}`), "json", buf, "xml")
+	assert.Error(t, err)
+	assert.Equal(t, 0, buf.Len())
+}
@@ -0,0 +1,73 @@
+//go:build gofigcover
+// +build gofigcover
+
+package gofig
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/akutz/gofig/types"
+)
+
+// keyCoverage records the keys of a config that were read after coverage was
+// enabled for the config.
+type keyCoverage struct {
+	enabled int32
+	keys    sync.Map
+}
+
+// recordCoverage records that the key was read if coverage is enabled.
+func (c *config) recordCoverage(k string) {
+	if atomic.LoadInt32(&c.coverage.enabled) == 1 {
+		c.coverage.keys.Store(strings.ToLower(k), true)
+	}
+}
+
+// EnableCoverage starts recording the keys read from the config so that the
+// registered keys that were never read may be listed with CoverageReport.
+// Coverage is recorded only when the program is built with the gofigcover
+// build tag. Otherwise EnableCoverage does nothing.
+func EnableCoverage(c types.Config) {
+	if cc, ok := unwrapConfig(c); ok {
+		atomic.StoreInt32(&cc.coverage.enabled, 1)
+	}
+}
+
+// CoverageReport returns a map of the names of the keys registered with the
+// config package or with the config and a flag indicating whether or not each
+// key was read from the config after EnableCoverage was called. The report is
+// empty unless the program is built with the gofigcover build tag. A TestMain
+// function may use the report to warn of the keys that the tests never read:
+//
+//	func TestMain(m *testing.M) {
+//		c := gofig.New()
+//		gofig.EnableCoverage(c)
+//		code := m.Run()
+//		for k, read := range gofig.CoverageReport(c) {
+//			if !read {
+//				log.Printf("warning: config key %s was never read", k)
+//			}
+//		}
+//		os.Exit(code)
+//	}
+func CoverageReport(c types.Config) map[string]bool {
+	m := map[string]bool{}
+	cc, ok := unwrapConfig(c)
+	if !ok {
+		return m
+	}
+
+	registrationsRWL.RLock()
+	regs := append([]types.ConfigRegistration{}, registrations...)
+	registrationsRWL.RUnlock()
+
+	for _, r := range append(regs, cc.registrations...) {
+		for k := range r.Keys() {
+			_, read := cc.coverage.keys.Load(strings.ToLower(k.KeyName()))
+			m[k.KeyName()] = read
+		}
+	}
+	return m
+}
@@ -0,0 +1,23 @@
+//go:build !gofigcover
+// +build !gofigcover
+
+package gofig
+
+import (
+	"github.com/akutz/gofig/types"
+)
+
+// keyCoverage is not recorded unless built with gofigcover
+type keyCoverage struct{}
+
+func (c *config) recordCoverage(k string) {}
+
+// EnableCoverage does nothing unless the program is built with the gofigcover
+// build tag.
+func EnableCoverage(c types.Config) {}
+
+// CoverageReport returns an empty report unless the program is built with the
+// gofigcover build tag.
+func CoverageReport(c types.Config) map[string]bool {
+	return map[string]bool{}
+}
@@ -0,0 +1,42 @@
+//go:build gofigcover
+// +build gofigcover
+
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestCoverageReport(t *testing.T) {
+	newConfigDirs("TestCoverageReport", t)
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+
+	r := newRegistration("Test Coverage")
+	r.Key(types.String, "", "localhost", "", "testCover.host")
+	r.Key(types.Int, "", 8080, "", "testCover.port")
+	r.Key(types.Bool, "", false, "", "testCover.debug")
+	Register(r)
+
+	c := New()
+	c.GetString("testCover.host")
+	assert.Equal(t, map[string]bool{
+		"testCover.host":  false,
+		"testCover.port":  false,
+		"testCover.debug": false,
+	}, CoverageReport(c))
+
+	EnableCoverage(c)
+	c.GetString("testCover.host")
+	ReadOnly(c).GetInt("testCover.port")
+	assert.Equal(t, map[string]bool{
+		"testCover.host":  true,
+		"testCover.port":  true,
+		"testCover.debug": false,
+	}, CoverageReport(ReadOnly(c)))
+}
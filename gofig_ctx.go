@@ -0,0 +1,96 @@
+package gofig
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/akutz/gofig/types"
+)
+
+// tracerName is the name of the tracer used to trace reads.
+const tracerName = "github.com/akutz/gofig"
+
+// traceGet starts a span for reading the key with the named function if the
+// context carries a trace span. The returned function ends the span.
+func traceGet(
+	ctx context.Context, c types.Config, fn string, k interface{}) func() {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return func() {}
+	}
+	_, span := parent.TracerProvider().Tracer(tracerName).Start(
+		ctx, "gofig."+fn, trace.WithAttributes(
+			attribute.String("gofig.key", toString(k)),
+			attribute.String("gofig.source", c.GetSource(k).String())))
+	return func() { span.End() }
+}
+
+func (c *config) GetStringCtx(ctx context.Context, k interface{}) string {
+	defer traceGet(ctx, c, "GetString", k)()
+	return c.GetString(k)
+}
+func (c *scopedConfig) GetStringCtx(ctx context.Context, k interface{}) string {
+	defer traceGet(ctx, c, "GetString", k)()
+	return c.GetString(k)
+}
+func (c *childConfig) GetStringCtx(ctx context.Context, k interface{}) string {
+	defer traceGet(ctx, c, "GetString", k)()
+	return c.GetString(k)
+}
+
+func (c *config) GetBoolCtx(ctx context.Context, k interface{}) bool {
+	defer traceGet(ctx, c, "GetBool", k)()
+	return c.GetBool(k)
+}
+func (c *scopedConfig) GetBoolCtx(ctx context.Context, k interface{}) bool {
+	defer traceGet(ctx, c, "GetBool", k)()
+	return c.GetBool(k)
+}
+func (c *childConfig) GetBoolCtx(ctx context.Context, k interface{}) bool {
+	defer traceGet(ctx, c, "GetBool", k)()
+	return c.GetBool(k)
+}
+
+func (c *config) GetStringSliceCtx(
+	ctx context.Context, k interface{}) []string {
+	defer traceGet(ctx, c, "GetStringSlice", k)()
+	return c.GetStringSlice(k)
+}
+func (c *scopedConfig) GetStringSliceCtx(
+	ctx context.Context, k interface{}) []string {
+	defer traceGet(ctx, c, "GetStringSlice", k)()
+	return c.GetStringSlice(k)
+}
+func (c *childConfig) GetStringSliceCtx(
+	ctx context.Context, k interface{}) []string {
+	defer traceGet(ctx, c, "GetStringSlice", k)()
+	return c.GetStringSlice(k)
+}
+
+func (c *config) GetIntCtx(ctx context.Context, k interface{}) int {
+	defer traceGet(ctx, c, "GetInt", k)()
+	return c.GetInt(k)
+}
+func (c *scopedConfig) GetIntCtx(ctx context.Context, k interface{}) int {
+	defer traceGet(ctx, c, "GetInt", k)()
+	return c.GetInt(k)
+}
+func (c *childConfig) GetIntCtx(ctx context.Context, k interface{}) int {
+	defer traceGet(ctx, c, "GetInt", k)()
+	return c.GetInt(k)
+}
+
+func (c *config) GetCtx(ctx context.Context, k interface{}) interface{} {
+	defer traceGet(ctx, c, "Get", k)()
+	return c.Get(k)
+}
+func (c *scopedConfig) GetCtx(ctx context.Context, k interface{}) interface{} {
+	defer traceGet(ctx, c, "Get", k)()
+	return c.Get(k)
+}
+func (c *childConfig) GetCtx(ctx context.Context, k interface{}) interface{} {
+	defer traceGet(ctx, c, "Get", k)()
+	return c.Get(k)
+}
@@ -0,0 +1,96 @@
+package gofig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/akutz/gofig/types"
+)
+
+type testTracerProvider struct {
+	noop.TracerProvider
+	spans *[]*testSpan
+}
+
+func (p testTracerProvider) Tracer(
+	string, ...trace.TracerOption) trace.Tracer {
+	return testTracer{tp: p}
+}
+
+type testTracer struct {
+	noop.Tracer
+	tp testTracerProvider
+}
+
+func (t testTracer) Start(
+	ctx context.Context,
+	name string,
+	opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	s := &testSpan{name: name, attrs: cfg.Attributes(), tp: t.tp}
+	*t.tp.spans = append(*t.tp.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+type testSpan struct {
+	noop.Span
+	name  string
+	attrs []attribute.KeyValue
+	ended bool
+	tp    testTracerProvider
+}
+
+func (s *testSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+func (s *testSpan) SpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	})
+}
+
+func (s *testSpan) TracerProvider() trace.TracerProvider {
+	return s.tp
+}
+
+func TestGetCtx(t *testing.T) {
+	newConfigDirs("TestGetCtx", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testCtx.host", "localhost")
+	c.Set("testCtx.port", 8080)
+
+	ctx := context.Background()
+	assert.Equal(t, "localhost", c.GetStringCtx(ctx, "testCtx.host"))
+	assert.Equal(t, 8080, c.GetIntCtx(ctx, "testCtx.port"))
+
+	spans := []*testSpan{}
+	tp := testTracerProvider{spans: &spans}
+	_, root := tp.Tracer("").Start(ctx, "root")
+	ctx = trace.ContextWithSpan(ctx, root)
+	spans = spans[:0]
+
+	assert.Equal(t, "localhost", c.GetStringCtx(ctx, "testCtx.host"))
+	assert.Equal(t, 8080, c.Scope("testCtx").GetIntCtx(ctx, "port"))
+	assert.Equal(t, "tcp://:7979", c.GetCtx(ctx, "rexray.host"))
+
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "gofig.GetString", spans[0].name)
+	assert.True(t, spans[0].ended)
+	assert.Contains(t, spans[0].attrs,
+		attribute.String("gofig.key", "testCtx.host"))
+	assert.Contains(t, spans[0].attrs,
+		attribute.String("gofig.source", types.Override.String()))
+	assert.Equal(t, "gofig.GetInt", spans[1].name)
+	assert.Contains(t, spans[1].attrs, attribute.String("gofig.key", "port"))
+	assert.Contains(t, spans[2].attrs,
+		attribute.String("gofig.source", types.Default.String()))
+}
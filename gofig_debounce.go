@@ -0,0 +1,78 @@
+package gofig
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultWatchDebounce is the default window in which a burst of change
+// events for a watched config file is coalesced into a single reload.
+const DefaultWatchDebounce = 200 * time.Millisecond
+
+func (c *config) SetWatchDebounce(d time.Duration) {
+	if d <= 0 {
+		d = DefaultWatchDebounce
+	}
+	c.watchDebounce.setWindow(d)
+}
+
+// notifyFileEvent is invoked for each change event for a watched config file.
+// The config is reloaded once the debounce window elapses without a new event
+// for the file.
+func (c *config) notifyFileEvent(filePath string) {
+	c.watchDebounce.trigger(filePath)
+}
+
+// reloadWatchedFile reloads the config after a burst of change events for the
+// watched config file.
+func (c *config) reloadWatchedFile(filePath string) {
+	if err := c.Reload(); err != nil {
+		logger().Error("error reloading watched config file",
+			"path", filePath, "error", err)
+	}
+}
+
+// debouncer coalesces bursts of events with the same name into a single call
+// of its function. Each event restarts the window for its name, and the
+// function is called once the window elapses without a new event.
+type debouncer struct {
+	sync.Mutex
+	window time.Duration
+	timers map[string]*time.Timer
+	fn     func(name string)
+}
+
+func newDebouncer(window time.Duration, fn func(name string)) *debouncer {
+	return &debouncer{
+		window: window,
+		timers: map[string]*time.Timer{},
+		fn:     fn,
+	}
+}
+
+func (d *debouncer) setWindow(window time.Duration) {
+	d.Lock()
+	defer d.Unlock()
+	d.window = window
+}
+
+func (d *debouncer) trigger(name string) {
+	d.Lock()
+	defer d.Unlock()
+	if t, ok := d.timers[name]; ok {
+		t.Stop()
+	}
+	var t *time.Timer
+	t = time.AfterFunc(d.window, func() {
+		d.Lock()
+		if d.timers[name] != t {
+			d.Unlock()
+			return
+		}
+		delete(d.timers, name)
+		fn := d.fn
+		d.Unlock()
+		fn(name)
+	})
+	d.timers[name] = t
+}
@@ -0,0 +1,63 @@
+package gofig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchDebounce(t *testing.T) {
+	_, usrFile := newConfigDirs("TestWatchDebounce", t)
+	wipeEnv()
+
+	writeFile := func(filePath, s string) {
+		if err := ioutil.WriteFile(filePath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	extraFile := filepath.Join(filepath.Dir(usrFile), "extra.yml")
+	writeFile(extraFile, "testDebounce:\n  count: 0\n")
+
+	c := newConfig()
+	assert.NoError(t, c.ReadConfigFile(extraFile))
+	c.SetWatchDebounce(50 * time.Millisecond)
+
+	var reloads int32
+	done := make(chan struct{}, 10)
+	c.watchDebounce.fn = func(name string) {
+		atomic.AddInt32(&reloads, 1)
+		c.reloadWatchedFile(name)
+		done <- struct{}{}
+	}
+
+	for x := 1; x <= 10; x++ {
+		writeFile(extraFile, fmt.Sprintf("testDebounce:\n  count: %d\n", x))
+		c.notifyFileEvent(extraFile)
+		time.Sleep(time.Millisecond)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+	time.Sleep(200 * time.Millisecond)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&reloads))
+	assert.Equal(t, 10, c.GetInt("testDebounce.count"))
+
+	c.notifyFileEvent(extraFile)
+	c.notifyFileEvent(usrFile)
+	for x := 0; x < 2; x++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for reload")
+		}
+	}
+	assert.EqualValues(t, 3, atomic.LoadInt32(&reloads))
+}
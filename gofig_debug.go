@@ -0,0 +1,84 @@
+//go:build !nogofigdebug
+// +build !nogofigdebug
+
+package gofig
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/akutz/gofig/types"
+)
+
+// debugAccess is the writer to which a config's key reads are logged.
+type debugAccess struct {
+	sync.Mutex
+	w io.Writer
+}
+
+// gofigPkgPath is the import path of the gofig package.
+var gofigPkgPath = reflect.TypeOf(config{}).PkgPath()
+
+// SetDebugAccess logs every read of one of the config's keys to the writer as
+// a line such as "GET key=service.host value=localhost caller=main.go:42",
+// where the caller is the first frame outside of the gofig packages. The
+// values of secure keys, including the secure keys in the map values of their
+// ancestors, are logged as [REDACTED]. A nil writer disables the logging. The
+// logging is intended for development only and is compiled out of programs
+// built with the nogofigdebug build tag.
+func SetDebugAccess(c types.Config, w io.Writer) {
+	cc, ok := unwrapConfig(c)
+	if !ok {
+		return
+	}
+	cc.debugAccess.Lock()
+	defer cc.debugAccess.Unlock()
+	cc.debugAccess.w = w
+}
+
+// recordDebugAccess logs the read of the key if debug access is enabled.
+func (c *config) recordDebugAccess(k string) {
+	c.debugAccess.Lock()
+	defer c.debugAccess.Unlock()
+	if c.debugAccess.w == nil {
+		return
+	}
+	var v interface{} = "[REDACTED]"
+	if !isSecureConfigKey(c, k) {
+		v = redactSecureValues(c, k, c.get(k), "[REDACTED]")
+	}
+	fmt.Fprintf(c.debugAccess.w,
+		"GET key=%s value=%v caller=%s\n", k, v, debugAccessCaller())
+}
+
+// debugAccessCaller returns the file name and line number of the first frame
+// on the stack that is not in one of the gofig packages. The frames of test
+// files are not skipped so the package's own tests report their callers.
+func debugAccessCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		f, more := frames.Next()
+		if !isGofigFrame(f) {
+			return fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// isGofigFrame returns a flag indicating whether or not the frame is in the
+// gofig package or one of its sub-packages and not in a test file.
+func isGofigFrame(f runtime.Frame) bool {
+	if strings.HasSuffix(f.File, "_test.go") {
+		return false
+	}
+	return strings.HasPrefix(f.Function, gofigPkgPath+".") ||
+		strings.HasPrefix(f.Function, gofigPkgPath+"/")
+}
@@ -0,0 +1,19 @@
+//go:build nogofigdebug
+// +build nogofigdebug
+
+package gofig
+
+import (
+	"io"
+
+	"github.com/akutz/gofig/types"
+)
+
+// debugAccess is not recorded when built with nogofigdebug
+type debugAccess struct{}
+
+// SetDebugAccess does nothing when the program is built with the
+// nogofigdebug build tag.
+func SetDebugAccess(c types.Config, w io.Writer) {}
+
+func (c *config) recordDebugAccess(k string) {}
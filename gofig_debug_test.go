@@ -0,0 +1,57 @@
+//go:build !nogofigdebug
+// +build !nogofigdebug
+
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestDebugAccess(t *testing.T) {
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+	r := newRegistration("Test Debug")
+	r.Key(types.SecureString, "", "", "", "testDebug.password")
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	c.Set("testDebug.host", "localhost")
+	c.Set("testDebug.password", "secret")
+
+	buf := &bytes.Buffer{}
+	SetDebugAccess(c, buf)
+
+	_, _, line, _ := runtime.Caller(0)
+	c.GetString("testDebug.host")
+	c.Scope("testDebug").GetString("password")
+	c.GetInt("testDebug.port")
+
+	assert.Equal(t, []string{
+		fmt.Sprintf("GET key=testDebug.host value=localhost "+
+			"caller=gofig_debug_test.go:%d", line+1),
+		fmt.Sprintf("GET key=testDebug.password value=[REDACTED] "+
+			"caller=gofig_debug_test.go:%d", line+2),
+		fmt.Sprintf("GET key=testDebug.port value=<nil> "+
+			"caller=gofig_debug_test.go:%d", line+3),
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+	assert.NotContains(t, buf.String(), "secret")
+
+	buf.Reset()
+	c.Get("testDebug")
+	assert.Contains(t, buf.String(), "password:[REDACTED]")
+	assert.Contains(t, buf.String(), "host:localhost")
+	assert.NotContains(t, buf.String(), "secret")
+
+	buf.Reset()
+	SetDebugAccess(c, nil)
+	c.GetString("testDebug.host")
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,112 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+// registeredDefault returns the default value of the key registered with the
+// config package or with the config.
+func (c *config) registeredDefault(k string) (interface{}, bool) {
+	lk := strings.ToLower(c.realKey(k))
+	registrationsRWL.RLock()
+	regs := append([]types.ConfigRegistration{}, registrations...)
+	registrationsRWL.RUnlock()
+	for _, r := range append(regs, c.registrations...) {
+		for rk := range r.Keys() {
+			if strings.ToLower(rk.KeyName()) == lk {
+				return rk.DefaultValue(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (c *config) GetStringOrDefault(k interface{}, def string) string {
+	if c.IsSet(k) {
+		return c.GetString(k)
+	}
+	if v, ok := c.registeredDefault(toString(k)); ok {
+		return cast.ToString(v)
+	}
+	return def
+}
+func (c *scopedConfig) GetStringOrDefault(k interface{}, def string) string {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetStringOrDefault(sk, def)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetStringOrDefault(szK, def)
+	}
+	return def
+}
+
+func (c *config) GetIntOrDefault(k interface{}, def int) int {
+	if c.IsSet(k) {
+		return c.GetInt(k)
+	}
+	if v, ok := c.registeredDefault(toString(k)); ok {
+		return cast.ToInt(v)
+	}
+	return def
+}
+func (c *scopedConfig) GetIntOrDefault(k interface{}, def int) int {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetIntOrDefault(sk, def)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetIntOrDefault(szK, def)
+	}
+	return def
+}
+
+func (c *config) GetBoolOrDefault(k interface{}, def bool) bool {
+	if c.IsSet(k) {
+		return c.GetBool(k)
+	}
+	if v, ok := c.registeredDefault(toString(k)); ok {
+		return cast.ToBool(v)
+	}
+	return def
+}
+func (c *scopedConfig) GetBoolOrDefault(k interface{}, def bool) bool {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetBoolOrDefault(sk, def)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetBoolOrDefault(szK, def)
+	}
+	return def
+}
+
+func (c *config) GetFloat64OrDefault(k interface{}, def float64) float64 {
+	if c.IsSet(k) {
+		return cast.ToFloat64(c.Get(k))
+	}
+	if v, ok := c.registeredDefault(toString(k)); ok {
+		return cast.ToFloat64(v)
+	}
+	return def
+}
+func (c *scopedConfig) GetFloat64OrDefault(
+	k interface{}, def float64) float64 {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetFloat64OrDefault(sk, def)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetFloat64OrDefault(szK, def)
+	}
+	return def
+}
@@ -0,0 +1,68 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestGetOrDefault(t *testing.T) {
+	newConfigDirs("TestGetOrDefault", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Get Or Default")
+	r.Key(types.Int, "", 8080, "", "testGetOrDefault.port")
+	r.Key(types.Bool, "", true, "", "testGetOrDefault.tls")
+	Register(r)
+
+	c := New()
+	c.Set("testGetOrDefault.ratio", 0.5)
+	c.Set("testGetOrDefault.name", "gofig")
+
+	assert.Equal(t, "tcp://:7979", c.GetStringOrDefault("rexray.host", "none"))
+	assert.Equal(t, "gofig", c.GetStringOrDefault("testGetOrDefault.name", ""))
+	assert.Equal(t, "none", c.GetStringOrDefault("testGetOrDefault.x", "none"))
+
+	assert.Equal(t, 8080, c.GetIntOrDefault("testGetOrDefault.port", 80))
+	assert.Equal(t, 80, c.GetIntOrDefault("testGetOrDefault.x", 80))
+	c.Set("testGetOrDefault.port", 9090)
+	assert.Equal(t, 9090, c.GetIntOrDefault("testGetOrDefault.port", 80))
+
+	assert.True(t, c.GetBoolOrDefault("testGetOrDefault.tls", false))
+	assert.True(t, c.GetBoolOrDefault("testGetOrDefault.x", true))
+
+	assert.Equal(t, 0.5, c.GetFloat64OrDefault("testGetOrDefault.ratio", 1))
+	assert.Equal(t, 1.5, c.GetFloat64OrDefault("testGetOrDefault.x", 1.5))
+
+	sc := c.Scope("testGetOrDefault")
+	assert.Equal(t, "gofig", sc.GetStringOrDefault("name", ""))
+	assert.Equal(t, 9090, sc.GetIntOrDefault("port", 80))
+	assert.Equal(t, 0.5, sc.GetFloat64OrDefault("ratio", 1))
+	assert.Equal(t, "tcp://:7979", sc.GetStringOrDefault("rexray.host", ""))
+	assert.Equal(t, 80, sc.GetIntOrDefault("x", 80))
+	assert.False(t, sc.GetBoolOrDefault("x", false))
+}
+
+func TestGetOrDefaultConfigRegistration(t *testing.T) {
+	newConfigDirs("TestGetOrDefaultConfigRegistration", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Get Or Default Config")
+	r.Key(types.Int, "", 8080, "", "testGetOrDefault.cfgPort")
+	r.Key(types.Bool, "", true, "", "testGetOrDefault.cfgTLS")
+	r.Key(types.String, "", "gofig", "", "testGetOrDefault.cfgName")
+
+	c := New(withRegistrations(r))
+	assert.Equal(t, 8080, c.GetIntOrDefault("testGetOrDefault.cfgPort", 80))
+	assert.True(t, c.GetBoolOrDefault("testGetOrDefault.cfgTLS", false))
+	assert.Equal(t,
+		"gofig", c.GetStringOrDefault("testGetOrDefault.cfgName", ""))
+	assert.Equal(t,
+		8080.0, c.GetFloat64OrDefault("testGetOrDefault.cfgPort", 1))
+
+	assert.Equal(t, 80, New().GetIntOrDefault("testGetOrDefault.cfgPort", 80))
+}
@@ -0,0 +1,58 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cast"
+)
+
+func (c *config) GetStringSliceDelim(k interface{}, delim string) []string {
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
+	if LogGetAndSet {
+		logger().Debug("config.GetStringSliceDelim",
+			"key", szK, "delim", delim)
+	}
+	ss, ok := c.envSliceValue(szK, delim)
+	if !ok {
+		v := c.get(szK)
+		if s, ok := v.(string); ok {
+			ss = splitSlice(s, delim)
+		} else {
+			ss = cast.ToStringSlice(v)
+		}
+	}
+	return c.replaceSliceEnvVars(ss)
+}
+func (c *scopedConfig) GetStringSliceDelim(
+	k interface{}, delim string) []string {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetStringSliceDelim(sk, delim)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetStringSliceDelim(szK, delim)
+	}
+	return nil
+}
+func (c *childConfig) GetStringSliceDelim(
+	k interface{}, delim string) []string {
+	if c.config.IsSet(k) {
+		return c.config.GetStringSliceDelim(k, delim)
+	}
+	return c.parent.GetStringSliceDelim(k, delim)
+}
+
+// replaceSliceEnvVars returns a copy of the slice with the environment
+// variable references in its elements replaced with their values.
+func (c *config) replaceSliceEnvVars(ss []string) []string {
+	rss := []string{}
+	envVars := os.Environ()
+	for _, s := range ss {
+		rss = append(rss, c.replaceEnvVars(s, envVars))
+	}
+	return rss
+}
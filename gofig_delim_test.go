@@ -0,0 +1,54 @@
+package gofig
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestGetStringSliceDelim(t *testing.T) {
+	newConfigDirs("TestGetStringSliceDelim", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Delim")
+	r.Key(types.String, "", "", "The locales", "testDelim.locales")
+	r.Key(types.String, "", "", "The paths", "testDelim.paths",
+		types.DelimOpt(";"))
+	Register(r)
+
+	c := New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(`
+testDelim:
+  locales: en_US,UTF-8; fr_FR,UTF-8
+`))))
+	assert.Equal(t,
+		[]string{"en_US,UTF-8", "fr_FR,UTF-8"},
+		c.GetStringSliceDelim("testDelim.locales", ";"))
+	assert.Equal(t,
+		[]string{"en_US,UTF-8", "fr_FR,UTF-8"},
+		c.Scope("testDelim").GetStringSliceDelim("locales", ";"))
+	assert.Equal(t,
+		[]string{"en_US,UTF-8", "fr_FR,UTF-8"},
+		c.Scope("testDelim").Scope("other").GetStringSliceDelim("locales", ";"))
+
+	os.Setenv("TESTDELIM_PATHS", "/data/a,b;/data/c")
+	defer os.Unsetenv("TESTDELIM_PATHS")
+	assert.Equal(t,
+		[]string{"/data/a,b", "/data/c"},
+		c.GetStringSlice("testDelim.paths"))
+	assert.Equal(t,
+		[]string{"/data/a", "b;/data/c"},
+		c.GetStringSliceDelim("testDelim.paths", ","))
+
+	c.Set("testDelim.paths", []string{"/data/a,b", "/data/c"})
+	os.Unsetenv("TESTDELIM_PATHS")
+	assert.Contains(t, c.EnvVars(), "TESTDELIM_PATHS=/data/a,b;/data/c")
+	assert.Equal(t,
+		[]string{"/data/a,b", "/data/c"},
+		c.GetStringSliceDelim("testDelim.paths", ";"))
+}
@@ -0,0 +1,112 @@
+package gofig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) Deprecate(k interface{}, message string) {
+	szK := strings.ToLower(c.realKey(toString(k)))
+	c.deprecationsRWL.Lock()
+	defer c.deprecationsRWL.Unlock()
+	c.deprecations[szK] = message
+}
+func (c *scopedConfig) Deprecate(k interface{}, message string) {
+	szK := toString(k)
+	c.Config.Deprecate(fmt.Sprintf("%s.%s", c.scope, szK), message)
+}
+
+func (c *config) deprecation(k string) (string, bool) {
+	c.deprecationsRWL.RLock()
+	defer c.deprecationsRWL.RUnlock()
+	msg, ok := c.deprecations[strings.ToLower(k)]
+	return msg, ok
+}
+
+func (c *config) hasDeprecations() bool {
+	c.deprecationsRWL.RLock()
+	defer c.deprecationsRWL.RUnlock()
+	return len(c.deprecations) > 0
+}
+
+// deprecatedKeyError logs a warning if the key is deprecated. If the key is
+// deprecated and ErrorOnDeprecated is true then an error is returned.
+func (c *config) deprecatedKeyError(k string) error {
+	msg, ok := c.deprecation(k)
+	if !ok {
+		return nil
+	}
+	if ErrorOnDeprecated {
+		return goof.WithFields(map[string]interface{}{
+			"key":     k,
+			"message": msg,
+		}, "deprecated key")
+	}
+	logger().Warn("deprecated key", "key", k, "message", msg)
+	return nil
+}
+
+// checkDeprecated logs a warning if the key is deprecated. If the key is
+// deprecated and ErrorOnDeprecated is true then an error is logged instead.
+// The error is returned by ReadConfig and Validate.
+func (c *config) checkDeprecated(k string) {
+	if err := c.deprecatedKeyError(k); err != nil {
+		logger().Error("deprecated key", "key", k, "error", err)
+	}
+}
+
+// checkDeprecatedKeys returns an error for each deprecated key that is set in
+// the config if ErrorOnDeprecated is true.
+func checkDeprecatedKeys(c types.Config) []ConfigError {
+	cc, ok := unwrapConfig(c)
+	if !ok || !ErrorOnDeprecated || !cc.hasDeprecations() {
+		return nil
+	}
+	cc.deprecationsRWL.RLock()
+	keys := []string{}
+	for k := range cc.deprecations {
+		keys = append(keys, k)
+	}
+	cc.deprecationsRWL.RUnlock()
+	sort.Strings(keys)
+
+	errs := []ConfigError{}
+	for _, k := range keys {
+		if cc.GetSource(k) == types.Default {
+			continue
+		}
+		if err := cc.deprecatedKeyError(k); err != nil {
+			errs = append(errs, NewConfigError(ErrCodeDeprecatedKey, k, err))
+		}
+	}
+	return errs
+}
+
+// checkDeprecatedSettings logs a warning for each deprecated key present in
+// the settings read from a config stream. If ErrorOnDeprecated is true then
+// an error is returned for the first deprecated key that is present.
+func (c *config) checkDeprecatedSettings(m map[string]interface{}) error {
+	if !c.hasDeprecations() {
+		return nil
+	}
+	c.deprecationsRWL.RLock()
+	keys := []string{}
+	for k := range c.deprecations {
+		keys = append(keys, k)
+	}
+	c.deprecationsRWL.RUnlock()
+	for _, k := range keys {
+		if _, ok := getNestedValue(m, k); !ok {
+			continue
+		}
+		if err := c.deprecatedKeyError(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
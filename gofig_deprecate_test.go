@@ -0,0 +1,156 @@
+package gofig
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+// logRecorder is a slog.Handler that records the log records.
+type logRecorder struct {
+	sync.Mutex
+	records []slog.Record
+}
+
+var logHook = &logRecorder{}
+
+func (h *logRecorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *logRecorder) Handle(ctx context.Context, r slog.Record) error {
+	h.Lock()
+	defer h.Unlock()
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func (h *logRecorder) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *logRecorder) WithGroup(string) slog.Handler {
+	return h
+}
+
+func (h *logRecorder) Reset() {
+	h.Lock()
+	defer h.Unlock()
+	h.records = nil
+}
+
+// recordLogs resets the log recorder and sets it as the package logger. The
+// returned function restores the previous logger.
+func recordLogs() func() {
+	logHook.Reset()
+	prev := pkgLogger.Load()
+	SetLogger(slog.New(logHook))
+	return func() { SetLogger(prev) }
+}
+
+func assertDeprecatedLogged(t *testing.T, k string) {
+	assertDeprecatedLoggedAt(t, k, slog.LevelWarn)
+}
+
+func assertDeprecatedLoggedAt(t *testing.T, k string, level slog.Level) {
+	logHook.Lock()
+	defer logHook.Unlock()
+	for _, r := range logHook.records {
+		if r.Message != "deprecated key" {
+			continue
+		}
+		found := false
+		r.Attrs(func(a slog.Attr) bool {
+			found = a.Key == "key" && a.Value.String() == k
+			return !found
+		})
+		if found {
+			assert.Equal(t, level, r.Level)
+			return
+		}
+	}
+	t.Fatalf("deprecated key %s not logged", k)
+}
+
+func TestDeprecate(t *testing.T) {
+	newConfigDirs("TestDeprecate", t)
+	wipeEnv()
+	defer recordLogs()()
+
+	c := New()
+	c.Deprecate("rexray.host", "use rexray.modules.default.host")
+
+	assertString(t, c, "rexray.host", "tcp://:7979")
+	assertDeprecatedLogged(t, "rexray.host")
+
+	logHook.Reset()
+	c.Scope("rexray").Deprecate("logLevel", "use rexray.logging.level")
+	err := c.ReadConfig(bytes.NewReader([]byte(`rexray:
+  logLevel: debug
+`)))
+	assert.NoError(t, err)
+	assertDeprecatedLogged(t, "rexray.loglevel")
+
+	logHook.Reset()
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	cc.IsSet("rexray.host")
+	assertDeprecatedLogged(t, "rexray.host")
+}
+
+func TestDeprecateErrorOnDeprecated(t *testing.T) {
+	newConfigDirs("TestDeprecateErrorOnDeprecated", t)
+	wipeEnv()
+	TestHook(t)
+	defer recordLogs()()
+	ErrorOnDeprecated = true
+
+	c := New()
+	c.Deprecate("rexray.logLevel", "use rexray.logging.level")
+
+	err := c.ReadConfig(bytes.NewReader(yamlConfig1))
+	assert.Error(t, err)
+	assert.Equal(t,
+		[]string{"libstorage"}, c.GetStringSlice("rexray.storageDrivers"))
+
+	assert.NotPanics(t, func() { c.GetString("rexray.logLevel") })
+	assert.NotPanics(t, func() { c.IsSet("rexray.logLevel") })
+	assert.Equal(t, "warn", c.GetString("rexray.logLevel"))
+	assertDeprecatedLoggedAt(t, "rexray.logLevel", slog.LevelError)
+
+	assert.NoError(t, Validate(c))
+	c.Set("rexray.logLevel", "debug")
+	err = Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		if assert.Len(t, verr.Errors, 1) {
+			assert.Equal(t, ErrCodeDeprecatedKey, verr.Errors[0].Code())
+			assert.Equal(t, "rexray.loglevel", verr.Errors[0].Key())
+		}
+	}
+}
+
+func TestDeprecateRegistrationEnvVar(t *testing.T) {
+	newConfigDirs("TestDeprecateRegistrationEnvVar", t)
+	wipeEnv()
+	TestHook(t)
+	defer recordLogs()()
+
+	r := newRegistration("Test Deprecate")
+	r.Key(types.String, "", "", "", "testDeprecate.userName")
+	r.Deprecate("testDeprecate.userName", "use testDeprecate.user")
+	Register(r)
+
+	os.Setenv("TESTDEPRECATE_USERNAME", "admin")
+	defer os.Setenv("TESTDEPRECATE_USERNAME", "")
+
+	New()
+	assertDeprecatedLogged(t, "testDeprecate.userName")
+}
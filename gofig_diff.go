@@ -0,0 +1,89 @@
+package gofig
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// Diff returns the changes required to transform config a into config b. The
+// comparison is performed on the flat, dot-notation key space returned by the
+// AllKeys function. The values of secure keys are compared but reported as
+// types.SecureValue.
+func Diff(a, b types.Config) ([]types.ConfigChange, error) {
+	if a == nil || b == nil {
+		return nil, goof.New("config is nil")
+	}
+
+	am := flatConfigSettings(a)
+	bm := flatConfigSettings(b)
+
+	changes := []types.ConfigChange{}
+	for k, bv := range bm {
+		av, ok := am[k]
+		switch {
+		case !ok:
+			changes = append(
+				changes, newConfigChange(a, b, k, types.Added, nil, bv))
+		case !reflect.DeepEqual(av, bv):
+			changes = append(
+				changes, newConfigChange(a, b, k, types.Modified, av, bv))
+		}
+	}
+	for k, av := range am {
+		if _, ok := bm[k]; !ok {
+			changes = append(
+				changes, newConfigChange(a, b, k, types.Removed, av, nil))
+		}
+	}
+
+	sort.Sort(configChangesByKey(changes))
+	return changes, nil
+}
+
+// newConfigChange returns the change of the key read from configs a and b.
+// The values are reported as types.SecureValue if the key is a secure key of
+// either config.
+func newConfigChange(
+	a, b types.Config,
+	k string,
+	kind types.ChangeKind,
+	oldVal, newVal interface{}) types.ConfigChange {
+
+	if isSecureConfigKey(a, k) || isSecureConfigKey(b, k) {
+		if oldVal != nil {
+			oldVal = types.SecureValue
+		}
+		if newVal != nil {
+			newVal = types.SecureValue
+		}
+	}
+	return types.ConfigChange{
+		Key:      k,
+		Kind:     kind,
+		OldValue: oldVal,
+		NewValue: newVal,
+	}
+}
+
+// flatConfigSettings returns a map of the config's settings keyed by their
+// dot-notation key names.
+func flatConfigSettings(c types.Config) map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, k := range c.AllKeys() {
+		if v := c.Get(k); v != nil {
+			m[strings.ToLower(k)] = v
+		}
+	}
+	return m
+}
+
+type configChangesByKey []types.ConfigChange
+
+func (c configChangesByKey) Len() int           { return len(c) }
+func (c configChangesByKey) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c configChangesByKey) Less(i, j int) bool { return c[i].Key < c[j].Key }
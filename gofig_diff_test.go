@@ -0,0 +1,137 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func findConfigChange(
+	changes []types.ConfigChange, k string) (types.ConfigChange, bool) {
+	for _, c := range changes {
+		if c.Key == k {
+			return c, true
+		}
+	}
+	return types.ConfigChange{}, false
+}
+
+func TestDiff(t *testing.T) {
+	wipeEnv()
+
+	a, err := FromYAML(`
+rexray:
+  logLevel: warn
+  modules:
+    default:
+      host: tcp://:7979
+      disabled: false
+mockProvider:
+  userName: admin
+  minVolSize: 16
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := FromYAML(`
+rexray:
+  logLevel: debug
+  modules:
+    default:
+      host: tcp://:7980
+      disabled: false
+    admin:
+      host: unix:///var/run/rexray.sock
+mockProvider:
+  minVolSize: "16"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		t.Logf("%s %s %v -> %v", c.Kind, c.Key, c.OldValue, c.NewValue)
+	}
+
+	c, ok := findConfigChange(changes, "rexray.loglevel")
+	assert.True(t, ok)
+	assert.Equal(t, types.Modified, c.Kind)
+	assert.Equal(t, "warn", c.OldValue)
+	assert.Equal(t, "debug", c.NewValue)
+
+	c, ok = findConfigChange(changes, "rexray.modules.default.host")
+	assert.True(t, ok)
+	assert.Equal(t, types.Modified, c.Kind)
+	assert.Equal(t, "tcp://:7980", c.NewValue)
+
+	c, ok = findConfigChange(changes, "rexray.modules.admin.host")
+	assert.True(t, ok)
+	assert.Equal(t, types.Added, c.Kind)
+	assert.Nil(t, c.OldValue)
+	assert.Equal(t, "unix:///var/run/rexray.sock", c.NewValue)
+
+	c, ok = findConfigChange(changes, "mockprovider.username")
+	assert.True(t, ok)
+	assert.Equal(t, types.Removed, c.Kind)
+	assert.Equal(t, "admin", c.OldValue)
+	assert.Nil(t, c.NewValue)
+
+	c, ok = findConfigChange(changes, "mockprovider.minvolsize")
+	assert.True(t, ok)
+	assert.Equal(t, types.Modified, c.Kind)
+	assert.Equal(t, 16, c.OldValue)
+	assert.Equal(t, "16", c.NewValue)
+
+	_, ok = findConfigChange(changes, "rexray.modules.default.disabled")
+	assert.False(t, ok)
+
+	changes, err = Diff(a, a)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 0)
+
+	_, err = Diff(a, nil)
+	assert.Error(t, err)
+}
+
+func TestDiffSecureKeys(t *testing.T) {
+	wipeEnv()
+
+	r := newRegistration("Test Diff")
+	r.Key(types.SecureString, "", "", "", "mockProvider.password")
+
+	a := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	a.Set("mockProvider.password", "i should be hidden")
+	b := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	b.Set("mockProvider.password", "i should also be hidden")
+
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, ok := findConfigChange(changes, "mockprovider.password")
+	assert.True(t, ok)
+	assert.Equal(t, types.Modified, c.Kind)
+	assert.Equal(t, types.SecureValue, c.OldValue)
+	assert.Equal(t, types.SecureValue, c.NewValue)
+
+	b.Set("mockProvider.password", "i should be hidden")
+	changes, err = Diff(a, b)
+	assert.NoError(t, err)
+	_, ok = findConfigChange(changes, "mockprovider.password")
+	assert.False(t, ok)
+
+	b.Set("mockProvider.password", "i should also be hidden")
+	changes, err = Diff(a.Scope("mockProvider"), b.Scope("mockProvider"))
+	assert.NoError(t, err)
+	c, ok = findConfigChange(changes, "password")
+	assert.True(t, ok)
+	assert.Equal(t, types.SecureValue, c.OldValue)
+	assert.Equal(t, types.SecureValue, c.NewValue)
+}
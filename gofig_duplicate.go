@@ -0,0 +1,65 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// DuplicateKeyError is the error that occurs when AllowDuplicateKeys is false
+// and a key is registered by more than one registration.
+type DuplicateKeyError struct {
+
+	// Key is the name of the key.
+	Key string
+
+	// Registration is the name of the registration that registered the key
+	// first.
+	Registration string
+
+	// ConflictingRegistration is the name of the registration that
+	// registered the key again.
+	ConflictingRegistration string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf(
+		"duplicate key: %s: registered by %q and %q",
+		e.Key, e.Registration, e.ConflictingRegistration)
+}
+
+// RegisterOrUpdate registers the configuration with the config package,
+// replacing the registration with the same name if there is one. It is the
+// same as Register, which also replaces a registration with the same name,
+// but makes the replacement explicit to the reader.
+func RegisterOrUpdate(r types.ConfigRegistration) {
+	Register(r)
+}
+
+// checkDuplicateKeys returns a *DuplicateKeyError if one of the
+// registration's keys is in the map of the names of the keys already
+// processed, keyed by their lower-case names, and the names of the
+// registrations that registered them. Otherwise the registration's keys are
+// added to the map.
+func checkDuplicateKeys(
+	r types.ConfigRegistration, keys map[string]string) error {
+
+	regKeys := []string{}
+	for k := range r.Keys() {
+		regKeys = append(regKeys, k.KeyName())
+	}
+	for _, k := range regKeys {
+		if name, ok := keys[strings.ToLower(k)]; ok {
+			return &DuplicateKeyError{
+				Key:                     k,
+				Registration:            name,
+				ConflictingRegistration: r.Name(),
+			}
+		}
+	}
+	for _, k := range regKeys {
+		keys[strings.ToLower(k)] = r.Name()
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	newConfigDirs("TestDuplicateKeys", t)
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+
+	r1 := newRegistration("Test Duplicate 1")
+	r1.Key(types.String, "", "a", "", "testDup.host")
+	Register(r1)
+	r2 := newRegistration("Test Duplicate 2")
+	r2.Key(types.Int, "", 1, "", "testDup.port")
+	r2.Key(types.String, "", "b", "", "testDup.Host")
+	Register(r2)
+
+	c := New()
+	assert.NoError(t, c.Reset())
+
+	AllowDuplicateKeys = false
+	defer func() { AllowDuplicateKeys = true }()
+
+	err := c.Reset()
+	if assert.IsType(t, &DuplicateKeyError{}, err) {
+		derr := err.(*DuplicateKeyError)
+		assert.Equal(t, "testDup.Host", derr.Key)
+		assert.Equal(t, "Test Duplicate 1", derr.Registration)
+		assert.Equal(t, "Test Duplicate 2", derr.ConflictingRegistration)
+		assert.EqualError(t, err, `duplicate key: testDup.Host: `+
+			`registered by "Test Duplicate 1" and "Test Duplicate 2"`)
+	}
+
+	r3 := newRegistration("Test Duplicate 2")
+	r3.Key(types.Int, "", 1, "", "testDup.port")
+	RegisterOrUpdate(r3)
+	assert.NoError(t, c.Reset())
+	assert.Equal(t, "a", c.GetString("testDup.host"))
+	assert.Equal(t, 1, c.GetInt("testDup.port"))
+}
@@ -0,0 +1,91 @@
+package gofig
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/akutz/goof"
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+func enumKey(k *configRegKey) {
+	enumKeysRWL.Lock()
+	defer enumKeysRWL.Unlock()
+	enumKeys[strings.ToLower(k.keyName)] = k
+}
+
+// getEnumKey returns the StringEnum key with the specified name.
+func getEnumKey(k string) (types.ConfigRegistrationKey, bool) {
+	enumKeysRWL.RLock()
+	defer enumKeysRWL.RUnlock()
+	rk, ok := enumKeys[strings.ToLower(k)]
+	return rk, ok
+}
+
+// isAllowedValue returns a flag indicating whether or not the value is one of
+// the key's allowed values.
+func isAllowedValue(rk types.ConfigRegistrationKey, v string) bool {
+	for _, av := range rk.AllowedValues() {
+		if av == v {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidEnumValueError returns an error if the key is a StringEnum key and
+// the value is not one of the key's allowed values.
+func invalidEnumValueError(k string, v interface{}) ConfigError {
+	rk, ok := getEnumKey(k)
+	if !ok || isAllowedValue(rk, cast.ToString(v)) {
+		return nil
+	}
+	return NewConfigError(ErrCodeInvalidValue, k, goof.WithFields(
+		map[string]interface{}{
+			"value":         v,
+			"allowedValues": rk.AllowedValues(),
+		}, "value is not allowed"))
+}
+
+// warnInvalidEnumValue logs a warning if the key is a StringEnum key and the
+// value is not one of the key's allowed values.
+func warnInvalidEnumValue(k string, v interface{}) {
+	if err := invalidEnumValueError(k, v); err != nil {
+		logger().Warn("invalid enum value", "key", k, "error", err)
+	}
+}
+
+// enumValue returns the value if the key is not a StringEnum key or the value
+// is one of the key's allowed values. Otherwise the key's default value is
+// returned.
+func enumValue(k, v string) string {
+	rk, ok := getEnumKey(k)
+	if !ok || isAllowedValue(rk, v) {
+		return v
+	}
+	return cast.ToString(rk.DefaultValue())
+}
+
+// checkEnumSettings returns a *ValidationError listing the StringEnum keys
+// present in the settings read from a config stream with values that are not
+// allowed.
+func checkEnumSettings(m map[string]interface{}) error {
+	flat := Flatten(m)
+	keys := []string{}
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	verr := &ValidationError{}
+	for _, k := range keys {
+		if err := invalidEnumValueError(realKey(k), flat[k]); err != nil {
+			verr.Errors = append(verr.Errors, err)
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
@@ -0,0 +1,56 @@
+package gofig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestStringEnum(t *testing.T) {
+	newConfigDirs("TestStringEnum", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Enum")
+	r.Key(types.StringEnum, "", "info", "The log level",
+		"testEnum.logLevel",
+		[]string{"debug", "info", "warn", "error"})
+	Register(r)
+
+	var rk types.ConfigRegistrationKey
+	for k := range r.Keys() {
+		rk = k
+	}
+	assert.Equal(t, "testEnumLogLevel", rk.FlagName())
+	assert.Equal(t, "TESTENUM_LOGLEVEL", rk.EnvVarName())
+	assert.Equal(t,
+		[]string{"debug", "info", "warn", "error"}, rk.AllowedValues())
+
+	c := New()
+	assert.Equal(t, "info", c.GetString("testEnum.logLevel"))
+
+	err := c.ReadConfig(strings.NewReader(`
+testEnum:
+  logLevel: verbose
+`))
+	assert.IsType(t, &ValidationError{}, err)
+	verr := err.(*ValidationError)
+	assert.Len(t, verr.Errors, 1)
+	assert.Equal(t, ErrCodeInvalidValue, verr.Errors[0].Code())
+	assert.Equal(t, "testenum.loglevel", verr.Errors[0].Key())
+	assert.Equal(t, "info", c.GetString("testEnum.logLevel"))
+
+	assert.NoError(t, c.ReadConfig(strings.NewReader(`
+testEnum:
+  logLevel: debug
+`)))
+	assert.Equal(t, "debug", c.GetString("testEnum.logLevel"))
+
+	c.Set("testEnum.logLevel", "verbose")
+	assert.Equal(t, "info", c.GetString("testEnum.logLevel"))
+	c.Set("testEnum.logLevel", "error")
+	assert.Equal(t, "error", c.GetString("testEnum.logLevel"))
+}
@@ -0,0 +1,67 @@
+package gofig
+
+import (
+	"bufio"
+	"os"
+	"sync"
+)
+
+var (
+	envFilePaths    []string
+	envFilePathsRWL = &sync.RWMutex{}
+)
+
+// SetEnvFilePaths sets the paths of the environment files loaded with
+// LoadEnvFile, in order, by Config instances created after this function is
+// called. Since a variable is set only if it is not already set, a variable
+// defined in more than one file has the value from the first file.
+func SetEnvFilePaths(paths ...string) {
+	envFilePathsRWL.Lock()
+	defer envFilePathsRWL.Unlock()
+	envFilePaths = append([]string{}, paths...)
+}
+
+func getEnvFilePaths() []string {
+	envFilePathsRWL.RLock()
+	defer envFilePathsRWL.RUnlock()
+	return envFilePaths
+}
+
+// LoadEnvFile reads the KEY=VALUE lines from the file and sets each of the
+// environment variables that is not already set. Blank lines and lines that
+// begin with # are skipped.
+func LoadEnvFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := envVarRx.FindStringSubmatch(scanner.Text())
+		if m == nil || len(m) < 3 || os.Getenv(m[1]) != "" {
+			continue
+		}
+		os.Setenv(m[1], m[2])
+	}
+	return scanner.Err()
+}
+
+func (c *config) LoadEnvFile(path string) error {
+	return LoadEnvFile(path)
+}
+
+// loadEnvFiles loads the environment files set with SetEnvFilePaths.
+func (c *config) loadEnvFiles() {
+	for _, path := range getEnvFilePaths() {
+		logger().Debug("loading env file", "path", path)
+		if err := LoadEnvFile(path); err != nil {
+			logger().Debug("error loading env file",
+				"path", path, "error", err)
+		}
+	}
+}
+
+func loadEtcEnvironment() {
+	LoadEnvFile("/etc/environment")
+}
@@ -0,0 +1,81 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeEnvFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gofig-test-TestLoadEnvFile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv("GOFIG_TEST_ENV_FILE_A")
+	defer os.Unsetenv("GOFIG_TEST_ENV_FILE_B")
+	defer os.Unsetenv("GOFIG_TEST_ENV_FILE_C")
+
+	os.Setenv("GOFIG_TEST_ENV_FILE_C", "preset")
+	path := writeEnvFile(t, dir, "env", `# a comment
+GOFIG_TEST_ENV_FILE_A=1
+
+  # an indented comment
+GOFIG_TEST_ENV_FILE_B=two words
+#GOFIG_TEST_ENV_FILE_D=commented
+not a variable
+GOFIG_TEST_ENV_FILE_C=overridden
+`)
+	assert.NoError(t, LoadEnvFile(path))
+	assert.Equal(t, "1", os.Getenv("GOFIG_TEST_ENV_FILE_A"))
+	assert.Equal(t, "two words", os.Getenv("GOFIG_TEST_ENV_FILE_B"))
+	assert.Equal(t, "preset", os.Getenv("GOFIG_TEST_ENV_FILE_C"))
+	_, ok := os.LookupEnv("GOFIG_TEST_ENV_FILE_D")
+	assert.False(t, ok)
+
+	assert.Error(t, LoadEnvFile(filepath.Join(dir, "missing")))
+}
+
+func TestSetEnvFilePaths(t *testing.T) {
+	newConfigDirs("TestSetEnvFilePaths", t)
+	wipeEnv()
+	dir, err := ioutil.TempDir("", "gofig-test-TestSetEnvFilePaths")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv("GOFIG_TEST_ENV_FILE_HOST")
+	defer os.Unsetenv("GOFIG_TEST_ENV_FILE_PORT")
+	defer SetEnvFilePaths()
+
+	p1 := writeEnvFile(t, dir, "env1", "GOFIG_TEST_ENV_FILE_HOST=first\n")
+	p2 := writeEnvFile(t, dir, "env2",
+		"GOFIG_TEST_ENV_FILE_HOST=second\nGOFIG_TEST_ENV_FILE_PORT=80\n")
+	SetEnvFilePaths(p1, filepath.Join(dir, "missing"), p2)
+
+	New()
+	assert.Equal(t, "first", os.Getenv("GOFIG_TEST_ENV_FILE_HOST"))
+	assert.Equal(t, "80", os.Getenv("GOFIG_TEST_ENV_FILE_PORT"))
+}
+
+func TestConfigLoadEnvFile(t *testing.T) {
+	newConfigDirs("TestConfigLoadEnvFile", t)
+	wipeEnv()
+	dir, err := ioutil.TempDir("", "gofig-test-TestConfigLoadEnvFile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv("REXRAY_LOGLEVEL")
+
+	c := New()
+	path := writeEnvFile(t, dir, "env", "REXRAY_LOGLEVEL=debug\n")
+	assert.Equal(t, ErrReadOnly, ReadOnly(c).LoadEnvFile(path))
+	assert.NoError(t, c.LoadEnvFile(path))
+	assert.Equal(t, "debug", c.GetString("rexray.logLevel"))
+}
@@ -0,0 +1,86 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	envKeyPrefix    string
+	envKeyPrefixRWL = &sync.RWMutex{}
+)
+
+// ConfigOption is an option used to configure the Config instances returned
+// by New and NewConfig.
+type ConfigOption func(c *config)
+
+// SetEnvKeyPrefix sets the prefix of the environment variables bound to keys
+// by Config instances created after this function is called. For example,
+// with the prefix "MYAPP" the key "service.host" is bound to the environment
+// variable MYAPP_SERVICE_HOST instead of SERVICE_HOST. The prefix is also
+// used by the EnvVars function.
+func SetEnvKeyPrefix(prefix string) {
+	envKeyPrefixRWL.Lock()
+	defer envKeyPrefixRWL.Unlock()
+	envKeyPrefix = prefix
+}
+
+func getEnvKeyPrefix() string {
+	envKeyPrefixRWL.RLock()
+	defer envKeyPrefixRWL.RUnlock()
+	return envKeyPrefix
+}
+
+// WithEnvKeyPrefix configures the config to use the specified prefix for the
+// environment variables bound to keys instead of the prefix set with
+// SetEnvKeyPrefix.
+func WithEnvKeyPrefix(prefix string) ConfigOption {
+	return func(c *config) {
+		c.envKeyPrefix = prefix
+	}
+}
+
+// prefixEnvVarName returns the name of the environment variable with the
+// prefix prepended to it.
+func prefixEnvVarName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s_%s", strings.ToUpper(prefix), name)
+}
+
+// envVarName returns the name of the environment variable with the config's
+// prefix prepended to it.
+func (c *config) envVarName(name string) string {
+	return prefixEnvVarName(c.envKeyPrefix, name)
+}
+
+func (c *config) EnvVarsWithPrefix(prefix string) []string {
+	flat := Flatten(c.allSettings())
+	for k := range flat {
+		if isSecureConfigKey(c, k) {
+			delete(flat, k)
+		}
+	}
+	envVars := map[string]string{}
+	c.flattenEnvVars("", unflattenMapKeys(flat), envVars)
+	evArr := []string{}
+	for k, v := range envVars {
+		evArr = append(evArr, fmt.Sprintf("%s=%v", k, v))
+	}
+	return prefixEnvVars(prefix, evArr)
+}
+
+// prefixEnvVars returns the key=value strings with the prefix and an
+// underscore prepended to each of them. Any trailing underscores are removed
+// from the prefix so the prefix is never followed by more than one
+// underscore.
+func prefixEnvVars(prefix string, envVars []string) []string {
+	prefix = strings.TrimRight(prefix, "_")
+	evArr := make([]string, len(envVars))
+	for x, ev := range envVars {
+		evArr[x] = prefixEnvVarName(prefix, ev)
+	}
+	return evArr
+}
@@ -0,0 +1,90 @@
+package gofig
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestEnvKeyPrefix(t *testing.T) {
+	newConfigDirs("TestEnvKeyPrefix", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Env Prefix")
+	r.Key(types.String, "", "localhost", "The host", "testEnvPrefix.host")
+	Register(r)
+
+	os.Setenv("TESTENVPREFIX_HOST", "host1")
+	os.Setenv("MYAPP_TESTENVPREFIX_HOST", "host2")
+	os.Setenv("OTHER_TESTENVPREFIX_HOST", "host3")
+	defer os.Unsetenv("TESTENVPREFIX_HOST")
+	defer os.Unsetenv("MYAPP_TESTENVPREFIX_HOST")
+	defer os.Unsetenv("OTHER_TESTENVPREFIX_HOST")
+
+	c := New()
+	assert.Equal(t, "host1", c.GetString("testEnvPrefix.host"))
+
+	SetEnvKeyPrefix("myapp")
+	defer SetEnvKeyPrefix("")
+
+	c = New()
+	assert.Equal(t, "host2", c.GetString("testEnvPrefix.host"))
+	assert.Equal(t, "host2", c.Scope("testEnvPrefix").GetString("host"))
+	assert.Equal(t, types.EnvVar, c.GetSource("testEnvPrefix.host"))
+	assert.Contains(t, c.EnvVars(), "MYAPP_TESTENVPREFIX_HOST=host2")
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Contains(t, cc.EnvVars(), "MYAPP_TESTENVPREFIX_HOST=host2")
+
+	assert.NoError(t, c.Reset())
+	assert.Equal(t, "host2", c.GetString("testEnvPrefix.host"))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, GenerateMarkdown([]types.ConfigRegistration{r}, buf))
+	assert.Contains(t, buf.String(), "`MYAPP_TESTENVPREFIX_HOST`")
+
+	c = New(WithEnvKeyPrefix("OTHER"))
+	assert.Equal(t, "host3", c.GetString("testEnvPrefix.host"))
+	assert.Contains(t, c.EnvVars(), "OTHER_TESTENVPREFIX_HOST=host3")
+}
+
+func TestEnvVarsWithPrefix(t *testing.T) {
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+	r := newRegistration("Test Env Vars Prefix")
+	r.Key(types.SecureString, "", "", "", "testEnvVars.password")
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	c.Set("testEnvVars.host", "localhost")
+	c.Set("testEnvVars.port", 8080)
+	c.Set("testEnvVars.password", "secret")
+
+	ev := c.EnvVarsWithPrefix("MY_SERVICE")
+	assert.ElementsMatch(t, []string{
+		"MY_SERVICE_TESTENVVARS_HOST=localhost",
+		"MY_SERVICE_TESTENVVARS_PORT=8080",
+	}, ev)
+	assert.ElementsMatch(t, ev, c.EnvVarsWithPrefix("my_service_"))
+	for _, v := range ev {
+		assert.NotContains(t, v, "__")
+		assert.NotContains(t, v, "secret")
+	}
+
+	assert.ElementsMatch(t, []string{
+		"TESTENVVARS_HOST=localhost",
+		"TESTENVVARS_PORT=8080",
+	}, c.EnvVarsWithPrefix(""))
+	assert.Contains(t, c.EnvVars(), "TESTENVVARS_PASSWORD=secret")
+
+	assert.ElementsMatch(t, []string{
+		"APP_HOST=localhost",
+		"APP_PORT=8080",
+	}, c.StrictScope("testEnvVars").EnvVarsWithPrefix("APP"))
+}
@@ -0,0 +1,74 @@
+package gofig
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	envSliceDelim    = ","
+	envSliceDelimRWL = &sync.RWMutex{}
+)
+
+// SetEnvSliceDelim sets the delimiter used to split the value of an
+// environment variable into the elements of the slice returned by the
+// GetStringSlice function, and to join the elements of a slice when the
+// config's settings are flattened into environment variables by the EnvVars
+// function. The default delimiter is a comma.
+func SetEnvSliceDelim(d string) {
+	envSliceDelimRWL.Lock()
+	defer envSliceDelimRWL.Unlock()
+	envSliceDelim = d
+}
+
+func getEnvSliceDelim() string {
+	envSliceDelimRWL.RLock()
+	defer envSliceDelimRWL.RUnlock()
+	return envSliceDelim
+}
+
+// keyEnvVarName returns the name of the environment variable for the key.
+// The name of the environment variable bound to the key is returned if the
+// key is registered, otherwise the name is derived from the key's name.
+func (c *config) keyEnvVarName(k string) string {
+	if kb, ok := c.keyBindings[strings.ToLower(k)]; ok && kb.envVar != "" {
+		return kb.envVar
+	}
+	return c.envVarName(strings.ToUpper(strings.Replace(k, ".", "_", -1)))
+}
+
+// keyDelim returns the delimiter used to split the value of the key's
+// environment variable into a slice. The delimiter registered for the key
+// with a DelimOpt is returned if there is one, otherwise the env slice
+// delimiter is returned.
+func (c *config) keyDelim(k string) string {
+	if kb, ok := c.keyBindings[strings.ToLower(k)]; ok && kb.delim != "" {
+		return kb.delim
+	}
+	return getEnvSliceDelim()
+}
+
+// envSliceValue returns the value of the key's environment variable split
+// into a slice using the specified delimiter. The second return value is
+// false if the environment variable is not set.
+func (c *config) envSliceValue(k, delim string) ([]string, bool) {
+	v := os.Getenv(c.keyEnvVarName(k))
+	if v == "" {
+		return nil, false
+	}
+	return splitSlice(v, delim), true
+}
+
+// splitSlice splits the string into a slice using the specified delimiter.
+// Leading and trailing white space is removed from the elements and empty
+// elements are omitted.
+func splitSlice(v, delim string) []string {
+	ss := []string{}
+	for _, s := range strings.Split(v, delim) {
+		if s = strings.TrimSpace(s); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
@@ -0,0 +1,33 @@
+package gofig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvSlice(t *testing.T) {
+	newConfigDirs("TestEnvSlice", t)
+	wipeEnv()
+
+	os.Setenv("MYAPP_SERVERS", "host1,host2,host3")
+	defer os.Unsetenv("MYAPP_SERVERS")
+
+	c := New(WithEnvKeyPrefix("MYAPP"))
+	assert.Equal(t,
+		[]string{"host1", "host2", "host3"}, c.GetStringSlice("servers"))
+
+	os.Setenv("MYAPP_SERVERS", "")
+	c.Set("servers", []string{"host4", "host5"})
+	assert.Equal(t, []string{"host4", "host5"}, c.GetStringSlice("servers"))
+	assert.Contains(t, c.EnvVars(), "MYAPP_SERVERS=host4,host5")
+
+	SetEnvSliceDelim(";")
+	defer SetEnvSliceDelim(",")
+
+	os.Setenv("MYAPP_SERVERS", "host1; host2")
+	assert.Equal(t, []string{"host1", "host2"}, c.GetStringSlice("servers"))
+	os.Setenv("MYAPP_SERVERS", "")
+	assert.Contains(t, c.EnvVars(), "MYAPP_SERVERS=host4;host5")
+}
@@ -0,0 +1,62 @@
+package gofig
+
+import (
+	"reflect"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) DeepEqual(other types.Config) bool {
+	return c != nil && deepEqual(c, other)
+}
+func (c *scopedConfig) DeepEqual(other types.Config) bool {
+	return c != nil && deepEqual(c, other)
+}
+func (c *childConfig) DeepEqual(other types.Config) bool {
+	return c != nil && deepEqual(c, other)
+}
+
+func (c *config) DeepEqualKeys(other types.Config, keys []string) bool {
+	return c != nil && deepEqualKeys(c, other, keys)
+}
+func (c *scopedConfig) DeepEqualKeys(
+	other types.Config, keys []string) bool {
+	return c != nil && deepEqualKeys(c, other, keys)
+}
+func (c *childConfig) DeepEqualKeys(
+	other types.Config, keys []string) bool {
+	return c != nil && deepEqualKeys(c, other, keys)
+}
+
+// deepEqual returns a flag indicating whether or not the settings of the
+// configs are deeply equal.
+func deepEqual(a, b types.Config) bool {
+	if isNilConfig(b) {
+		return false
+	}
+	return reflect.DeepEqual(a.AllSettings(), b.AllSettings())
+}
+
+// deepEqualKeys returns a flag indicating whether or not the values of the
+// keys are deeply equal in both configs.
+func deepEqualKeys(a, b types.Config, keys []string) bool {
+	if isNilConfig(b) {
+		return false
+	}
+	for _, k := range keys {
+		if !reflect.DeepEqual(a.Get(k), b.Get(k)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNilConfig returns a flag indicating whether or not the config is nil or
+// is an interface that holds a nil pointer.
+func isNilConfig(c types.Config) bool {
+	if c == nil {
+		return true
+	}
+	v := reflect.ValueOf(c)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
@@ -0,0 +1,48 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestDeepEqual(t *testing.T) {
+	newConfigDirs("TestDeepEqual", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Equal")
+	r.Key(types.String, "", "localhost", "", "testEqual.host")
+	r.Key(types.Int, "", 8080, "", "testEqual.port")
+	Register(r)
+
+	y := []byte("testEqual:\n  host: 127.0.0.1\n  port: 9090\n")
+	c1 := New()
+	c2 := New()
+	assert.NoError(t, c1.ReadConfig(bytes.NewReader(y)))
+	assert.NoError(t, c2.ReadConfig(bytes.NewReader(y)))
+	assert.True(t, c1.DeepEqual(c2))
+	assert.True(t, c2.DeepEqual(c1))
+	assert.True(t, c1.Scope("testEqual").DeepEqual(c2.Scope("testEqual")))
+
+	c1.Set("testEqual.port", 9091)
+	assert.False(t, c1.DeepEqual(c2))
+	assert.False(t, c1.DeepEqualKeys(c2, []string{"testEqual.port"}))
+	assert.True(t, c1.DeepEqualKeys(c2, []string{"testEqual.host"}))
+	assert.True(t, c1.DeepEqualKeys(c2, nil))
+
+	assert.NoError(t, c1.ResetToDefaults())
+	assert.NoError(t, c2.ResetToDefaults())
+	assert.True(t, c1.DeepEqual(c2))
+	assert.True(t, c1.DeepEqual(New()))
+	assert.True(t, c1.DeepEqualKeys(c2, []string{"testEqual"}))
+
+	var nc *config
+	assert.False(t, c1.DeepEqual(nil))
+	assert.False(t, c1.DeepEqual(nc))
+	assert.False(t, c1.DeepEqualKeys(nil, []string{"testEqual.host"}))
+	assert.False(t, nc.DeepEqual(c1))
+}
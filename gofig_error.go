@@ -0,0 +1,115 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ErrCodeParseFailed is the code of the error that occurs when a config
+	// stream cannot be parsed.
+	ErrCodeParseFailed = "parse_failed"
+
+	// ErrCodeUnknownKey is the code of the error that occurs when a config
+	// stream read in strict mode contains a key that is not registered.
+	ErrCodeUnknownKey = "unknown_key"
+
+	// ErrCodeInvalidValue is the code of the error that occurs when a config
+	// stream contains a value that is not one of a StringEnum key's allowed
+	// values.
+	ErrCodeInvalidValue = "invalid_value"
+
+	// ErrCodeMutuallyExclusive is the code of the error that occurs when more
+	// than one of a set of mutually exclusive keys is set.
+	ErrCodeMutuallyExclusive = "mutually_exclusive"
+
+	// ErrCodeNoneSet is the code of the error that occurs when none of a set
+	// of keys, of which at least one must be set, is set.
+	ErrCodeNoneSet = "none_set"
+
+	// ErrCodeMissingDefault is the code of the error that occurs when a
+	// registration's YAML does not contain a value for one of its keys.
+	ErrCodeMissingDefault = "missing_default"
+
+	// ErrCodeInvalidType is the code of the error that occurs when a value
+	// does not match the type of the key with which it is associated.
+	ErrCodeInvalidType = "invalid_type"
+
+	// ErrCodeOutOfRange is the code of the error that occurs when the value
+	// of an Int, Float64, or Duration key is outside the key's range.
+	ErrCodeOutOfRange = "out_of_range"
+
+	// ErrCodeMergeConflict is the code of the error that occurs when a config
+	// stream is merged with the ErrorOnConflict strategy and the stream has a
+	// different value for a key that was already read.
+	ErrCodeMergeConflict = "merge_conflict"
+
+	// ErrCodeTemplateExpansion is the code of the error that occurs when the
+	// ${key} references in a key's value cannot be expanded. The error's cause
+	// is a *TemplateExpansionError.
+	ErrCodeTemplateExpansion = "template_expansion"
+
+	// ErrCodeDeprecatedKey is the code of the error that occurs when a
+	// deprecated key is set and ErrorOnDeprecated is true.
+	ErrCodeDeprecatedKey = "deprecated_key"
+)
+
+// ConfigError is an error related to a config key or stream.
+type ConfigError interface {
+	error
+
+	// Code returns the error code, such as ErrCodeParseFailed.
+	Code() string
+
+	// Key returns the name of the key to which the error is related (if
+	// any).
+	Key() string
+
+	// Cause returns the error that caused this error (if any).
+	Cause() error
+}
+
+// configError is the implementation of ConfigError
+type configError struct {
+	code  string
+	key   string
+	cause error
+}
+
+// NewConfigError returns a new ConfigError.
+func NewConfigError(code, key string, cause error) ConfigError {
+	return &configError{code: code, key: key, cause: cause}
+}
+
+func (e *configError) Code() string  { return e.code }
+func (e *configError) Key() string   { return e.key }
+func (e *configError) Cause() error  { return e.cause }
+func (e *configError) Unwrap() error { return e.cause }
+
+func (e *configError) Error() string {
+	msg := e.code
+	if e.key != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.key)
+	}
+	if e.cause != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.cause)
+	}
+	return msg
+}
+
+// ValidationError is an error that aggregates one or more ConfigError
+// values, such as each of the unknown keys found in a config stream read in
+// strict mode.
+type ValidationError struct {
+
+	// Errors are the individual errors.
+	Errors []ConfigError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := []string{}
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("validation failed: %s", strings.Join(msgs, "; "))
+}
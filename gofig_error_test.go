@@ -0,0 +1,52 @@
+package gofig
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigErrorParseFailed(t *testing.T) {
+	newConfigDirs("TestConfigErrorParseFailed", t)
+	wipeEnv()
+
+	c := New()
+	err := c.ReadConfig(bytes.NewReader([]byte("{\"rexray\": ")))
+	var cerr ConfigError
+	if assert.True(t, errors.As(err, &cerr)) {
+		assert.Equal(t, ErrCodeParseFailed, cerr.Code())
+		assert.Equal(t, "", cerr.Key())
+		assert.Error(t, cerr.Cause())
+	}
+	var verr *ValidationError
+	assert.False(t, errors.As(err, &verr))
+
+	tmp, err := ioutil.TempFile("", "TestConfigErrorParseFailed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	tmp.WriteString("rexray:\n  - host\n  logLevel: [")
+	tmp.Close()
+
+	err = c.ReadConfigFile(tmp.Name())
+	if assert.True(t, errors.As(err, &cerr)) {
+		assert.Equal(t, ErrCodeParseFailed, cerr.Code())
+	}
+	assertString(t, c, "rexray.logLevel", "warn")
+}
+
+func TestValidationError(t *testing.T) {
+	err := error(&ValidationError{Errors: []ConfigError{
+		NewConfigError(ErrCodeUnknownKey, "a.b", nil),
+		NewConfigError(ErrCodeUnknownKey, "a.c", nil),
+	}})
+	assert.Equal(t,
+		"validation failed: unknown_key: a.b; unknown_key: a.c", err.Error())
+	var cerr ConfigError
+	assert.False(t, errors.As(err, &cerr))
+}
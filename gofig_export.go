@@ -0,0 +1,174 @@
+package gofig
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/akutz/gofig/types"
+)
+
+var unquotedEnvValRx = regexp.MustCompile(`^[\w@%+=:,./-]*$`)
+
+func (c *config) ExportEnvFile(w io.Writer, includeSecure bool) error {
+	return c.exportEnvVars(w, includeSecure, "", quoteEnvFileValue)
+}
+
+func (c *config) ExportShellScript(w io.Writer, includeSecure bool) error {
+	return c.exportEnvVars(w, includeSecure, "export ", quoteShellValue)
+}
+
+// exportEnvVars writes the config's environment variables to the writer as
+// sorted KEY=VALUE lines. Each line begins with the specified prefix and the
+// values are quoted with the specified function.
+func (c *config) exportEnvVars(
+	w io.Writer,
+	includeSecure bool,
+	linePrefix string,
+	quote func(string) string) error {
+
+	envVars := c.exportableEnvVars(includeSecure)
+	keys := []string{}
+	for k := range envVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err := fmt.Fprintf(
+			w, "%s%s=%s\n", linePrefix, k, quote(envVars[k])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportableEnvVars returns a map of the config's environment variables. If
+// includeSecure is false then the secure keys are omitted.
+func (c *config) exportableEnvVars(includeSecure bool) map[string]string {
+	var m map[string]interface{}
+	if includeSecure {
+		m = c.nestedSettings(false)
+	} else {
+		m = c.nestedSecureSettings()
+	}
+	envVars := map[string]string{}
+	c.flattenEnvVars("", m, envVars)
+	return envVars
+}
+
+// quoteEnvFileValue double-quotes the value if it contains whitespace or
+// special characters.
+func quoteEnvFileValue(s string) string {
+	if unquotedEnvValRx.MatchString(s) {
+		return s
+	}
+	r := strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`)
+	return fmt.Sprintf(`"%s"`, r.Replace(s))
+}
+
+// quoteShellValue single-quotes the value if it contains whitespace or
+// special characters.
+func quoteShellValue(s string) string {
+	if unquotedEnvValRx.MatchString(s) {
+		return s
+	}
+	return fmt.Sprintf("'%s'", strings.Replace(s, "'", `'\''`, -1))
+}
+
+// ExportHelmValues writes the config's settings to the writer as a Helm
+// values.yaml file that begins with a comment indicating when the file was
+// generated. The value of each secure key is replaced with a reference to a
+// Helm value named after the key, such as {{ .Values.dbPassword }} for the key
+// db.password.
+func ExportHelmValues(c types.Config, w io.Writer) error {
+	flat := flatConfigSettings(c)
+	for k := range flat {
+		if isSecureConfigKey(c, k) {
+			flat[k] = fmt.Sprintf("{{ .Values.%s }}", helmValueName(k))
+		}
+	}
+	buf, err := yaml.Marshal(unflattenMapKeys(flat))
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w,
+		"# This file was auto-generated by gofig at %s.\n",
+		time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// helmValueName returns the camel-case form of the secure key's registered
+// name.
+func helmValueName(k string) string {
+	secureKeysRWL.RLock()
+	if rk, ok := secureKeys[strings.ToLower(k)]; ok {
+		k = rk.KeyName()
+	}
+	secureKeysRWL.RUnlock()
+	kp := strings.Split(k, ".")
+	for x := 1; x < len(kp); x++ {
+		kp[x] = strings.Title(kp[x])
+	}
+	return strings.Join(kp, "")
+}
+
+// ExportSystemdEnvironmentFile writes the config's environment variables to
+// the writer as sorted KEY="VALUE" lines that may be loaded with the
+// EnvironmentFile setting of a systemd unit. The elements of a slice value
+// are joined with spaces. The secure keys are omitted.
+func ExportSystemdEnvironmentFile(c types.Config, w io.Writer) error {
+	cc, _ := unwrapConfig(c)
+	envVars := map[string]string{}
+	for k, v := range flatConfigSettings(c) {
+		if isSecureConfigKey(c, k) {
+			continue
+		}
+		var s string
+		switch tv := v.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+			continue
+		case []string:
+			s = strings.Join(tv, " ")
+		case []interface{}:
+			s = strings.Join(cast.ToStringSlice(tv), " ")
+		default:
+			s = cast.ToString(tv)
+		}
+		name := strings.ToUpper(strings.Replace(k, ".", "_", -1))
+		if cc != nil {
+			name = cc.envVarName(name)
+		}
+		envVars[name] = s
+	}
+	keys := []string{}
+	for k := range envVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err := fmt.Fprintf(
+			w, "%s=%s\n", k, quoteSystemdValue(envVars[k])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quoteSystemdValue double-quotes the value and escapes the characters that
+// systemd treats as special in a double-quoted environment file value. A
+// newline is written as is since systemd preserves the newlines in a
+// double-quoted value.
+func quoteSystemdValue(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+	return fmt.Sprintf(`"%s"`, r.Replace(s))
+}
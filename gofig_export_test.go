@@ -0,0 +1,144 @@
+package gofig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func newExportTestConfig(t *testing.T) types.Config {
+	r := newRegistration("Test Export")
+	r.Key(types.SecureString, "", "", "", "testExport.password")
+
+	c := New()
+	c.Set("testExport.password", "secret")
+	c.Set("testExport.greeting", "hello world")
+	c.Set("testExport.quote", `it's "$HOME"`)
+	c.Set("testExport.port", 8080)
+	return c
+}
+
+func TestExportEnvFile(t *testing.T) {
+	newConfigDirs("TestExportEnvFile", t)
+	wipeEnv()
+
+	c := newExportTestConfig(t)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, c.ExportEnvFile(buf, false))
+	s := buf.String()
+	assert.Contains(t, s, "REXRAY_HOST=tcp://:7979\n")
+	assert.Contains(t, s, "TESTEXPORT_GREETING=\"hello world\"\n")
+	assert.Contains(t, s, "TESTEXPORT_QUOTE=\"it's \\\"\\$HOME\\\"\"\n")
+	assert.Contains(t, s, "TESTEXPORT_PORT=8080\n")
+	assert.NotContains(t, s, "TESTEXPORT_PASSWORD")
+
+	buf.Reset()
+	assert.NoError(t, c.ExportEnvFile(buf, true))
+	assert.Contains(t, buf.String(), "TESTEXPORT_PASSWORD=secret\n")
+}
+
+func TestExportShellScript(t *testing.T) {
+	newConfigDirs("TestExportShellScript", t)
+	wipeEnv()
+
+	c := newExportTestConfig(t)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, c.ExportShellScript(buf, false))
+	s := buf.String()
+	assert.Contains(t, s, "export REXRAY_HOST=tcp://:7979\n")
+	assert.Contains(t, s, "export TESTEXPORT_GREETING='hello world'\n")
+	assert.Contains(t, s, "export TESTEXPORT_QUOTE='it'\\''s \"$HOME\"'\n")
+	assert.NotContains(t, s, "TESTEXPORT_PASSWORD")
+
+	buf.Reset()
+	assert.NoError(t, c.ExportShellScript(buf, true))
+	assert.Contains(t, buf.String(), "export TESTEXPORT_PASSWORD=secret\n")
+}
+
+func TestExportHelmValues(t *testing.T) {
+	newConfigDirs("TestExportHelmValues", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Helm")
+	r.Key(types.SecureString, "", "", "", "testHelm.db.password")
+	c := New()
+	c.Set("testHelm.db.password", "secret")
+	c.Set("testHelm.db.host", "db.example.com")
+	c.Set("testHelm.replicas", 3)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, ExportHelmValues(c, buf))
+	s := buf.String()
+	t.Log(s)
+	assert.True(t, strings.HasPrefix(
+		s, "# This file was auto-generated by gofig at "))
+	assert.NotContains(t, s, "---")
+	assert.NotContains(t, s, "secret")
+
+	m, err := ValidateYAML(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	helm, ok := m["testhelm"].(map[interface{}]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, 3, helm["replicas"])
+	db, ok := helm["db"].(map[interface{}]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "db.example.com", db["host"])
+	assert.Equal(t, "{{ .Values.testHelmDbPassword }}", db["password"])
+	rexray, ok := m["rexray"].(map[interface{}]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "tcp://:7979", rexray["host"])
+
+	buf.Reset()
+	assert.NoError(t, ExportHelmValues(c.Scope("testHelm"), buf))
+	assert.NotContains(t, buf.String(), "secret")
+	m, err = ValidateYAML(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	db, ok = m["db"].(map[interface{}]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "db.example.com", db["host"])
+	assert.Equal(t, "{{ .Values.dbPassword }}", db["password"])
+}
+
+func TestQuoteSystemdValue(t *testing.T) {
+	assert.Equal(t, `""`, quoteSystemdValue(""))
+	assert.Equal(t, `"hello world"`, quoteSystemdValue("hello world"))
+	assert.Equal(t, `"say \"hi\""`, quoteSystemdValue(`say "hi"`))
+	assert.Equal(t, "\"line1\nline2\"", quoteSystemdValue("line1\nline2"))
+	assert.Equal(t, `"\$HOME/\${USER}"`, quoteSystemdValue("$HOME/${USER}"))
+	assert.Equal(t, `"C:\\temp"`, quoteSystemdValue(`C:\temp`))
+	assert.Equal(t, "\"\\`date\\`\"", quoteSystemdValue("`date`"))
+	assert.Equal(t, `"it's"`, quoteSystemdValue("it's"))
+}
+
+func TestExportSystemdEnvironmentFile(t *testing.T) {
+	newConfigDirs("TestExportSystemdEnvironmentFile", t)
+	wipeEnv()
+	TestHook(t)
+
+	c := newExportTestConfig(t)
+	c.Set("testExport.hosts", []string{"a.example.com", "b.example.com"})
+	buf := &bytes.Buffer{}
+	assert.NoError(t, ExportSystemdEnvironmentFile(c, buf))
+	s := buf.String()
+	assert.Contains(t, s, "REXRAY_HOST=\"tcp://:7979\"\n")
+	assert.Contains(t, s, "TESTEXPORT_GREETING=\"hello world\"\n")
+	assert.Contains(t, s, "TESTEXPORT_QUOTE=\"it's \\\"\\$HOME\\\"\"\n")
+	assert.Contains(t, s, "TESTEXPORT_PORT=\"8080\"\n")
+	assert.Contains(t, s,
+		"TESTEXPORT_HOSTS=\"a.example.com b.example.com\"\n")
+	assert.NotContains(t, s, "TESTEXPORT_PASSWORD")
+	assert.NotContains(t, s, "secret")
+	assert.NotContains(t, s, "export ")
+
+	buf.Reset()
+	assert.NoError(t, ExportSystemdEnvironmentFile(c.Scope("testExport"), buf))
+	s = buf.String()
+	assert.Contains(t, s, "PORT=\"8080\"\n")
+	assert.NotContains(t, s, "PASSWORD")
+	assert.NotContains(t, s, "secret")
+}
@@ -0,0 +1,263 @@
+package gofig
+
+import (
+	"io"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// childConfig is a config with its own settings that reads the keys it does
+// not set from its parent.
+type childConfig struct {
+	*config
+	parent types.Config
+}
+
+func newChildConfig(parent types.Config) *childConfig {
+	c := newConfigObj()
+	c.configName = "config"
+	c.configType = "yml"
+	c.publishSnapshot()
+	return &childConfig{config: c, parent: parent}
+}
+
+func (c *config) Extend() types.Config {
+	return newChildConfig(c)
+}
+func (c *scopedConfig) Extend() types.Config {
+	return newChildConfig(c)
+}
+func (c *childConfig) Extend() types.Config {
+	return newChildConfig(c)
+}
+
+func (c *childConfig) Parent() types.Config {
+	return c.parent
+}
+
+func (c *childConfig) Scope(scope interface{}) types.Config {
+	return &scopedConfig{Config: c, scope: toString(scope)}
+}
+
+func (c *childConfig) Copy() (types.Config, error) {
+	return c.merged(), nil
+}
+
+// merged returns a copy of the child's own config with the settings of the
+// keys the child does not set copied from its parent. The settings of the
+// child are read and serialized through the copy so that they include the
+// settings inherited from the parent.
+func (c *childConfig) merged() *config {
+	own := flatConfigSettings(c.config)
+	cc, _ := c.config.Copy()
+	mc := cc.(*config)
+	mc.keyPrefix = strings.TrimSuffix(qualifiedConfigKey(c.parent, ""), ".")
+	for k, v := range flatConfigSettings(c.parent) {
+		if _, ok := own[k]; !ok {
+			mc.b.Set(k, v)
+			mc.overrideKeys[k] = true
+		}
+	}
+	mc.publishSnapshot()
+	return mc
+}
+
+func (c *childConfig) AllKeys() []string {
+	ak := c.config.AllKeys()
+	seen := map[string]bool{}
+	for _, k := range ak {
+		seen[strings.ToLower(k)] = true
+	}
+	for _, k := range c.parent.AllKeys() {
+		if !seen[strings.ToLower(k)] {
+			ak = append(ak, k)
+		}
+	}
+	return ak
+}
+
+func (c *childConfig) AllSettings() map[string]interface{} {
+	flat := flatConfigSettings(c.parent)
+	for k, v := range flatConfigSettings(c.config) {
+		flat[k] = v
+	}
+	return unflattenMapKeys(flat)
+}
+
+func (c *childConfig) IsSet(k interface{}) bool {
+	return c.config.IsSet(k) || c.parent.IsSet(k)
+}
+
+func (c *childConfig) Get(k interface{}) interface{} {
+	if c.config.IsSet(k) {
+		return c.config.Get(k)
+	}
+	return c.parent.Get(k)
+}
+
+func (c *childConfig) GetString(k interface{}) string {
+	if c.config.IsSet(k) {
+		return c.config.GetString(k)
+	}
+	return c.parent.GetString(k)
+}
+
+func (c *childConfig) GetBool(k interface{}) bool {
+	if c.config.IsSet(k) {
+		return c.config.GetBool(k)
+	}
+	return c.parent.GetBool(k)
+}
+
+func (c *childConfig) GetStringSlice(k interface{}) []string {
+	if c.config.IsSet(k) {
+		return c.config.GetStringSlice(k)
+	}
+	return c.parent.GetStringSlice(k)
+}
+
+func (c *childConfig) GetInt(k interface{}) int {
+	if c.config.IsSet(k) {
+		return c.config.GetInt(k)
+	}
+	return c.parent.GetInt(k)
+}
+
+func (c *childConfig) GetSource(k interface{}) types.ConfigSource {
+	if c.config.IsSet(k) {
+		return c.config.GetSource(k)
+	}
+	return c.parent.GetSource(k)
+}
+
+func (c *childConfig) TryGetString(k interface{}) (string, bool) {
+	if c.config.IsSet(k) {
+		return c.config.TryGetString(k)
+	}
+	return c.parent.TryGetString(k)
+}
+
+func (c *childConfig) TryGetInt(k interface{}) (int, bool) {
+	if c.config.IsSet(k) {
+		return c.config.TryGetInt(k)
+	}
+	return c.parent.TryGetInt(k)
+}
+
+func (c *childConfig) TryGetBool(k interface{}) (bool, bool) {
+	if c.config.IsSet(k) {
+		return c.config.TryGetBool(k)
+	}
+	return c.parent.TryGetBool(k)
+}
+
+func (c *childConfig) GetStringOrDefault(k interface{}, def string) string {
+	if c.config.IsSet(k) {
+		return c.config.GetString(k)
+	}
+	return c.parent.GetStringOrDefault(k, def)
+}
+
+func (c *childConfig) GetIntOrDefault(k interface{}, def int) int {
+	if c.config.IsSet(k) {
+		return c.config.GetInt(k)
+	}
+	return c.parent.GetIntOrDefault(k, def)
+}
+
+func (c *childConfig) GetBoolOrDefault(k interface{}, def bool) bool {
+	if c.config.IsSet(k) {
+		return c.config.GetBool(k)
+	}
+	return c.parent.GetBoolOrDefault(k, def)
+}
+
+func (c *childConfig) GetFloat64OrDefault(k interface{}, def float64) float64 {
+	if c.config.IsSet(k) {
+		return c.config.GetFloat64OrDefault(k, def)
+	}
+	return c.parent.GetFloat64OrDefault(k, def)
+}
+
+func (c *childConfig) GetByPath(path string) (interface{}, error) {
+	return c.merged().GetByPath(path)
+}
+
+func (c *childConfig) Unmarshal(rawVal interface{}) error {
+	return c.merged().Unmarshal(rawVal)
+}
+
+func (c *childConfig) UnmarshalKey(k interface{}, rawVal interface{}) error {
+	return c.merged().UnmarshalKey(k, rawVal)
+}
+
+func (c *childConfig) EnvVars() []string {
+	return c.merged().EnvVars()
+}
+
+func (c *childConfig) EnvVarsWithPrefix(prefix string) []string {
+	return c.merged().EnvVarsWithPrefix(prefix)
+}
+
+func (c *childConfig) LookupEnvVar(k interface{}) (string, string, bool) {
+	return c.merged().LookupEnvVar(k)
+}
+
+func (c *childConfig) MarshalJSON() ([]byte, error) {
+	return c.merged().MarshalJSON()
+}
+
+func (c *childConfig) ToJSON() (string, error) {
+	return c.merged().ToJSON()
+}
+
+func (c *childConfig) ToJSONCompact() (string, error) {
+	return c.merged().ToJSONCompact()
+}
+
+func (c *childConfig) MaskedToJSON() (string, error) {
+	return c.merged().MaskedToJSON()
+}
+
+func (c *childConfig) MaskedToJSONCompact() (string, error) {
+	return c.merged().MaskedToJSONCompact()
+}
+
+func (c *childConfig) ToYAML() (string, error) {
+	return c.merged().ToYAML()
+}
+
+func (c *childConfig) ToTOML() (string, error) {
+	return c.merged().ToTOML()
+}
+
+func (c *childConfig) ToProto() ([]byte, error) {
+	return c.merged().ToProto()
+}
+
+func (c *childConfig) ExportEnvFile(w io.Writer, includeSecure bool) error {
+	return c.merged().ExportEnvFile(w, includeSecure)
+}
+
+func (c *childConfig) ExportShellScript(w io.Writer, includeSecure bool) error {
+	return c.merged().ExportShellScript(w, includeSecure)
+}
+
+func (c *childConfig) ExportKubernetesConfigMap(
+	name, namespace string, w io.Writer) error {
+	return c.merged().ExportKubernetesConfigMap(name, namespace, w)
+}
+
+func (c *childConfig) ExportKubernetesSecret(
+	name, namespace string, w io.Writer) error {
+	return c.merged().ExportKubernetesSecret(name, namespace, w)
+}
+
+func (c *childConfig) WriteConfigAs(filePath, format string) error {
+	return c.merged().WriteConfigAs(filePath, format)
+}
+
+func (c *childConfig) WriteCompressedConfigFile(filePath string) error {
+	return c.merged().WriteCompressedConfigFile(filePath)
+}
@@ -0,0 +1,124 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtend(t *testing.T) {
+	newConfigDirs("TestExtend", t)
+	wipeEnv()
+
+	p := New()
+	p.Set("testExtend.host", "parent")
+	p.Set("testExtend.port", 1)
+
+	c := p.Extend()
+	assert.Equal(t, p, c.Parent())
+	assert.Equal(t, "parent", c.GetString("testExtend.host"))
+	assert.Equal(t, 1, c.GetInt("testExtend.port"))
+	assert.Equal(t, "tcp://:7979", c.GetString("rexray.host"))
+
+	c.Set("testExtend.host", "child")
+	assert.Equal(t, "child", c.GetString("testExtend.host"))
+	assert.Equal(t, "parent", p.GetString("testExtend.host"))
+
+	p.Set("testExtend.host", "parent2")
+	p.Set("testExtend.port", 2)
+	assert.Equal(t, "child", c.GetString("testExtend.host"))
+	assert.Equal(t, 2, c.GetInt("testExtend.port"))
+	assert.Equal(t, 2, c.Scope("testExtend").GetInt("port"))
+	assert.Equal(t, "child", c.Scope("testExtend").GetString("host"))
+
+	assert.Contains(t, c.AllKeys(), "testextend.host")
+	assert.Contains(t, c.AllKeys(), "rexray.host")
+	m := c.AllSettings()["testextend"].(map[string]interface{})
+	assert.Equal(t, "child", m["host"])
+	assert.Equal(t, 2, m["port"])
+
+	gc := c.Extend()
+	assert.Equal(t, "child", gc.GetString("testExtend.host"))
+	assert.Equal(t, 2, gc.GetInt("testExtend.port"))
+}
+
+func TestExtendCopy(t *testing.T) {
+	newConfigDirs("TestExtendCopy", t)
+	wipeEnv()
+
+	p := New()
+	p.Set("testExtend.host", "parent")
+	p.Set("testExtend.port", 1)
+
+	c := p.Extend()
+	c.Set("testExtend.host", "child")
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Nil(t, cc.Parent())
+	assert.Equal(t, "child", cc.GetString("testExtend.host"))
+	assert.Equal(t, 1, cc.GetInt("testExtend.port"))
+
+	p.Set("testExtend.port", 2)
+	c.Set("testExtend.host", "child2")
+	assert.Equal(t, 1, cc.GetInt("testExtend.port"))
+	assert.Equal(t, "child", cc.GetString("testExtend.host"))
+}
+
+func TestExtendSerialization(t *testing.T) {
+	newConfigDirs("TestExtendSerialization", t)
+	wipeEnv()
+
+	p := New()
+	p.Set("testExtend.host", "parent")
+	p.Set("testExtend.port", 1)
+	p.Set("testExtend.tags", []interface{}{"a", "b"})
+
+	c := p.Extend()
+	c.Set("testExtend.host", "child")
+
+	v, err := c.GetByPath("testExtend.tags[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", v)
+	v, err = c.GetByPath("testExtend.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "child", v)
+
+	var s struct {
+		TestExtend struct {
+			Host string
+			Port int
+		}
+	}
+	assert.NoError(t, c.Unmarshal(&s))
+	assert.Equal(t, "child", s.TestExtend.Host)
+	assert.Equal(t, 1, s.TestExtend.Port)
+	s.TestExtend.Port = 0
+	assert.NoError(t, c.UnmarshalKey("testExtend", &s.TestExtend))
+	assert.Equal(t, 1, s.TestExtend.Port)
+
+	j, err := c.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, j, `"host":"child"`)
+	assert.Contains(t, j, `"port":1`)
+	assert.Contains(t, j, `"host":"tcp://:7979"`)
+
+	y, err := c.ToYAML()
+	assert.NoError(t, err)
+	assert.Contains(t, y, "port: 1")
+
+	assert.Contains(t, c.EnvVars(), "TESTEXTEND_HOST=child")
+	assert.Contains(t, c.EnvVars(), "TESTEXTEND_PORT=1")
+	assert.Contains(t, c.EnvVarsWithPrefix("APP_"), "APP_TESTEXTEND_PORT=1")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, c.ExportEnvFile(buf, false))
+	assert.Contains(t, buf.String(), "TESTEXTEND_PORT=")
+
+	p.Set("testExtend.port", 2)
+	j, err = c.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, j, `"port":2`)
+	assert.Equal(t, "parent", p.GetString("testExtend.host"))
+}
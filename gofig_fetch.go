@@ -0,0 +1,87 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) GetOrFetch(
+	k interface{},
+	fetch func() (interface{}, error),
+	ttl time.Duration) (interface{}, error) {
+	return getOrFetch(c, c.lookup, &c.fetchGroup, toString(k), fetch, ttl)
+}
+func (c *scopedConfig) GetOrFetch(
+	k interface{},
+	fetch func() (interface{}, error),
+	ttl time.Duration) (interface{}, error) {
+	szK := toString(k)
+	return c.Config.GetOrFetch(fmt.Sprintf("%s.%s", c.scope, szK), fetch, ttl)
+}
+func (c *childConfig) GetOrFetch(
+	k interface{},
+	fetch func() (interface{}, error),
+	ttl time.Duration) (interface{}, error) {
+	return getOrFetch(c, c.lookup, &c.fetchGroup, toString(k), fetch, ttl)
+}
+
+// lookup returns the value of the key and a flag indicating whether or not
+// the key is set. The value is read while holding the config's read lock.
+func (c *config) lookup(k string) (interface{}, bool) {
+	c.expireTTLs()
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	if !c.b.IsSet(k) {
+		return nil, false
+	}
+	return c.b.Get(k), true
+}
+func (c *childConfig) lookup(k string) (interface{}, bool) {
+	if v, ok := c.config.lookup(k); ok {
+		return v, true
+	}
+	if c.parent.IsSet(k) {
+		return c.parent.Get(k), true
+	}
+	return nil, false
+}
+
+// getOrFetch returns the value of the key if it is set, otherwise it sets the
+// key to the value returned by the fetch function. The group ensures the
+// fetch function is invoked only once for concurrent calls for the same key.
+func getOrFetch(
+	c types.Config,
+	lookup func(k string) (interface{}, bool),
+	g *singleflight.Group,
+	k string,
+	fetch func() (interface{}, error),
+	ttl time.Duration) (interface{}, error) {
+
+	k = realKey(k)
+	if v, ok := lookup(k); ok {
+		return v, nil
+	}
+	v, err, _ := g.Do(strings.ToLower(k), func() (interface{}, error) {
+		// the key may have been set by a fetch that completed after the
+		// key was looked up above
+		if v, ok := lookup(k); ok {
+			return v, nil
+		}
+		v, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		if ttl > 0 {
+			c.SetWithTTL(k, v, ttl)
+		} else {
+			c.Set(k, v)
+		}
+		return v, nil
+	})
+	return v, err
+}
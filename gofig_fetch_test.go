@@ -0,0 +1,122 @@
+package gofig
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrFetch(t *testing.T) {
+	newConfigDirs("TestGetOrFetch", t)
+	wipeEnv()
+	c := New()
+
+	var calls int32
+	fetch := func() (interface{}, error) {
+		return atomic.AddInt32(&calls, 1), nil
+	}
+
+	v, err := c.GetOrFetch("testFetch.addr", fetch, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), v)
+	v, err = c.GetOrFetch("testFetch.addr", fetch, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), v)
+	assert.Equal(t, int32(1), c.Get("testFetch.addr"))
+
+	c.Set("testFetch.host", "localhost")
+	v, err = c.GetOrFetch("testFetch.host", fetch, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", v)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	sc := c.Scope("testFetch")
+	v, err = sc.GetOrFetch("port", fetch, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), v)
+	assert.Equal(t, int32(2), c.Get("testFetch.port"))
+
+	errFetch := errors.New("fetch failed")
+	_, err = c.GetOrFetch("testFetch.err", func() (interface{}, error) {
+		return nil, errFetch
+	}, 0)
+	assert.Equal(t, errFetch, err)
+	assert.False(t, c.IsSet("testFetch.err"))
+
+	_, err = ReadOnly(c).GetOrFetch("testFetch.other", fetch, 0)
+	assert.Equal(t, ErrReadOnly, err)
+	v, err = ReadOnly(c).GetOrFetch("testFetch.addr", fetch, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), v)
+}
+
+func TestGetOrFetchTTL(t *testing.T) {
+	newConfigDirs("TestGetOrFetchTTL", t)
+	wipeEnv()
+	c := New()
+
+	var calls int32
+	fetch := func() (interface{}, error) {
+		return atomic.AddInt32(&calls, 1), nil
+	}
+
+	v, err := c.GetOrFetch("testFetch.addr", fetch, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), v)
+	v, err = c.GetOrFetch("testFetch.addr", fetch, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), v)
+
+	time.Sleep(100 * time.Millisecond)
+	v, err = c.GetOrFetch("testFetch.addr", fetch, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), v)
+}
+
+func TestGetOrFetchSingleflight(t *testing.T) {
+	newConfigDirs("TestGetOrFetchSingleflight", t)
+	wipeEnv()
+	c := New()
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func() (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return "fetched", nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make(chan interface{}, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrFetch("testFetch.addr", fetch, 0)
+			assert.NoError(t, err)
+			results <- v
+		}()
+	}
+
+	<-started
+	// the config is not locked while the fetch is in progress
+	c.Set("testFetch.other", true)
+	assert.True(t, c.GetBool("testFetch.other"))
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for v := range results {
+		assert.Equal(t, "fetched", v)
+	}
+}
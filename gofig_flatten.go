@@ -0,0 +1,34 @@
+package gofig
+
+// Flatten transforms a tree of nested maps into a map keyed with dot-notation.
+func Flatten(m map[string]interface{}) map[string]interface{} {
+	flat := map[string]interface{}{}
+	flattenMapKeys("", m, flat)
+	return flat
+}
+
+// Unflatten transforms a map keyed with dot-notation into a tree of nested
+// maps. It is the reverse of Flatten.
+func Unflatten(flat map[string]interface{}) map[string]interface{} {
+	return unflattenMapKeys(flat)
+}
+
+func (c *config) Flatten() map[string]interface{} {
+	return Flatten(c.AllSettings())
+}
+func (c *scopedConfig) Flatten() map[string]interface{} {
+	return Flatten(c.AllSettings())
+}
+func (c *childConfig) Flatten() map[string]interface{} {
+	return Flatten(c.AllSettings())
+}
+
+func (c *config) AllSettingsFlat() map[string]interface{} {
+	return c.Flatten()
+}
+func (c *scopedConfig) AllSettingsFlat() map[string]interface{} {
+	return c.Flatten()
+}
+func (c *childConfig) AllSettingsFlat() map[string]interface{} {
+	return c.Flatten()
+}
@@ -0,0 +1,67 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	newConfigDirs("TestFlatten", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testFlatten.service.host", "localhost")
+	c.Set("testFlatten.service.timeout", 30)
+
+	flat := c.Flatten()
+	assert.Equal(t, "localhost", flat["testflatten.service.host"])
+	assert.Equal(t, 30, flat["testflatten.service.timeout"])
+	assert.Equal(t, "tcp://:7979", flat["rexray.host"])
+	assert.Len(t, flat, len(c.AllKeys()))
+
+	flat = c.Scope("testFlatten").Flatten()
+	assert.Equal(t, map[string]interface{}{
+		"service.host":    "localhost",
+		"service.timeout": 30,
+	}, flat)
+}
+
+func TestUnflatten(t *testing.T) {
+	flat := map[string]interface{}{
+		"service.host":    "localhost",
+		"service.timeout": 30,
+		"name":            "gofig",
+	}
+	m := Unflatten(flat)
+	assert.Equal(t, map[string]interface{}{
+		"service": map[string]interface{}{
+			"host":    "localhost",
+			"timeout": 30,
+		},
+		"name": "gofig",
+	}, m)
+	assert.Equal(t, flat, Flatten(m))
+}
+
+func TestAllSettingsFlat(t *testing.T) {
+	newConfigDirs("TestAllSettingsFlat", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testFlat.service.host", "localhost")
+	c.Set("testFlat.service.ports", []int{80, 443})
+
+	flat := c.AllSettingsFlat()
+	assert.Equal(t, "localhost", flat["testflat.service.host"])
+	assert.Equal(t, []int{80, 443}, flat["testflat.service.ports"])
+	for _, k := range c.AllKeys() {
+		assert.Contains(t, flat, k)
+	}
+	assert.Equal(t, c.Flatten(), flat)
+
+	assert.Equal(t, map[string]interface{}{
+		"service.host":  "localhost",
+		"service.ports": []int{80, 443},
+	}, c.Scope("testFlat").AllSettingsFlat())
+}
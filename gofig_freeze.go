@@ -0,0 +1,21 @@
+package gofig
+
+import (
+	"github.com/akutz/goof"
+)
+
+// ErrFrozen is the error returned, or the value with which the Set function
+// panics, when attempting to modify a frozen Config instance.
+var ErrFrozen = goof.New("config is frozen")
+
+func (c *config) Freeze() {
+	c.frozen.Store(true)
+}
+
+func (c *config) Unfreeze() {
+	c.frozen.Store(false)
+}
+
+func (c *config) IsFrozen() bool {
+	return c.frozen.Load()
+}
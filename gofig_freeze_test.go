@@ -0,0 +1,73 @@
+package gofig
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	etcFile, _ := newConfigDirs("TestFreeze", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testFreeze.count", 3)
+	assert.False(t, c.IsFrozen())
+
+	c.Freeze()
+	assert.True(t, c.IsFrozen())
+	assert.Panics(t, func() { c.Set("testFreeze.count", 4) })
+	assert.Panics(t, func() { c.Scope("testFreeze").Set("count", 4) })
+	assert.Equal(t, ErrFrozen, c.ReadConfig(bytes.NewReader(yamlConfig1)))
+	assert.Equal(t, ErrFrozen, c.ReadConfigFile(etcFile))
+	assert.Equal(t, ErrFrozen, c.Merge(New()))
+	assert.Equal(t, ErrFrozen, c.Scope("rexray").Merge(New()))
+	assert.Equal(t, 3, c.GetInt("testFreeze.count"))
+	assertString(t, c, "rexray.logLevel", "warn")
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.True(t, cc.IsFrozen())
+	assert.Panics(t, func() { cc.Set("testFreeze.count", 4) })
+	cc.Unfreeze()
+	assert.False(t, cc.IsFrozen())
+	cc.Set("testFreeze.count", 4)
+	assert.Equal(t, 4, cc.GetInt("testFreeze.count"))
+	assert.True(t, c.IsFrozen())
+
+	ReadOnly(c).Unfreeze()
+	assert.True(t, c.IsFrozen())
+	assert.True(t, ReadOnly(c).IsFrozen())
+	assert.Panics(t, func() { c.Set("testFreeze.count", 4) })
+
+	c.Unfreeze()
+	ReadOnly(c).Freeze()
+	assert.False(t, c.IsFrozen())
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlConfig1)))
+	assertString(t, c, "rexray.logLevel", "error")
+}
+
+func TestFreezeConcurrent(t *testing.T) {
+	newConfigDirs("TestFreezeConcurrent", t)
+	wipeEnv()
+
+	c := New()
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.Freeze()
+			c.Unfreeze()
+		}()
+		go func() {
+			defer wg.Done()
+			c.IsFrozen()
+			c.Reset()
+		}()
+	}
+	wg.Wait()
+	assert.False(t, c.IsFrozen())
+}
@@ -0,0 +1,37 @@
+package gofig
+
+import (
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// NewConfigFromMap returns a new config with the values in the map set as
+// override values, as if each were set with the Set function. The map may be
+// flat, keyed with dot-notation, or a tree of nested maps whose leaf values
+// are set with their dot-notation names. Setting a secure key does not change
+// the key's registration.
+func NewConfigFromMap(m map[string]interface{}) types.Config {
+	c := newConfig()
+	if err := MergeMap(c, m); err != nil {
+		logger().Error("error setting config from map", "error", err)
+	}
+	return c
+}
+
+// MergeMap sets the values in the map, which may be flat or a tree of nested
+// maps, as override values in the config in a single transaction.
+func MergeMap(c types.Config, m map[string]interface{}) error {
+	if c == nil {
+		return goof.New("config is nil")
+	}
+	sm, _ := toStringMap(m)
+	flat := map[string]interface{}{}
+	flattenMapKeys("", sm, flat)
+	return c.Transaction(func(tx types.ConfigTx) error {
+		for k, v := range flat {
+			tx.Set(k, v)
+		}
+		return nil
+	})
+}
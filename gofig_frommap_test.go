@@ -0,0 +1,61 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestNewConfigFromMap(t *testing.T) {
+	newConfigDirs("TestNewConfigFromMap", t)
+	wipeEnv()
+
+	c := NewConfigFromMap(map[string]interface{}{
+		"testFromMap": map[string]interface{}{
+			"service": map[interface{}]interface{}{
+				"host": "localhost",
+				"port": 8080,
+			},
+			"tags": []string{"a", "b"},
+		},
+		"testFromMap.debug": true,
+	})
+	assert.Equal(t, "localhost", c.GetString("testFromMap.service.host"))
+	assert.Equal(t, 8080, c.GetInt("testFromMap.service.port"))
+	assert.Equal(t, []string{"a", "b"}, c.GetStringSlice("testFromMap.tags"))
+	assert.True(t, c.GetBool("testFromMap.debug"))
+	assert.Equal(t, types.Override, c.GetSource("testFromMap.service.host"))
+
+	assert.Equal(t, map[string]interface{}{
+		"service": map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+		},
+		"tags":  []string{"a", "b"},
+		"debug": true,
+	}, c.Scope("testFromMap").AllSettings())
+}
+
+func TestMergeMap(t *testing.T) {
+	newConfigDirs("TestMergeMap", t)
+	wipeEnv()
+
+	c := NewConfigFromMap(map[string]interface{}{
+		"testMergeMap.host": "localhost",
+	})
+	assert.NoError(t, MergeMap(c, map[string]interface{}{
+		"testMergeMap": map[string]interface{}{"port": 8080},
+	}))
+	assert.Equal(t, "localhost", c.GetString("testMergeMap.host"))
+	assert.Equal(t, 8080, c.GetInt("testMergeMap.port"))
+
+	assert.NoError(t, MergeMap(c.Scope("testMergeMap"),
+		map[string]interface{}{"host": "example.com"}))
+	assert.Equal(t, "example.com", c.GetString("testMergeMap.host"))
+
+	assert.Equal(t, ErrReadOnly, MergeMap(ReadOnly(c),
+		map[string]interface{}{"testMergeMap.host": "other"}))
+	assert.Error(t, MergeMap(nil, nil))
+}
@@ -0,0 +1,71 @@
+package gofig
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// NewConfigFromFS returns a new config with the file at the path in the file
+// system, such as one created with the embed package, read into it at the
+// lowest priority with ReadConfigFS. If format is empty the format of the
+// file is determined by its extension or detected from its content. An error
+// reading the file is logged and the config is returned without it.
+func NewConfigFromFS(fsys fs.FS, name, format string) types.Config {
+	c := newConfig()
+	if err := c.readConfigFS(fsys, name, format); err != nil {
+		logger().Error("error reading config from fs",
+			"path", name, "error", err)
+	}
+	return c
+}
+
+func (c *config) ReadConfigFS(fsys fs.FS, filePath string) error {
+	return c.readConfigFS(fsys, filePath, "")
+}
+
+// readConfigFS reads the file at the path in the file system into the config
+// beneath the config streams already read into it.
+func (c *config) readConfigFS(fsys fs.FS, filePath, format string) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	if fsys == nil {
+		return goof.New("file system is nil")
+	}
+	buf, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return err
+	}
+	if buf, err = decompressIfCompressed(buf); err != nil {
+		return err
+	}
+	format = strings.ToLower(format)
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(path.Ext(filePath), "."))
+	}
+	if !isSupportedConfigFormat(format) {
+		format = detectConfigFormat(buf, c.configType)
+	}
+	if err := c.checkSettings(buf, format); err != nil {
+		return err
+	}
+
+	c.rwl.Lock()
+	overrides := map[string]interface{}{}
+	for k := range c.overrideKeys {
+		overrides[k] = c.b.Get(k)
+	}
+	reads := append(
+		[]configRead{{buf: buf, format: format}}, c.reads...)
+	err = c.resetWithOverrides(reads, overrides)
+	c.rwl.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.validateOnLoad()
+}
@@ -0,0 +1,69 @@
+package gofig
+
+import (
+	"embed"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+//go:embed testdata/fs
+var testFS embed.FS
+
+func newTestSubFS(t *testing.T) fs.FS {
+	fsys, err := fs.Sub(testFS, "testdata/fs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fsys
+}
+
+func TestReadConfigFS(t *testing.T) {
+	wipeEnv()
+	TestHook(t)
+	r := newRegistration("Test FS")
+	r.Key(types.Int, "", 0, "", "testFS.port")
+	Register(r)
+
+	filePath := filepath.Join(t.TempDir(), "config.yml")
+	if err := ioutil.WriteFile(
+		filePath, []byte("testFS:\n  host: file.example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	assert.NoError(t, c.ReadConfigFile(filePath))
+	c.Set("testFS.name", "override")
+	os.Setenv("TESTFS_PORT", "9090")
+	defer os.Unsetenv("TESTFS_PORT")
+
+	assert.NoError(t, c.ReadConfigFS(newTestSubFS(t), "config/defaults.yml"))
+	assert.Equal(t, "file.example.com", c.GetString("testFS.host"))
+	assert.Equal(t, 9090, c.GetInt("testFS.port"))
+	assert.Equal(t, "override", c.GetString("testFS.name"))
+	os.Unsetenv("TESTFS_PORT")
+
+	assert.NoError(t, c.Reload())
+	assert.Equal(t, "file.example.com", c.GetString("testFS.host"))
+	assert.Equal(t, 8080, c.GetInt("testFS.port"))
+
+	assert.Error(t, c.ReadConfigFS(newTestSubFS(t), "config/missing.yml"))
+	assert.Equal(t, ErrReadOnly,
+		ReadOnly(c).ReadConfigFS(newTestSubFS(t), "config/defaults.yml"))
+}
+
+func TestNewConfigFromFS(t *testing.T) {
+	newConfigDirs("TestNewConfigFromFS", t)
+	wipeEnv()
+
+	c := NewConfigFromFS(newTestSubFS(t), "config/defaults.yml", "yaml")
+	assert.Equal(t, "embedded.example.com", c.GetString("testFS.host"))
+	assert.Equal(t, 8080, c.GetInt("testFS.port"))
+	assert.Equal(t, "warn", c.GetString("rexray.logLevel"))
+}
@@ -0,0 +1,214 @@
+package gofig
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// HTTPHandlerOption is an option used to configure the handler returned by
+// NewHTTPHandler.
+type HTTPHandlerOption func(h *httpHandler)
+
+// WithAuth configures the handler to require HTTP basic authentication with
+// the specified user name and password.
+func WithAuth(user, pass string) HTTPHandlerOption {
+	return func(h *httpHandler) {
+		h.user = user
+		h.pass = pass
+		h.auth = true
+	}
+}
+
+// httpHandler serves a config instance over HTTP
+type httpHandler struct {
+	c    types.Config
+	auth bool
+	user string
+	pass string
+}
+
+// NewHTTPHandler returns an http.Handler that may be used to inspect and
+// update the config. The handler serves the following routes:
+//
+//	GET /config        the config's settings as a JSON object
+//	GET /config/env    the config's environment variables as a JSON array
+//	GET /config/{key}  the value of the key as JSON
+//	PUT /config/{key}  sets the value of the key to the JSON request body
+//
+// The values of secure keys are never returned. A PUT request for a frozen
+// or read-only config returns 423 Locked.
+func NewHTTPHandler(
+	c types.Config, opts ...HTTPHandlerOption) http.Handler {
+	h := &httpHandler{c: c}
+	for _, o := range opts {
+		o(h)
+	}
+	return h
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.auth && !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="gofig"`)
+		writeHTTPError(w, http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == "/config":
+		if r.Method != http.MethodGet {
+			writeHTTPError(w, http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, unflattenMapKeys(h.settings()))
+	case path == "/config/env":
+		if r.Method != http.MethodGet {
+			writeHTTPError(w, http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, h.envVars())
+	case strings.HasPrefix(path, "/config/"):
+		k := strings.TrimPrefix(path, "/config/")
+		switch r.Method {
+		case http.MethodGet:
+			h.getKey(w, k)
+		case http.MethodPut:
+			h.putKey(w, r, k)
+		default:
+			writeHTTPError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeHTTPError(w, http.StatusNotFound)
+	}
+}
+
+func (h *httpHandler) authorized(r *http.Request) bool {
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(h.user)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(h.pass)) == 1
+	return userOK && passOK
+}
+
+func (h *httpHandler) getKey(w http.ResponseWriter, k string) {
+	if h.isSecureKey(k) {
+		writeHTTPError(w, http.StatusForbidden)
+		return
+	}
+	v := h.c.Get(k)
+	if v == nil {
+		writeHTTPError(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, redactSecureValues(h.c, k, v, nil))
+}
+
+func (h *httpHandler) putKey(w http.ResponseWriter, r *http.Request, k string) {
+	if h.c.IsFrozen() {
+		writeHTTPError(w, http.StatusLocked)
+		return
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := setRecover(h.c, k, normalizeJSONNumbers(v)); err != nil {
+		if err == ErrFrozen || err == ErrReadOnly {
+			writeHTTPError(w, http.StatusLocked)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	logger().Debug("set config key via http", "key", k)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// settings returns the config's settings with the secure keys omitted.
+func (h *httpHandler) settings() map[string]interface{} {
+	m := flatConfigSettings(h.c)
+	for k := range m {
+		if h.isSecureKey(k) {
+			delete(m, k)
+		}
+	}
+	return m
+}
+
+// envVars returns the config's environment variables with the secure keys
+// omitted.
+func (h *httpHandler) envVars() []string {
+	return h.c.EnvVarsWithPrefix("")
+}
+
+func (h *httpHandler) isSecureKey(k string) bool {
+	if isSecureKey(realKey(k)) {
+		return true
+	}
+	if s := h.c.GetScope(); s != "" {
+		return isSecureKey(realKey(fmt.Sprintf("%s.%s", s, k)))
+	}
+	return false
+}
+
+// setRecover sets the key's value and returns the value with which the Set
+// function panicked, if any, as an error.
+func setRecover(c types.Config, k string, v interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rerr, ok := r.(error); ok {
+				err = rerr
+				return
+			}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	c.Set(k, v)
+	return nil
+}
+
+// normalizeJSONNumbers replaces the json.Number values decoded from a JSON
+// document with int or float64 values.
+func normalizeJSONNumbers(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case json.Number:
+		if i, err := tv.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := tv.Float64()
+		return f
+	case map[string]interface{}:
+		for k, mv := range tv {
+			tv[k] = normalizeJSONNumbers(mv)
+		}
+	case []interface{}:
+		for x, av := range tv {
+			tv[x] = normalizeJSONNumbers(av)
+		}
+	}
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf)
+}
+
+func writeHTTPError(w http.ResponseWriter, status int) {
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,152 @@
+package gofig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func doHTTPRequest(
+	t *testing.T,
+	s *httptest.Server,
+	method, path, body string,
+	auth ...string) (int, string) {
+
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auth) == 2 {
+		req.SetBasicAuth(auth[0], auth[1])
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	buf, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, string(buf)
+}
+
+func TestHTTPHandler(t *testing.T) {
+	newConfigDirs("TestHTTPHandler", t)
+	wipeEnv()
+
+	c := newExportTestConfig(t)
+	s := httptest.NewServer(NewHTTPHandler(c))
+	defer s.Close()
+
+	status, body := doHTTPRequest(t, s, "GET", "/config", "")
+	assert.Equal(t, http.StatusOK, status)
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &m))
+	assert.Equal(t, "tcp://:7979", m["rexray"].(map[string]interface{})["host"])
+	assert.NotContains(t, body, "secret")
+
+	status, body = doHTTPRequest(t, s, "GET", "/config/rexray.host", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `"tcp://:7979"`, body)
+
+	status, body = doHTTPRequest(t, s, "GET", "/config/testExport.password", "")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.NotContains(t, body, "secret")
+
+	status, _ = doHTTPRequest(t, s, "GET", "/config/testExport.missing", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, body = doHTTPRequest(t, s, "GET", "/config/env", "")
+	assert.Equal(t, http.StatusOK, status)
+	var evs []string
+	assert.NoError(t, json.Unmarshal([]byte(body), &evs))
+	assert.Contains(t, evs, "REXRAY_HOST=tcp://:7979")
+	assert.NotContains(t, body, "secret")
+
+	status, _ = doHTTPRequest(t, s, "PUT", "/config/testExport.port", "9090")
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, 9090, c.GetInt("testExport.port"))
+
+	status, _ = doHTTPRequest(t, s, "PUT", "/config/testExport.port", "{")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = doHTTPRequest(t, s, "DELETE", "/config/testExport.port", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+	c.Freeze()
+	status, _ = doHTTPRequest(t, s, "PUT", "/config/testExport.port", "80")
+	assert.Equal(t, http.StatusLocked, status)
+	assert.Equal(t, 9090, c.GetInt("testExport.port"))
+}
+
+func TestHTTPHandlerReadOnly(t *testing.T) {
+	newConfigDirs("TestHTTPHandlerReadOnly", t)
+	wipeEnv()
+
+	s := httptest.NewServer(NewHTTPHandler(ReadOnly(New())))
+	defer s.Close()
+
+	status, _ := doHTTPRequest(t, s, "PUT", "/config/rexray.host", `"x"`)
+	assert.Equal(t, http.StatusLocked, status)
+}
+
+func TestHTTPHandlerWithAuth(t *testing.T) {
+	newConfigDirs("TestHTTPHandlerWithAuth", t)
+	wipeEnv()
+
+	s := httptest.NewServer(NewHTTPHandler(New(), WithAuth("admin", "pass")))
+	defer s.Close()
+
+	status, _ := doHTTPRequest(t, s, "GET", "/config", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = doHTTPRequest(t, s, "GET", "/config", "", "admin", "nope")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = doHTTPRequest(t, s, "GET", "/config", "", "admin", "pass")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestHTTPHandlerSecureDescendants(t *testing.T) {
+	newConfigDirs("TestHTTPHandlerSecureDescendants", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test HTTP Secure")
+	r.Key(types.SecureString, "", "", "", "testHTTP.db.password")
+	SetEnvKeyPrefix("MYAPP")
+	defer SetEnvKeyPrefix("")
+
+	c := New()
+	c.Set("testHTTP.db.host", "h")
+	c.Set("testHTTP.db.password", "s3cret")
+	s := httptest.NewServer(NewHTTPHandler(c))
+	defer s.Close()
+
+	status, body := doHTTPRequest(t, s, "GET", "/config/testHTTP", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"db":{"host":"h"}}`, strings.TrimSpace(body))
+
+	status, body = doHTTPRequest(t, s, "GET", "/config/testHTTP.db", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"host":"h"}`, strings.TrimSpace(body))
+
+	status, body = doHTTPRequest(t, s, "GET", "/config/env", "")
+	assert.Equal(t, http.StatusOK, status)
+	var evs []string
+	assert.NoError(t, json.Unmarshal([]byte(body), &evs))
+	assert.Contains(t, evs, "MYAPP_TESTHTTP_DB_HOST=h")
+	assert.NotContains(t, body, "s3cret")
+
+	s2 := httptest.NewServer(NewHTTPHandler(c.Scope("testHTTP")))
+	defer s2.Close()
+	status, body = doHTTPRequest(t, s2, "GET", "/config/db", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"host":"h"}`, strings.TrimSpace(body))
+}
@@ -0,0 +1,124 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/akutz/goof"
+	"github.com/spf13/cast"
+)
+
+// IncludeKey is the name of the key in a YAML config file that lists the
+// paths of the config files to include in it.
+const IncludeKey = "_include"
+
+// expandIncludes returns the YAML config file's content merged on top of the
+// content of the files listed by the file's IncludeKey. The paths of the
+// included files are relative to the directory of the file that includes
+// them and may be glob patterns. The content of the file is returned
+// unchanged if the file is not YAML or does not include any files.
+func expandIncludes(
+	filePath string, buf []byte, format string) ([]byte, error) {
+
+	if format != "yml" && format != "yaml" {
+		return buf, nil
+	}
+	m, err := readSettings(buf, format)
+	if err != nil {
+		return nil, NewConfigError(ErrCodeParseFailed, "", err)
+	}
+	if _, ok := m[IncludeKey]; !ok {
+		return buf, nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+	flat := map[string]interface{}{}
+	err = includeSettings(absPath, m, flat, []string{absPath})
+	if err != nil {
+		return nil, err
+	}
+	return marshalConfig(unflattenMapKeys(flat), format)
+}
+
+// includeSettings adds the settings of the files included by the settings to
+// the flat map, followed by the settings themselves. The stack is the list of
+// files being included, used to detect circular includes.
+func includeSettings(
+	filePath string,
+	m map[string]interface{},
+	flat map[string]interface{},
+	stack []string) error {
+
+	if iv, ok := m[IncludeKey]; ok {
+		delete(m, IncludeKey)
+		for _, p := range cast.ToStringSlice(iv) {
+			paths, err := includePaths(filePath, p)
+			if err != nil {
+				return err
+			}
+			for _, ip := range paths {
+				if err := includeFile(ip, flat, stack); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	flattenMapKeys("", m, flat)
+	return nil
+}
+
+// includeFile adds the settings of the included file, and of the files it
+// includes, to the flat map.
+func includeFile(
+	filePath string, flat map[string]interface{}, stack []string) error {
+
+	for _, sp := range stack {
+		if sp == filePath {
+			return goof.WithFields(map[string]interface{}{
+				"path":  filePath,
+				"chain": strings.Join(append(stack, filePath), " -> "),
+			}, "circular config include")
+		}
+	}
+	buf, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(filePath), "."))
+	if !isSupportedConfigFormat(format) {
+		format = detectConfigFormat(buf, "yml")
+	}
+	m, err := readSettings(buf, format)
+	if err != nil {
+		return goof.WithFieldE(
+			"path", filePath, "error parsing included config file", err)
+	}
+	return includeSettings(filePath, m, flat, append(stack, filePath))
+}
+
+// includePaths returns the sorted, absolute paths of the files that match the
+// include path, which is relative to the directory of the including file.
+func includePaths(filePath, p string) ([]string, error) {
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(filepath.Dir(filePath), p)
+	}
+	paths, err := filepath.Glob(p)
+	if err != nil {
+		return nil, goof.WithFieldE("path", p, "invalid include path", err)
+	}
+	if len(paths) == 0 && !hasGlobMeta(p) {
+		paths = []string{p}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// hasGlobMeta returns a flag indicating whether or not the path contains any
+// of the special characters of a glob pattern.
+func hasGlobMeta(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
@@ -0,0 +1,90 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeIncludeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(
+			filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInclude(t *testing.T) {
+	wipeEnv()
+
+	dir, err := ioutil.TempDir("", "gofig-test-TestInclude")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeIncludeFiles(t, dir, map[string]string{
+		"config.yml": `
+_include:
+- base.yml
+- conf.d/*.yml
+testInclude:
+  host: main
+`,
+		"base.yml": `
+_include: common/log.yml
+testInclude:
+  host: base
+  port: 8080
+  name: base
+`,
+		"common/log.yml": `
+testInclude:
+  log:
+    level: info
+`,
+		"conf.d/10-port.yml": "testInclude:\n  port: 9090\n",
+		"conf.d/20-name.yml": "testInclude:\n  name: conf.d\n",
+		"conf.d/ignored.txt": "testInclude:\n  name: ignored\n",
+	})
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	assert.NoError(t, c.ReadConfigFile(filepath.Join(dir, "config.yml")))
+	assert.Equal(t, "main", c.GetString("testInclude.host"))
+	assert.Equal(t, 9090, c.GetInt("testInclude.port"))
+	assert.Equal(t, "conf.d", c.GetString("testInclude.name"))
+	assert.Equal(t, "info", c.GetString("testInclude.log.level"))
+	assert.False(t, c.IsSet(IncludeKey))
+}
+
+func TestIncludeCircular(t *testing.T) {
+	wipeEnv()
+
+	dir, err := ioutil.TempDir("", "gofig-test-TestIncludeCircular")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeIncludeFiles(t, dir, map[string]string{
+		"a.yml": "_include: [b.yml]\ntestInclude:\n  a: true\n",
+		"b.yml": "_include: [a.yml]\ntestInclude:\n  b: true\n",
+	})
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	err = c.ReadConfigFile(filepath.Join(dir, "a.yml"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "circular config include")
+	}
+	assert.False(t, c.IsSet("testInclude.a"))
+
+	c = newConfigWithOptions(false, false, "config", "yml")
+	writeIncludeFiles(t, dir, map[string]string{
+		"c.yml": "_include: [missing.yml]\n",
+	})
+	assert.Error(t, c.ReadConfigFile(filepath.Join(dir, "c.yml")))
+}
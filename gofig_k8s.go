@@ -0,0 +1,61 @@
+package gofig
+
+import (
+	"encoding/base64"
+	"io"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// k8sObjectMeta is the metadata of a Kubernetes object.
+type k8sObjectMeta struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// k8sConfigMap is a Kubernetes ConfigMap or Secret manifest.
+type k8sConfigMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sObjectMeta     `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data"`
+}
+
+func (c *config) ExportKubernetesConfigMap(
+	name, namespace string, w io.Writer) error {
+	return writeK8sManifest(w, &k8sConfigMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   k8sObjectMeta{Name: name, Namespace: namespace},
+		Data:       c.exportableEnvVars(false),
+	})
+}
+
+func (c *config) ExportKubernetesSecret(
+	name, namespace string, w io.Writer) error {
+	insecure := c.exportableEnvVars(false)
+	data := map[string]string{}
+	for k, v := range c.exportableEnvVars(true) {
+		if _, ok := insecure[k]; ok {
+			continue
+		}
+		data[k] = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	return writeK8sManifest(w, &k8sConfigMap{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   k8sObjectMeta{Name: name, Namespace: namespace},
+		Type:       "Opaque",
+		Data:       data,
+	})
+}
+
+func writeK8sManifest(w io.Writer, m *k8sConfigMap) error {
+	buf, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
@@ -0,0 +1,43 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportKubernetesConfigMap(t *testing.T) {
+	newConfigDirs("TestExportKubernetesConfigMap", t)
+	wipeEnv()
+
+	c := newExportTestConfig(t)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, c.ExportKubernetesConfigMap("gofig", "default", buf))
+
+	m, err := ValidateYAML(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", m["apiVersion"])
+	assert.Equal(t, "ConfigMap", m["kind"])
+	assert.Contains(t, buf.String(), "  name: gofig\n")
+	assert.Contains(t, buf.String(), "  namespace: default\n")
+	assert.Contains(t, buf.String(), "  REXRAY_HOST: tcp://:7979\n")
+	assert.Contains(t, buf.String(), "  TESTEXPORT_GREETING: hello world\n")
+	assert.NotContains(t, buf.String(), "TESTEXPORT_PASSWORD")
+}
+
+func TestExportKubernetesSecret(t *testing.T) {
+	newConfigDirs("TestExportKubernetesSecret", t)
+	wipeEnv()
+
+	c := newExportTestConfig(t)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, c.ExportKubernetesSecret("gofig", "", buf))
+
+	m, err := ValidateYAML(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, "Secret", m["kind"])
+	assert.Contains(t, buf.String(), "  TESTEXPORT_PASSWORD: c2VjcmV0\n")
+	assert.NotContains(t, buf.String(), "REXRAY_HOST")
+	assert.NotContains(t, buf.String(), "namespace")
+}
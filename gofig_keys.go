@@ -0,0 +1,84 @@
+package gofig
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+func (c *config) KeysMatching(pattern string) []string {
+	return keysMatching(c.AllKeys(), pattern)
+}
+func (c *scopedConfig) KeysMatching(pattern string) []string {
+	return c.Config.KeysMatching(pattern)
+}
+func (c *childConfig) KeysMatching(pattern string) []string {
+	return keysMatching(c.AllKeys(), pattern)
+}
+
+func (c *config) KeysWithPrefix(prefix string) []string {
+	return keysWithPrefix(c.AllKeys(), prefix)
+}
+func (c *scopedConfig) KeysWithPrefix(prefix string) []string {
+	return c.Config.KeysWithPrefix(prefix)
+}
+func (c *childConfig) KeysWithPrefix(prefix string) []string {
+	return keysWithPrefix(c.AllKeys(), prefix)
+}
+
+func (c *config) KeysWithSuffix(suffix string) []string {
+	return keysWithSuffix(c.AllKeys(), suffix)
+}
+func (c *scopedConfig) KeysWithSuffix(suffix string) []string {
+	return c.Config.KeysWithSuffix(suffix)
+}
+func (c *childConfig) KeysWithSuffix(suffix string) []string {
+	return keysWithSuffix(c.AllKeys(), suffix)
+}
+
+// keysMatching returns the sorted keys that match the glob pattern. Keys are
+// matched without regard to case. An empty slice is returned if the pattern
+// is malformed.
+func keysMatching(keys []string, pattern string) []string {
+	lp := strings.ToLower(pattern)
+	if _, err := path.Match(lp, ""); err != nil {
+		logger().Error("invalid key pattern",
+			"pattern", pattern, "error", err)
+		return []string{}
+	}
+	return filterKeys(keys, func(lk string) bool {
+		ok, _ := path.Match(lp, lk)
+		return ok
+	})
+}
+
+// keysWithPrefix returns the sorted keys that start with the prefix. Keys are
+// matched without regard to case.
+func keysWithPrefix(keys []string, prefix string) []string {
+	lp := strings.ToLower(prefix)
+	return filterKeys(keys, func(lk string) bool {
+		return strings.HasPrefix(lk, lp)
+	})
+}
+
+// keysWithSuffix returns the sorted keys that end with the suffix. Keys are
+// matched without regard to case.
+func keysWithSuffix(keys []string, suffix string) []string {
+	ls := strings.ToLower(suffix)
+	return filterKeys(keys, func(lk string) bool {
+		return strings.HasSuffix(lk, ls)
+	})
+}
+
+// filterKeys returns the sorted keys for which the match function, invoked
+// with the lower-case name of the key, returns true.
+func filterKeys(keys []string, match func(lk string) bool) []string {
+	matched := []string{}
+	for _, k := range keys {
+		if match(strings.ToLower(k)) {
+			matched = append(matched, k)
+		}
+	}
+	sort.Strings(matched)
+	return matched
+}
@@ -0,0 +1,83 @@
+package gofig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newKeysConfig(plugins int) *config {
+	c := newConfigWithOptions(false, false, "config", "yml")
+	for i := 0; i < plugins; i++ {
+		c.Set(fmt.Sprintf("plugins.p%03d.enabled", i), true)
+		c.Set(fmt.Sprintf("plugins.p%03d.host", i), "localhost")
+	}
+	return c
+}
+
+func TestKeysMatching(t *testing.T) {
+	wipeEnv()
+
+	c := newKeysConfig(3)
+	c.Set("server.enabled", true)
+
+	assert.Equal(t, []string{
+		"plugins.p000.enabled",
+		"plugins.p001.enabled",
+		"plugins.p002.enabled",
+	}, c.KeysMatching("plugins.*.enabled"))
+	assert.Equal(t, []string{
+		"plugins.p001.enabled",
+		"plugins.p001.host",
+	}, c.KeysMatching("Plugins.P001.*"))
+	assert.Empty(t, c.KeysMatching("plugins.[.enabled"))
+
+	assert.Equal(t, []string{
+		"plugins.p002.enabled",
+		"plugins.p002.host",
+	}, c.KeysWithPrefix("plugins.p002."))
+	assert.Equal(t, []string{
+		"plugins.p000.enabled",
+		"plugins.p001.enabled",
+		"plugins.p002.enabled",
+		"server.enabled",
+	}, c.KeysWithSuffix(".enabled"))
+
+	sc := c.Scope("plugins")
+	assert.Equal(t,
+		c.KeysMatching("plugins.*.enabled"),
+		sc.KeysMatching("plugins.*.enabled"))
+	assert.Equal(t,
+		[]string{"server.enabled"}, sc.KeysWithPrefix("server."))
+	assert.Equal(t,
+		c.KeysWithSuffix(".host"), sc.KeysWithSuffix(".host"))
+
+	cc := c.Extend()
+	cc.Set("child.enabled", true)
+	assert.Equal(t,
+		[]string{"child.enabled"}, cc.KeysWithPrefix("child."))
+	assert.Len(t, cc.KeysWithSuffix(".enabled"), 5)
+}
+
+// BenchmarkKeysMatching benchmarks matching keys in a config with 1,000 keys.
+func BenchmarkKeysMatching(b *testing.B) {
+	wipeEnv()
+
+	c := newKeysConfig(500)
+	b.Run("KeysMatching", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.KeysMatching("plugins.*.enabled")
+		}
+	})
+	b.Run("KeysWithPrefix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.KeysWithPrefix("plugins.p250.")
+		}
+	})
+	b.Run("KeysWithSuffix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.KeysWithSuffix(".enabled")
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package gofig
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+var pkgLogger atomic.Pointer[slog.Logger]
+
+// SetLogger sets the logger used by the package. If the logger is nil then
+// the default slog logger is used.
+func SetLogger(l *slog.Logger) {
+	pkgLogger.Store(l)
+}
+
+// logger returns the logger used by the package.
+func logger() *slog.Logger {
+	if l := pkgLogger.Load(); l != nil {
+		return l
+	}
+	return slog.Default()
+}
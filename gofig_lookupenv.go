@@ -0,0 +1,28 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func (c *config) LookupEnvVar(k interface{}) (string, string, bool) {
+	szK := c.realKey(toString(k))
+	c.rwl.RLock()
+	kb, ok := c.keyBindings[strings.ToLower(szK)]
+	c.rwl.RUnlock()
+	evn := c.envVarName(strings.ToUpper(strings.Replace(szK, ".", "_", -1)))
+	if ok && kb.envVar != "" {
+		evn = kb.envVar
+	}
+	v, found := os.LookupEnv(evn)
+	return evn, v, found
+}
+func (c *scopedConfig) LookupEnvVar(k interface{}) (string, string, bool) {
+	szK := toString(k)
+	evn, v, ok := c.Config.LookupEnvVar(fmt.Sprintf("%s.%s", c.scope, szK))
+	if !ok && c.Parent() != nil {
+		return c.Parent().LookupEnvVar(szK)
+	}
+	return evn, v, ok
+}
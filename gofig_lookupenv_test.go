@@ -0,0 +1,53 @@
+package gofig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestLookupEnvVar(t *testing.T) {
+	newConfigDirs("TestLookupEnvVar", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Lookup Env")
+	r.Key(types.String, "", "localhost", "", "testLookupEnv.host")
+	r.Key(types.Int, "", 80, "", "testLookupEnv.port", "", "LOOKUP_PORT")
+	Register(r)
+
+	os.Setenv("TESTLOOKUPENV_HOST", "example.com")
+	os.Setenv("LOOKUP_PORT", "8080")
+	defer os.Unsetenv("TESTLOOKUPENV_HOST")
+	defer os.Unsetenv("LOOKUP_PORT")
+
+	c := New()
+	evn, v, ok := c.LookupEnvVar("testLookupEnv.host")
+	assert.Equal(t, "TESTLOOKUPENV_HOST", evn)
+	assert.Equal(t, "example.com", v)
+	assert.True(t, ok)
+
+	evn, v, ok = c.LookupEnvVar("testLookupEnv.port")
+	assert.Equal(t, "LOOKUP_PORT", evn)
+	assert.Equal(t, "8080", v)
+	assert.True(t, ok)
+	assert.Equal(t, 8080, c.GetInt("testLookupEnv.port"))
+
+	evn, v, ok = c.Scope("testLookupEnv").LookupEnvVar("port")
+	assert.Equal(t, "LOOKUP_PORT", evn)
+	assert.Equal(t, "8080", v)
+	assert.True(t, ok)
+
+	evn, v, ok = c.LookupEnvVar("testLookupEnv.user")
+	assert.Equal(t, "TESTLOOKUPENV_USER", evn)
+	assert.Equal(t, "", v)
+	assert.False(t, ok)
+
+	evn, _, ok = New(WithEnvKeyPrefix("myapp")).
+		LookupEnvVar("testLookupEnv.host")
+	assert.Equal(t, "MYAPP_TESTLOOKUPENV_HOST", evn)
+	assert.False(t, ok)
+}
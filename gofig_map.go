@@ -0,0 +1,79 @@
+package gofig
+
+import (
+	"fmt"
+
+	"github.com/spf13/cast"
+)
+
+func (c *config) GetMap(k interface{}) map[string]interface{} {
+	szK := c.realKey(toString(k))
+	c.checkDeprecated(szK)
+	c.recordAccess(szK)
+	if LogGetAndSet {
+		logger().Debug("config.GetMap", "key", szK)
+	}
+	m, _ := toStringMap(c.get(szK))
+	return m
+}
+func (c *scopedConfig) GetMap(k interface{}) map[string]interface{} {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetMap(sk)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetMap(szK)
+	}
+	return nil
+}
+func (c *childConfig) GetMap(k interface{}) map[string]interface{} {
+	if c.config.IsSet(k) {
+		return c.config.GetMap(k)
+	}
+	return c.parent.GetMap(k)
+}
+
+func (c *config) GetMapString(k interface{}) map[string]string {
+	return toStringMapString(c.GetMap(k))
+}
+func (c *scopedConfig) GetMapString(k interface{}) map[string]string {
+	return toStringMapString(c.GetMap(k))
+}
+func (c *childConfig) GetMapString(k interface{}) map[string]string {
+	return toStringMapString(c.GetMap(k))
+}
+
+// toStringMap returns a copy of the value as a map with string keys if the
+// value is a map. The nested maps in the value are converted as well.
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	m := map[string]interface{}{}
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, mv := range tv {
+			m[k] = toStringMapValue(mv)
+		}
+	case map[interface{}]interface{}:
+		for k, mv := range tv {
+			m[cast.ToString(k)] = toStringMapValue(mv)
+		}
+	default:
+		return nil, false
+	}
+	return m, true
+}
+
+func toStringMapValue(v interface{}) interface{} {
+	if m, ok := toStringMap(v); ok {
+		return m
+	}
+	return v
+}
+
+// toStringMapString returns the map with its values converted to strings.
+func toStringMapString(m map[string]interface{}) map[string]string {
+	if m == nil {
+		return nil
+	}
+	return cast.ToStringMapString(m)
+}
@@ -0,0 +1,60 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mapYAML = []byte(`testMap:
+  database:
+    host: db.example.com
+    port: 5432
+    pool:
+      size: 10
+      timeouts:
+        idle: 30s
+  name: app
+`)
+
+func TestGetMap(t *testing.T) {
+	wipeEnv()
+	c := newConfigWithOptions(false, false, "config", "yml")
+	if err := c.ReadConfig(bytes.NewReader(mapYAML)); err != nil {
+		t.Fatal(err)
+	}
+
+	m := c.GetMap("testMap.database")
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "db.example.com", m["host"])
+		assert.EqualValues(t, 5432, m["port"])
+		pool, ok := m["pool"].(map[string]interface{})
+		if assert.True(t, ok) {
+			timeouts, ok := pool["timeouts"].(map[string]interface{})
+			if assert.True(t, ok) {
+				assert.Equal(t, "30s", timeouts["idle"])
+			}
+		}
+	}
+
+	assert.Equal(t,
+		map[string]string{"idle": "30s"},
+		c.GetMapString("testMap.database.pool.timeouts"))
+	assert.Equal(t, "5432", c.GetMapString("testMap.database")["port"])
+
+	assert.Nil(t, c.GetMap("testMap.name"))
+	assert.Nil(t, c.GetMap("testMap.database.port"))
+	assert.Nil(t, c.GetMap("testMap.missing"))
+	assert.Nil(t, c.GetMapString("testMap.name"))
+
+	sc := c.Scope("testMap")
+	assert.Equal(t,
+		map[string]string{"idle": "30s"},
+		sc.GetMapString("database.pool.timeouts"))
+	assert.Nil(t, sc.GetMap("name"))
+
+	m["host"] = "changed"
+	assert.Equal(t,
+		"db.example.com", c.GetMap("testMap.database")["host"])
+}
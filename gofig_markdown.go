@@ -0,0 +1,75 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// GenerateMarkdown writes documentation for the keys of the registrations to
+// the writer as markdown. Each registration's keys are written as a table per
+// section, with the keys that do not belong to a section written first.
+func GenerateMarkdown(regs []types.ConfigRegistration, w io.Writer) error {
+	buf := &bytes.Buffer{}
+	for i, r := range regs {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "## %s\n", r.Name())
+
+		sections := []string{""}
+		keys := map[string][]types.ConfigRegistrationKey{}
+		for k := range r.Keys() {
+			s := k.Section()
+			if _, ok := keys[s]; !ok && s != "" {
+				sections = append(sections, s)
+			}
+			keys[s] = append(keys[s], k)
+		}
+
+		for _, s := range sections {
+			if len(keys[s]) == 0 {
+				continue
+			}
+			buf.WriteString("\n")
+			if s != "" {
+				fmt.Fprintf(buf, "### %s\n\n", s)
+				if d := r.SectionDescription(s); d != "" {
+					fmt.Fprintf(buf, "%s\n\n", d)
+				}
+			}
+			writeMarkdownTable(buf, keys[s])
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeMarkdownTable writes a markdown table of the keys to the buffer.
+func writeMarkdownTable(buf *bytes.Buffer, keys []types.ConfigRegistrationKey) {
+	buf.WriteString("| Key | Type | Default | EnvVar | Flag | Description |\n")
+	buf.WriteString("|-----|------|---------|--------|------|-------------|\n")
+	for _, k := range keys {
+		var defVal string
+		if dv := k.DefaultValue(); dv != nil && fmt.Sprint(dv) != "" {
+			defVal = fmt.Sprintf("`%v`", dv)
+		}
+		fmt.Fprintf(buf, "| `%s` | %s | %s | `%s` | `--%s` | %s |\n",
+			k.KeyName(),
+			k.KeyType(),
+			markdownCell(defVal),
+			prefixEnvVarName(getEnvKeyPrefix(), k.EnvVarName()),
+			k.FlagName(),
+			markdownCell(k.Description()))
+	}
+}
+
+// markdownCell escapes the text so that it may be written to a markdown table
+// cell.
+func markdownCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", " ", -1)
+}
@@ -0,0 +1,63 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestGenerateMarkdown(t *testing.T) {
+	TestHook(t)
+
+	r := newRegistration("Test Markdown")
+	r.Key(types.String, "", "gofig", "The name", "testMarkdown.name")
+	r.Section("Service", "The service settings.")
+	r.Key(types.String, "", "localhost", "The host", "testMarkdown.host")
+	r.Key(types.Int, "", 8080, "The port", "testMarkdown.port")
+	r.Section("Auth", "")
+	r.Key(types.SecureString, "", "", "The a|b password",
+		"testMarkdown.password")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, GenerateMarkdown(
+		[]types.ConfigRegistration{r, newRegistration("Empty")}, buf))
+	assert.Equal(t, "## Test Markdown\n"+
+		"\n"+
+		"| Key | Type | Default | EnvVar | Flag | Description |\n"+
+		"|-----|------|---------|--------|------|-------------|\n"+
+		"| `testMarkdown.name` | String | `gofig` | `TESTMARKDOWN_NAME` | "+
+		"`--testMarkdownName` | The name |\n"+
+		"\n"+
+		"### Service\n"+
+		"\n"+
+		"The service settings.\n"+
+		"\n"+
+		"| Key | Type | Default | EnvVar | Flag | Description |\n"+
+		"|-----|------|---------|--------|------|-------------|\n"+
+		"| `testMarkdown.host` | String | `localhost` | `TESTMARKDOWN_HOST` | "+
+		"`--testMarkdownHost` | The host |\n"+
+		"| `testMarkdown.port` | Int | `8080` | `TESTMARKDOWN_PORT` | "+
+		"`--testMarkdownPort` | The port |\n"+
+		"\n"+
+		"### Auth\n"+
+		"\n"+
+		"| Key | Type | Default | EnvVar | Flag | Description |\n"+
+		"|-----|------|---------|--------|------|-------------|\n"+
+		"| `testMarkdown.password` | SecureString |  | "+
+		"`TESTMARKDOWN_PASSWORD` | `--testMarkdownPassword` | "+
+		"The a\\|b password |\n"+
+		"\n"+
+		"## Empty\n", buf.String())
+
+	var rk types.ConfigRegistrationKey
+	for k := range r.Keys() {
+		if k.KeyName() == "testMarkdown.port" {
+			rk = k
+		}
+	}
+	assert.Equal(t, "Service", rk.Section())
+	assert.Equal(t, "The service settings.", r.SectionDescription("Service"))
+}
@@ -0,0 +1,29 @@
+package gofig
+
+import (
+	"encoding/json"
+)
+
+func (c *config) MaskedToJSON() (string, error) {
+	m, err := c.allSecureSettings(secureValuesMask)
+	if err != nil {
+		return "", err
+	}
+	buf, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func (c *config) MaskedToJSONCompact() (string, error) {
+	m, err := c.allSecureSettings(secureValuesMask)
+	if err != nil {
+		return "", err
+	}
+	buf, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
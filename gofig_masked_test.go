@@ -0,0 +1,42 @@
+package gofig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestMaskedToJSON(t *testing.T) {
+	newConfigDirs("TestMaskedToJSON", t)
+	wipeEnv()
+
+	r := newRegistration("Test Masked")
+	r.Key(types.SecureString, "", "", "", "testMasked.password")
+
+	c := New()
+	c.Set("testMasked.password", "secret")
+	c.Set("testMasked.user", "admin")
+
+	for _, f := range []func() (string, error){
+		c.MaskedToJSON,
+		c.MaskedToJSONCompact,
+		c.Scope("testMasked").MaskedToJSON,
+	} {
+		s, err := f()
+		assert.NoError(t, err)
+		assert.NotContains(t, s, "secret")
+
+		var m map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(s), &m))
+		mm := m["testmasked"].(map[string]interface{})
+		assert.Equal(t, types.RedactedValue, mm["password"])
+		assert.Equal(t, "admin", mm["user"])
+	}
+
+	s, err := c.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.NotContains(t, s, "password")
+}
@@ -0,0 +1,132 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akutz/goof"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/akutz/gofig/types"
+)
+
+// MergeAll merges the settings from the second and subsequent Config
+// instances, in order, into the first Config instance.
+func MergeAll(configs ...types.Config) error {
+	if len(configs) == 0 {
+		return goof.New("configs is empty")
+	}
+	for _, other := range configs[1:] {
+		if err := configs[0].Merge(other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergeFilesOption is an option used to configure how MergeConfigFiles reads
+// the config files.
+type MergeFilesOption func(o *mergeFilesOptions)
+
+type mergeFilesOptions struct {
+	failFast bool
+}
+
+// WithFailFast configures whether or not a missing config file is an error.
+// If failFast is false a missing file is skipped with a warning. Otherwise
+// the missing file's error is returned. Files are loaded with fail fast by
+// default.
+func WithFailFast(failFast bool) MergeFilesOption {
+	return func(o *mergeFilesOptions) {
+		o.failFast = failFast
+	}
+}
+
+// MergeConfigFiles reads the config files, in order, into the config so that
+// the values in each file override the values in the files before it. A file
+// with a .gz, .gzip, .zst, or .zstd extension is read with
+// ReadCompressedConfigFile. The error from the first file that cannot be
+// read is returned with the file's path, and the files after it are not
+// read.
+func MergeConfigFiles(c types.Config, paths ...string) error {
+	return MergeConfigFilesWithOptions(c, paths)
+}
+
+// MergeConfigFilesWithOptions is the same as MergeConfigFiles except that it
+// accepts options, such as WithFailFast.
+func MergeConfigFilesWithOptions(
+	c types.Config, paths []string, opts ...MergeFilesOption) error {
+
+	if c == nil {
+		return goof.New("config is nil")
+	}
+	o := &mergeFilesOptions{failFast: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+	for _, p := range paths {
+		var err error
+		if algo, _ := compressedFileExt(p); algo != "" {
+			err = c.ReadCompressedConfigFile(p)
+		} else {
+			err = c.ReadConfigFile(p)
+		}
+		if err != nil && !o.failFast && os.IsNotExist(err) {
+			logger().Warn("skipped merging missing config file", "path", p)
+			continue
+		}
+		if err != nil {
+			return goof.WithFieldE(
+				"path", p, "error merging config file", err)
+		}
+	}
+	return nil
+}
+
+func (c *config) Merge(other types.Config) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	if other == nil {
+		return goof.New("config is nil")
+	}
+	buf, err := yaml.Marshal(unflattenMapKeys(flatConfigSettings(other)))
+	if err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	return c.mergeConfig(buf, "yml")
+}
+func (c *scopedConfig) Merge(other types.Config) error {
+	if other == nil {
+		return goof.New("config is nil")
+	}
+	rc, _ := qualifyConfigKey(c, "")
+	sc := newConfigObj()
+	for k, v := range flatConfigSettings(other) {
+		sk := fmt.Sprintf("%s.%s", c.scope, k)
+		if rc != nil && isSecureConfigKey(other, k) {
+			rc.addMergedSecureKey(qualifiedConfigKey(c, k))
+		}
+		sc.b.Set(sk, v)
+	}
+	return c.Config.Merge(sc)
+}
+
+// addMergedSecureKey records that the key, which was merged into the config
+// from a secure key of another config, is a secure key of this config.
+func (c *config) addMergedSecureKey(k string) {
+	c.mergedSecureKeysRWL.Lock()
+	defer c.mergedSecureKeysRWL.Unlock()
+	c.mergedSecureKeys[strings.ToLower(k)] = true
+}
+
+// isMergedSecureKey returns a flag indicating whether or not the key was
+// merged into the config from a secure key of another config.
+func (c *config) isMergedSecureKey(k string) bool {
+	c.mergedSecureKeysRWL.RLock()
+	defer c.mergedSecureKeysRWL.RUnlock()
+	return c.mergedSecureKeys[strings.ToLower(k)]
+}
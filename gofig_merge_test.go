@@ -0,0 +1,164 @@
+package gofig
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+// newOverlayConfig returns a config that does not include the settings from
+// the registrations.
+func newOverlayConfig(t *testing.T, y []byte) types.Config {
+	c := newConfigObj()
+	c.configType = "yml"
+	if err := c.ReadConfig(bytes.NewReader(y)); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestMerge(t *testing.T) {
+	newConfigDirs("TestMerge", t)
+	wipeEnv()
+
+	c1 := New()
+	if err := c1.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+	c2 := newOverlayConfig(t, yamlConfig2)
+	c2.Set("mockProvider.docker.maxVolSize", 64)
+	c2.Set("mockProvider.userName", nil)
+
+	if err := c1.Merge(c2); err != nil {
+		t.Fatal(err)
+	}
+
+	assertString(t, c1, "rexray.logLevel", "debug")
+	assertString(t, c1, "rexray.host", "tcp://:7979")
+	assertString(t, c1, "mockProvider.userName", "admin")
+	assertStorageDrivers(t, c1)
+	assertOsDrivers2(t, c1)
+	assert.Equal(t, 32, c1.GetInt("mockProvider.docker.minVolSize"))
+	assert.Equal(t, 64, c1.GetInt("mockProvider.docker.maxVolSize"))
+
+	c1.Set("rexray.logLevel", "info")
+	if err := c1.Merge(c2); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c1, "rexray.logLevel", "info")
+
+	assert.Error(t, c1.Merge(nil))
+}
+
+func TestMergeAll(t *testing.T) {
+	newConfigDirs("TestMergeAll", t)
+	wipeEnv()
+
+	c1 := New()
+	c2 := newOverlayConfig(t, []byte(`rexray:
+  logLevel: error
+  host: tcp://:7980
+`))
+	c3 := newOverlayConfig(t, []byte(`rexray:
+  logLevel: debug
+`))
+
+	if err := MergeAll(c1, c2, c3); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c1, "rexray.logLevel", "debug")
+	assertString(t, c1, "rexray.host", "tcp://:7980")
+
+	assert.Error(t, MergeAll())
+}
+
+func TestMergeScoped(t *testing.T) {
+	newConfigDirs("TestMergeScoped", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Merge")
+	r.Key(types.SecureString, "", "", "", "password")
+
+	c1 := New()
+	c2 := newOverlayConfig(t, []byte(`
+logLevel: debug
+password: i should be hidden
+`))
+
+	if err := c1.Scope("rexray").Merge(c2); err != nil {
+		t.Fatal(err)
+	}
+
+	assertString(t, c1, "rexray.logLevel", "debug")
+	assertString(t, c1, "rexray.password", "i should be hidden")
+	assertString(t, c1.Scope("rexray"), "logLevel", "debug")
+
+	jsonStr, err := c1.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, strings.Contains(jsonStr, "i should be hidden"))
+	assert.False(t, isSecureKey("rexray.password"))
+	assert.True(t, isSecureConfigKey(c1, "rexray.password"))
+	assert.True(t, isSecureConfigKey(c1.Scope("rexray"), "password"))
+	assert.False(t, isSecureConfigKey(New(), "rexray.password"))
+
+	cc, err := c1.Copy()
+	assert.NoError(t, err)
+	jsonStr, err = cc.ToJSON()
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(jsonStr, "i should be hidden"))
+}
+
+func TestMergeConfigFiles(t *testing.T) {
+	wipeEnv()
+	dir := t.TempDir()
+	writeFile := func(name, s string) string {
+		filePath := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(filePath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return filePath
+	}
+	base := writeFile("base.yml", "testMerge:\n  a: base\n  b: base\n  c: base\n")
+	env := writeFile("env.json", `{"testMerge": {"b": "env", "c": "env"}}`)
+	buf, err := compress([]byte("testMerge:\n  c: local\n"), CompressionGzip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := writeFile("local.yml.gz", string(buf))
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	assert.NoError(t, MergeConfigFiles(c, base, env, local))
+	assert.Equal(t, "base", c.GetString("testMerge.a"))
+	assert.Equal(t, "env", c.GetString("testMerge.b"))
+	assert.Equal(t, "local", c.GetString("testMerge.c"))
+
+	missing := filepath.Join(dir, "missing.yml")
+	c = newConfigWithOptions(false, false, "config", "yml")
+	err = MergeConfigFiles(c, base, missing, env)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "missing.yml")
+	}
+	assert.Equal(t, "base", c.GetString("testMerge.b"))
+
+	c = newConfigWithOptions(false, false, "config", "yml")
+	assert.NoError(t, MergeConfigFilesWithOptions(
+		c, []string{base, missing, env}, WithFailFast(false)))
+	assert.Equal(t, "env", c.GetString("testMerge.b"))
+
+	invalid := writeFile("invalid.yml", "testMerge: [")
+	c = newConfigWithOptions(false, false, "config", "yml")
+	err = MergeConfigFilesWithOptions(
+		c, []string{base, invalid, env}, WithFailFast(false))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid.yml")
+	}
+}
@@ -0,0 +1,118 @@
+package gofig
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akutz/goof"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/akutz/gofig/types"
+)
+
+// WithMergeStrategy configures how the config resolves the value of a key
+// that is read from more than one config stream. The default strategy is
+// types.LastWins.
+func WithMergeStrategy(s types.MergeStrategy) ConfigOption {
+	return func(c *config) {
+		c.mergeStrategy = s
+	}
+}
+
+func (c *config) SetMergeStrategy(s types.MergeStrategy) {
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	c.mergeStrategy = s
+}
+
+// applyMergeStrategy returns the config stream with the values of the keys
+// that were already read from the config's other streams resolved according
+// to the config's merge strategy. The stream is returned unchanged if the
+// strategy is types.LastWins. The config's lock must be held.
+func (c *config) applyMergeStrategy(r configRead) (configRead, error) {
+	if c.mergeStrategy == types.LastWins || len(c.reads) == 0 {
+		return r, nil
+	}
+
+	// a stream that cannot be parsed is returned unchanged so the error is
+	// reported when the stream is merged
+	m, err := readSettings(r.buf, r.format)
+	if err != nil {
+		return r, nil
+	}
+	prev := map[string]interface{}{}
+	for _, pr := range c.reads {
+		pm, err := readSettings(pr.buf, pr.format)
+		if err != nil {
+			continue
+		}
+		pflat := map[string]interface{}{}
+		flattenMapKeys("", pm, pflat)
+		for k, v := range pflat {
+			prev[strings.ToLower(c.realKey(k))] = v
+		}
+	}
+
+	flat := map[string]interface{}{}
+	flattenMapKeys("", m, flat)
+	var errs []ConfigError
+	for k, v := range flat {
+		rk := strings.ToLower(c.realKey(k))
+		pv, ok := prev[rk]
+		if !ok {
+			continue
+		}
+		switch c.mergeStrategy {
+		case types.FirstWins:
+			delete(flat, k)
+		case types.ErrorOnConflict:
+			if !reflect.DeepEqual(pv, v) {
+				errs = append(errs, mergeConflictError(rk, pv, v))
+			}
+		case types.AppendSlices:
+			if s, ok := appendSlices(pv, v); ok {
+				flat[k] = s
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Key() < errs[j].Key()
+		})
+		return r, &ValidationError{Errors: errs}
+	}
+
+	buf, err := yaml.Marshal(unflattenMapKeys(flat))
+	if err != nil {
+		return r, err
+	}
+	return configRead{buf: buf, format: "yml", filePath: r.filePath}, nil
+}
+
+// mergeConflictError returns an error for a key whose value in a config
+// stream is different than the value already read from another stream.
+func mergeConflictError(k string, oldVal, newVal interface{}) ConfigError {
+	return NewConfigError(ErrCodeMergeConflict, k, goof.WithFields(
+		map[string]interface{}{
+			"existingValue": oldVal,
+			"value":         newVal,
+		}, "key is already set to a different value"))
+}
+
+// appendSlices returns the elements of the second slice appended to the
+// elements of the first slice. False is returned if either value is not a
+// slice.
+func appendSlices(a, b interface{}) ([]interface{}, bool) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() != reflect.Slice || bv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	s := make([]interface{}, 0, av.Len()+bv.Len())
+	for _, v := range []reflect.Value{av, bv} {
+		for x := 0; x < v.Len(); x++ {
+			s = append(s, v.Index(x).Interface())
+		}
+	}
+	return s, true
+}
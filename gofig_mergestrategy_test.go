@@ -0,0 +1,101 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+var mergeStrategyFirstYAML = []byte(`testMerge:
+  name: first
+  port: 80
+  hosts:
+  - a
+  - b
+  only1: one
+`)
+
+var mergeStrategySecondYAML = []byte(`testMerge:
+  name: second
+  port: 8080
+  hosts:
+  - c
+  only2: two
+`)
+
+func newMergeStrategyConfig(
+	t *testing.T, opts ...ConfigOption) types.Config {
+
+	c := newConfigWithOptions(false, false, "config", "yml", opts...)
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(mergeStrategyFirstYAML)))
+	return c
+}
+
+func TestMergeStrategy(t *testing.T) {
+	wipeEnv()
+
+	c := newMergeStrategyConfig(t)
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(mergeStrategySecondYAML)))
+	assert.Equal(t, "second", c.GetString("testMerge.name"))
+	assert.Equal(t, 8080, c.GetInt("testMerge.port"))
+	assert.Equal(t, []string{"c"}, c.GetStringSlice("testMerge.hosts"))
+
+	c = newMergeStrategyConfig(t, WithMergeStrategy(types.FirstWins))
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(mergeStrategySecondYAML)))
+	assert.Equal(t, "first", c.GetString("testMerge.name"))
+	assert.Equal(t, 80, c.GetInt("testMerge.port"))
+	assert.Equal(t, []string{"a", "b"}, c.GetStringSlice("testMerge.hosts"))
+	assert.Equal(t, "one", c.GetString("testMerge.only1"))
+	assert.Equal(t, "two", c.GetString("testMerge.only2"))
+	assert.NoError(t, c.Reset())
+	assert.Equal(t, "first", c.GetString("testMerge.name"))
+	assert.Equal(t, "two", c.GetString("testMerge.only2"))
+
+	c = newMergeStrategyConfig(t)
+	c.SetMergeStrategy(types.AppendSlices)
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(mergeStrategySecondYAML)))
+	assert.Equal(t, "second", c.GetString("testMerge.name"))
+	assert.Equal(t, 8080, c.GetInt("testMerge.port"))
+	assert.Equal(t,
+		[]string{"a", "b", "c"}, c.GetStringSlice("testMerge.hosts"))
+
+	c = newMergeStrategyConfig(t, WithMergeStrategy(types.ErrorOnConflict))
+	err := c.ReadConfig(bytes.NewReader(mergeStrategySecondYAML))
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 3)
+		for x, k := range []string{
+			"testmerge.hosts", "testmerge.name", "testmerge.port"} {
+			assert.Equal(t, ErrCodeMergeConflict, verr.Errors[x].Code())
+			assert.Equal(t, k, verr.Errors[x].Key())
+		}
+	}
+	assert.Equal(t, "first", c.GetString("testMerge.name"))
+	assert.Equal(t, "", c.GetString("testMerge.only2"))
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(mergeStrategyFirstYAML)))
+}
+
+func TestMergeStrategyMerge(t *testing.T) {
+	wipeEnv()
+
+	other := newConfigWithOptions(false, false, "config", "yml")
+	other.Set("testMerge.name", "other")
+	other.Set("testMerge.hosts", []string{"d"})
+
+	c := newMergeStrategyConfig(t, WithMergeStrategy(types.FirstWins))
+	assert.NoError(t, c.Merge(other))
+	assert.Equal(t, "first", c.GetString("testMerge.name"))
+
+	c = newMergeStrategyConfig(t, WithMergeStrategy(types.AppendSlices))
+	assert.NoError(t, c.Merge(other))
+	assert.Equal(t, "other", c.GetString("testMerge.name"))
+	assert.Equal(t,
+		[]string{"a", "b", "d"}, c.GetStringSlice("testMerge.hosts"))
+
+	c = newMergeStrategyConfig(t, WithMergeStrategy(types.ErrorOnConflict))
+	assert.Error(t, c.Merge(other))
+	assert.Equal(t, "first", c.GetString("testMerge.name"))
+}
@@ -0,0 +1,111 @@
+package gofig
+
+import (
+	"sync"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// ConfigVersionKey is the name of the key that holds the version of a config
+// file's structure. A config file without the key is version 0.
+var ConfigVersionKey = "configVersion"
+
+// migration is a function that migrates a config to a version.
+type migration struct {
+	toVersion int
+	fn        func(types.Config) error
+}
+
+var (
+	migrations    = map[int]*migration{}
+	migrationsRWL = &sync.RWMutex{}
+)
+
+// AddMigration registers a function that migrates a config from one version
+// to the next. When a config is created the migrations are applied in
+// sequence to the config files it reads whose version is less than the
+// config's version, after which the migrated config is written back to the
+// first config file that was read. The version of the configs created after
+// this function is called is the highest toVersion of the registered
+// migrations unless it is changed with SetConfigVersion.
+func AddMigration(fromVersion, toVersion int, fn func(types.Config) error) {
+	if toVersion <= fromVersion || fn == nil {
+		logger().Error("invalid config migration",
+			"fromVersion", fromVersion, "toVersion", toVersion)
+		return
+	}
+	migrationsRWL.Lock()
+	defer migrationsRWL.Unlock()
+	migrations[fromVersion] = &migration{toVersion: toVersion, fn: fn}
+}
+
+// latestConfigVersion returns the highest toVersion of the registered
+// migrations.
+func latestConfigVersion() int {
+	migrationsRWL.RLock()
+	defer migrationsRWL.RUnlock()
+	v := 0
+	for _, m := range migrations {
+		if m.toVersion > v {
+			v = m.toVersion
+		}
+	}
+	return v
+}
+
+func getMigration(fromVersion int) (*migration, bool) {
+	migrationsRWL.RLock()
+	defer migrationsRWL.RUnlock()
+	m, ok := migrations[fromVersion]
+	return m, ok
+}
+
+func (c *config) SetConfigVersion(v int) {
+	if c.frozen.Load() {
+		panic(ErrFrozen)
+	}
+	c.configVersion = v
+	if err := c.migrate(); err != nil {
+		logger().Error("error migrating config", "error", err)
+	}
+}
+func (c *scopedConfig) SetConfigVersion(v int) {
+	c.Config.SetConfigVersion(v)
+}
+
+// migrate applies the registered migrations to the config if the version of
+// its config files is less than the config's version and then writes the
+// migrated config to the first config file that was read.
+func (c *config) migrate() error {
+	if c.configFilePath == "" {
+		return nil
+	}
+	v := c.GetInt(ConfigVersionKey)
+	if v >= c.configVersion {
+		return nil
+	}
+	for v < c.configVersion {
+		m, ok := getMigration(v)
+		if !ok {
+			return goof.WithFields(map[string]interface{}{
+				"fromVersion": v,
+				"toVersion":   c.configVersion,
+			}, "no config migration found")
+		}
+		logger().Info("migrating config",
+			"path", c.configFilePath,
+			"fromVersion", v,
+			"toVersion", m.toVersion)
+		if err := m.fn(c); err != nil {
+			return goof.WithFieldsE(map[string]interface{}{
+				"fromVersion": v,
+				"toVersion":   m.toVersion,
+			}, "error migrating config", err)
+		}
+		v = m.toVersion
+	}
+	c.Set(ConfigVersionKey, v)
+	return c.WriteConfigFile()
+}
@@ -0,0 +1,61 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestMigrate(t *testing.T) {
+	_, usrFile := newConfigDirs("TestMigrate", t)
+	wipeEnv()
+	TestHook(t)
+
+	assert.NoError(t, ioutil.WriteFile(usrFile, []byte(`
+testMigrate:
+  server: 127.0.0.1
+  secure: true
+`), 0644))
+
+	var steps []int
+	AddMigration(0, 1, func(c types.Config) error {
+		steps = append(steps, 1)
+		c.Set("testMigrate.host", c.GetString("testMigrate.server"))
+		return nil
+	})
+	AddMigration(1, 2, func(c types.Config) error {
+		steps = append(steps, 2)
+		c.Set("testMigrate.tls.enabled", c.GetBool("testMigrate.secure"))
+		return nil
+	})
+
+	c := NewConfig(WithGlobalConfig(false))
+	assert.Equal(t, []int{1, 2}, steps)
+	assert.Equal(t, 2, c.GetInt(ConfigVersionKey))
+	assert.Equal(t, "127.0.0.1", c.GetString("testMigrate.host"))
+	assert.True(t, c.GetBool("testMigrate.tls.enabled"))
+
+	buf, err := ioutil.ReadFile(usrFile)
+	assert.NoError(t, err)
+	m := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal(buf, &m))
+	assert.Equal(t, 2, m["configversion"])
+	tm, ok := m["testmigrate"].(map[interface{}]interface{})
+	if assert.True(t, ok) {
+		assert.Equal(t, "127.0.0.1", tm["host"])
+	}
+
+	// the file is already at the current version
+	steps = nil
+	c = NewConfig(WithGlobalConfig(false))
+	assert.Empty(t, steps)
+	assert.Equal(t, "127.0.0.1", c.GetString("testMigrate.host"))
+
+	// there is no migration from version 2 to version 3
+	c.SetConfigVersion(3)
+	assert.Equal(t, 2, c.GetInt(ConfigVersionKey))
+}
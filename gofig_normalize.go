@@ -0,0 +1,125 @@
+package gofig
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	keyNormalizer     = defaultKeyNormalizer
+	normalizedKeys    = map[string]string{}
+	regKeyNames       = map[string]bool{}
+	normalizedKeysRWL = &sync.RWMutex{}
+)
+
+// SetKeyNormalizer sets the function used to canonicalize key names so that
+// different spellings of a key resolve to the same registered key. The Get,
+// Set, and IsSet functions read or write the registered key whose normalized
+// name is equal to the normalized name of the specified key. Since the names
+// of environment variables are upper-case, an environment variable is used for
+// a registered key if the key's own environment variable is not set and the
+// variable's normalized name is equal to the key's normalized name once the
+// dots are removed from both.
+//
+// The default normalizer inserts a dot between the words of a camelCase name,
+// replaces hyphens and underscores with dots, and lower-cases the result, so
+// "myapp-log-level", "myapp_log_level", and "myapp.logLevel" all resolve to
+// the same key. A nil function restores the default normalizer.
+func SetKeyNormalizer(fn func(string) string) {
+	normalizedKeysRWL.Lock()
+	defer normalizedKeysRWL.Unlock()
+	if fn == nil {
+		fn = defaultKeyNormalizer
+	}
+	keyNormalizer = fn
+	normalizedKeys = map[string]string{}
+	for k := range regKeyNames {
+		normalizedKeys[keyNormalizer(k)] = k
+	}
+}
+
+// defaultKeyNormalizer is the default function used to canonicalize key
+// names.
+func defaultKeyNormalizer(k string) string {
+	b := &bytes.Buffer{}
+	var prev rune
+	for _, r := range k {
+		switch {
+		case r == '-' || r == '_':
+			r = '.'
+		case unicode.IsUpper(r) &&
+			(unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			b.WriteRune('.')
+		}
+		b.WriteRune(unicode.ToLower(r))
+		prev = r
+	}
+	return b.String()
+}
+
+// registerKeyName records the name of a registered key so that other
+// spellings of the name resolve to it.
+func registerKeyName(k string) {
+	normalizedKeysRWL.Lock()
+	defer normalizedKeysRWL.Unlock()
+	regKeyNames[k] = true
+	normalizedKeys[keyNormalizer(k)] = k
+}
+
+// normalizeKey returns the name of the registered key whose normalized name
+// is equal to the normalized name of the specified key. If there is no such
+// key then the key is returned unchanged.
+func normalizeKey(k string) string {
+	normalizedKeysRWL.RLock()
+	defer normalizedKeysRWL.RUnlock()
+	if regKeyNames[k] {
+		return k
+	}
+	if nk, ok := normalizedKeys[keyNormalizer(k)]; ok {
+		return nk
+	}
+	return k
+}
+
+// normalizedEnvVars returns the names of the set environment variables keyed
+// by their normalized env names with the config's environment variable prefix
+// removed.
+func (c *config) normalizedEnvVars() map[string]string {
+	normalizedKeysRWL.RLock()
+	defer normalizedKeysRWL.RUnlock()
+	prefix := c.envVarName("")
+	m := map[string]string{}
+	for _, ev := range os.Environ() {
+		p := strings.SplitN(ev, "=", 2)
+		if len(p) != 2 || p[1] == "" || !strings.HasPrefix(p[0], prefix) {
+			continue
+		}
+		m[normalizedEnvName(strings.TrimPrefix(p[0], prefix))] = p[0]
+	}
+	return m
+}
+
+// normalizedEnvName returns the normalized name of the key or environment
+// variable without any dots. The normalizer's lock must be held.
+func normalizedEnvName(k string) string {
+	return strings.Replace(keyNormalizer(k), ".", "", -1)
+}
+
+// keyEnvVar returns the name of the environment variable to bind to the key.
+// If the key's environment variable is not set but a set environment
+// variable has the same normalized env name as the key then that variable's
+// name is returned.
+func keyEnvVar(k, evn string, envVars map[string]string) string {
+	if os.Getenv(evn) != "" {
+		return evn
+	}
+	normalizedKeysRWL.RLock()
+	defer normalizedKeysRWL.RUnlock()
+	if nev, ok := envVars[normalizedEnvName(k)]; ok {
+		return nev
+	}
+	return evn
+}
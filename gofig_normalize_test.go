@@ -0,0 +1,62 @@
+package gofig
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestDefaultKeyNormalizer(t *testing.T) {
+	assert.Equal(t, "myapp.log.level", defaultKeyNormalizer("myapp-log-level"))
+	assert.Equal(t, "myapp.log.level", defaultKeyNormalizer("myapp_log_level"))
+	assert.Equal(t, "myapp.log.level", defaultKeyNormalizer("myapp.logLevel"))
+	assert.Equal(t, "myapp.log.level", defaultKeyNormalizer("MYAPP_LOG_LEVEL"))
+	assert.Equal(t, "v2.api", defaultKeyNormalizer("v2Api"))
+}
+
+func TestKeyNormalizer(t *testing.T) {
+	newConfigDirs("TestKeyNormalizer", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Normalizer")
+	r.Key(types.String, "", "info", "The log level", "testNorm.logLevel")
+	Register(r)
+
+	c := New()
+	for _, k := range []string{
+		"testNorm.logLevel",
+		"testNorm-log-level",
+		"testNorm_log_level",
+		"TESTNORM.LOGLEVEL",
+	} {
+		assert.Equal(t, "info", c.GetString(k), k)
+	}
+
+	c.Set("testNorm-log-level", "debug")
+	assert.True(t, c.IsSet("testNorm_log_level"))
+	assert.Equal(t, "debug", c.GetString("testNorm.logLevel"))
+	assert.Equal(t, "debug", c.Scope("testNorm").GetString("log_level"))
+	assert.False(t, c.IsSet("testNorm.log.other"))
+
+	os.Setenv("TESTNORM_LOG_LEVEL", "warn")
+	defer os.Unsetenv("TESTNORM_LOG_LEVEL")
+	c = New()
+	assert.Equal(t, "warn", c.GetString("testNorm.logLevel"))
+	assert.Equal(t, types.EnvVar, c.GetSource("testNorm.logLevel"))
+	os.Setenv("TESTNORM_LOG_LEVEL", "")
+
+	SetKeyNormalizer(func(k string) string {
+		return strings.ToLower(strings.Replace(k, "/", ".", -1))
+	})
+	c = New()
+	assert.Equal(t, "info", c.GetString("testNorm/logLevel"))
+	assert.Equal(t, "", c.GetString("testNorm-log-level"))
+
+	SetKeyNormalizer(nil)
+	assert.Equal(t, "info", c.GetString("testNorm-log-level"))
+}
@@ -0,0 +1,77 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/akutz/gofig/types"
+)
+
+// observer is a function that receives the values of an observed key.
+type observer struct {
+	fn func(v interface{})
+}
+
+func (c *config) Observe(
+	k interface{}, fn func(v interface{})) types.CancelFunc {
+	szK := strings.ToLower(c.realKey(toString(k)))
+	o := &observer{fn: fn}
+
+	c.observersRWL.Lock()
+	obs, _ := c.observers.Load(szK)
+	list, _ := obs.([]*observer)
+	c.observers.Store(szK, append(list[:len(list):len(list)], o))
+	atomic.AddInt32(&c.observerCount, 1)
+	c.observersRWL.Unlock()
+
+	fn(c.Get(szK))
+
+	return func() { c.removeObserver(szK, o) }
+}
+func (c *scopedConfig) Observe(
+	k interface{}, fn func(v interface{})) types.CancelFunc {
+	szK := toString(k)
+	return c.Config.Observe(fmt.Sprintf("%s.%s", c.scope, szK), fn)
+}
+
+// removeObserver removes the observer of the key. Removing an observer that
+// was already removed has no effect.
+func (c *config) removeObserver(k string, o *observer) {
+	c.observersRWL.Lock()
+	defer c.observersRWL.Unlock()
+	obs, ok := c.observers.Load(k)
+	if !ok {
+		return
+	}
+	list := []*observer{}
+	for _, lo := range obs.([]*observer) {
+		if lo != o {
+			list = append(list, lo)
+		}
+	}
+	if len(list) == len(obs.([]*observer)) {
+		return
+	}
+	if len(list) == 0 {
+		c.observers.Delete(k)
+	} else {
+		c.observers.Store(k, list)
+	}
+	atomic.AddInt32(&c.observerCount, -1)
+}
+
+func (c *config) hasObservers() bool {
+	return atomic.LoadInt32(&c.observerCount) > 0
+}
+
+// notifyObservers invokes the observers of the key with the key's new value.
+func (c *config) notifyObservers(k string, v interface{}) {
+	obs, ok := c.observers.Load(k)
+	if !ok {
+		return
+	}
+	for _, o := range obs.([]*observer) {
+		o.fn(v)
+	}
+}
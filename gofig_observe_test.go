@@ -0,0 +1,59 @@
+package gofig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve(t *testing.T) {
+	newConfigDirs("TestObserve", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testObserve.poolSize", 4)
+
+	vals := []interface{}{}
+	cancel := c.Observe("testObserve.poolSize", func(v interface{}) {
+		vals = append(vals, v)
+	})
+	assert.Equal(t, []interface{}{4}, vals)
+
+	c.Set("testObserve.poolSize", 8)
+	c.Set("testObserve.poolSize", 8)
+	c.Set("testObserve.other", 1)
+	assert.Equal(t, []interface{}{4, 8}, vals)
+
+	scoped := []interface{}{}
+	cancelScoped := c.Scope("testObserve").Observe(
+		"poolSize", func(v interface{}) { scoped = append(scoped, v) })
+
+	c.Set("testObserve.poolSize", 16)
+	assert.Equal(t, []interface{}{4, 8, 16}, vals)
+	assert.Equal(t, []interface{}{8, 16}, scoped)
+
+	cancel()
+	cancel()
+	cancelScoped()
+	c.Set("testObserve.poolSize", 32)
+	assert.Equal(t, []interface{}{4, 8, 16}, vals)
+	assert.Equal(t, []interface{}{8, 16}, scoped)
+}
+
+func TestObserveReadConfig(t *testing.T) {
+	newConfigDirs("TestObserveReadConfig", t)
+	wipeEnv()
+
+	c := New()
+	vals := []interface{}{}
+	defer c.Observe("rexray.logLevel", func(v interface{}) {
+		vals = append(vals, v)
+	})()
+
+	assert.NoError(t, c.ReadConfig(strings.NewReader(`
+rexray:
+  logLevel: debug
+`)))
+	assert.Equal(t, []interface{}{"warn", "debug"}, vals)
+}
@@ -0,0 +1,53 @@
+package gofig
+
+// WithGlobalConfig configures whether or not the config file is read from
+// the global config directory. The file is read by default.
+func WithGlobalConfig(load bool) ConfigOption {
+	return func(c *config) {
+		c.loadGlobalConfig = load
+	}
+}
+
+// WithUserConfig configures whether or not the config file is read from the
+// user config directory. The file is read by default.
+func WithUserConfig(load bool) ConfigOption {
+	return func(c *config) {
+		c.loadUserConfig = load
+	}
+}
+
+// WithConfigName configures the name of the config file without its
+// extension. The default name is "config".
+func WithConfigName(name string) ConfigOption {
+	return func(c *config) {
+		c.configName = name
+	}
+}
+
+// WithConfigType configures the format of the config file: yml, yaml, json,
+// or toml. The default format is "yml".
+func WithConfigType(t string) ConfigOption {
+	return func(c *config) {
+		c.configType = t
+	}
+}
+
+// WithProfile configures the name of the config's profile instead of the
+// profile set with SetProfile.
+func WithProfile(p string) ConfigOption {
+	return func(c *config) {
+		c.profile = p
+	}
+}
+
+// WithStrictMode enables strict mode for the config regardless of the value
+// of the StrictMode variable.
+func WithStrictMode() ConfigOption {
+	return withStrictMode(true)
+}
+
+func withStrictMode(enabled bool) ConfigOption {
+	return func(c *config) {
+		c.strictMode = enabled
+	}
+}
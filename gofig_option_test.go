@@ -0,0 +1,49 @@
+package gofig
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigOptions(t *testing.T) {
+	etcFilePath, usrFilePath := newConfigDirs("TestNewConfigOptions", t)
+	wipeEnv()
+	assert.NoError(t, ioutil.WriteFile(
+		etcFilePath, []byte("testOption:\n  global: true\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(
+		usrFilePath, []byte("testOption:\n  user: true\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(
+		filepath.Join(filepath.Dir(usrFilePath), "myapp.json"),
+		[]byte(`{"testOption": {"json": true}}`), 0644))
+
+	c := NewConfig()
+	assert.True(t, c.GetBool("testOption.global"))
+	assert.True(t, c.GetBool("testOption.user"))
+
+	c = NewConfig(WithGlobalConfig(false))
+	assert.False(t, c.GetBool("testOption.global"))
+	assert.True(t, c.GetBool("testOption.user"))
+
+	c = NewConfig(WithUserConfig(false))
+	assert.True(t, c.GetBool("testOption.global"))
+	assert.False(t, c.GetBool("testOption.user"))
+
+	c = NewConfig(WithConfigName("myapp"), WithConfigType("json"))
+	assert.False(t, c.GetBool("testOption.global"))
+	assert.True(t, c.GetBool("testOption.json"))
+
+	c = NewConfig(WithProfile("prod"))
+	assert.Equal(t, "prod", c.GetProfile())
+
+	c = NewConfig(WithStrictMode())
+	assert.Error(t, c.ReadConfig(
+		bytes.NewReader([]byte("testOption:\n  unknown: true\n"))))
+
+	c = NewConfigLegacy(false, true, "config", "yml")
+	assert.False(t, c.GetBool("testOption.global"))
+	assert.True(t, c.GetBool("testOption.user"))
+}
@@ -0,0 +1,359 @@
+package gofig
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// overlayConfig is a view of a stack of configs in which the value of a key
+// is read from the first config in the stack in which the key is set. The
+// embedded config is the first config in the stack, so writes, such as Set,
+// modify only that config.
+type overlayConfig struct {
+	types.Config
+	layers []types.Config
+}
+
+// NewOverlay returns a view of the configs in which the first config has the
+// highest precedence, such as NewOverlay(runtime, user, system). The value of
+// a key is read from the first config in which the key is set by something
+// other than a registered default value, or from the last config if no such
+// config exists. Set and the other functions that modify a config modify only
+// the first config. NewOverlay panics if no configs are specified.
+func NewOverlay(configs ...types.Config) types.Config {
+	layers := []types.Config{}
+	for _, c := range configs {
+		if !isNilConfig(c) {
+			layers = append(layers, c)
+		}
+	}
+	if len(layers) == 0 {
+		panic(goof.New("overlay requires at least one config"))
+	}
+	return &overlayConfig{Config: layers[0], layers: layers}
+}
+
+// layer returns the config from which the value of the key is read.
+func (c *overlayConfig) layer(k interface{}) types.Config {
+	for _, l := range c.layers {
+		if l.GetSource(k) != types.Default {
+			return l
+		}
+	}
+	return c.layers[len(c.layers)-1]
+}
+
+func (c *overlayConfig) Parent() types.Config {
+	return nil
+}
+
+func (c *overlayConfig) Scope(scope interface{}) types.Config {
+	return &scopedConfig{Config: c, scope: toString(scope)}
+}
+
+func (c *overlayConfig) StrictScope(scope string) types.Config {
+	return newScopedStrictConfig(c, scope)
+}
+
+func (c *overlayConfig) SubConfig(prefix string) types.Config {
+	return newSubConfig(c, prefix)
+}
+
+func (c *overlayConfig) Extend() types.Config {
+	return newChildConfig(c)
+}
+
+func (c *overlayConfig) Copy() (types.Config, error) {
+	cc, err := c.layers[len(c.layers)-1].Copy()
+	if err != nil {
+		return nil, err
+	}
+	frozen := cc.IsFrozen()
+	cc.Unfreeze()
+	for x := len(c.layers) - 2; x >= 0; x-- {
+		for k, v := range flatConfigSettings(c.layers[x]) {
+			cc.Set(k, v)
+		}
+	}
+	if frozen {
+		cc.Freeze()
+	}
+	return cc, nil
+}
+
+// merged returns a copy of the stack with the settings of the configs merged
+// in order of precedence. The settings of the overlay are read and serialized
+// through the copy. The first config is returned if the copy fails.
+func (c *overlayConfig) merged() types.Config {
+	cc, err := c.Copy()
+	if err != nil {
+		logger().Error("error merging overlay", "error", err)
+		return c.Config
+	}
+	return cc
+}
+
+func (c *overlayConfig) AllKeys() []string {
+	ak := []string{}
+	seen := map[string]bool{}
+	for _, l := range c.layers {
+		for _, k := range l.AllKeys() {
+			if lk := strings.ToLower(k); !seen[lk] {
+				seen[lk] = true
+				ak = append(ak, k)
+			}
+		}
+	}
+	return ak
+}
+
+func (c *overlayConfig) AllSettings() map[string]interface{} {
+	flat := map[string]interface{}{}
+	for x := len(c.layers) - 1; x >= 0; x-- {
+		for k, v := range flatConfigSettings(c.layers[x]) {
+			flat[k] = v
+		}
+	}
+	return unflattenMapKeys(flat)
+}
+
+func (c *overlayConfig) Flatten() map[string]interface{} {
+	return Flatten(c.AllSettings())
+}
+
+func (c *overlayConfig) AllSettingsFlat() map[string]interface{} {
+	return c.Flatten()
+}
+
+func (c *overlayConfig) KeysMatching(pattern string) []string {
+	return keysMatching(c.AllKeys(), pattern)
+}
+
+func (c *overlayConfig) KeysWithPrefix(prefix string) []string {
+	return keysWithPrefix(c.AllKeys(), prefix)
+}
+
+func (c *overlayConfig) KeysWithSuffix(suffix string) []string {
+	return keysWithSuffix(c.AllKeys(), suffix)
+}
+
+func (c *overlayConfig) DeepEqual(other types.Config) bool {
+	return c != nil && deepEqual(c, other)
+}
+
+func (c *overlayConfig) DeepEqualKeys(
+	other types.Config, keys []string) bool {
+	return c != nil && deepEqualKeys(c, other, keys)
+}
+
+func (c *overlayConfig) IsSet(k interface{}) bool {
+	for _, l := range c.layers {
+		if l.IsSet(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *overlayConfig) GetSource(k interface{}) types.ConfigSource {
+	return c.layer(k).GetSource(k)
+}
+
+func (c *overlayConfig) GetKeyTags(k interface{}) (map[string]string, error) {
+	var err error
+	for _, l := range c.layers {
+		var tags map[string]string
+		if tags, err = l.GetKeyTags(k); err == nil {
+			return tags, nil
+		}
+	}
+	return nil, err
+}
+
+func (c *overlayConfig) GetOrFetch(
+	k interface{},
+	fetch func() (interface{}, error),
+	ttl time.Duration) (interface{}, error) {
+	if l := c.layer(k); l.GetSource(k) != types.Default {
+		return l.Get(k), nil
+	}
+	return c.Config.GetOrFetch(k, fetch, ttl)
+}
+
+func (c *overlayConfig) Get(k interface{}) interface{} {
+	return c.layer(k).Get(k)
+}
+
+func (c *overlayConfig) GetString(k interface{}) string {
+	return c.layer(k).GetString(k)
+}
+
+func (c *overlayConfig) GetBool(k interface{}) bool {
+	return c.layer(k).GetBool(k)
+}
+
+func (c *overlayConfig) GetStringSlice(k interface{}) []string {
+	return c.layer(k).GetStringSlice(k)
+}
+
+func (c *overlayConfig) GetStringSliceDelim(
+	k interface{}, delim string) []string {
+	return c.layer(k).GetStringSliceDelim(k, delim)
+}
+
+func (c *overlayConfig) GetInt(k interface{}) int {
+	return c.layer(k).GetInt(k)
+}
+
+func (c *overlayConfig) GetCtx(ctx context.Context, k interface{}) interface{} {
+	defer traceGet(ctx, c, "Get", k)()
+	return c.Get(k)
+}
+
+func (c *overlayConfig) GetStringCtx(
+	ctx context.Context, k interface{}) string {
+	defer traceGet(ctx, c, "GetString", k)()
+	return c.GetString(k)
+}
+
+func (c *overlayConfig) GetBoolCtx(ctx context.Context, k interface{}) bool {
+	defer traceGet(ctx, c, "GetBool", k)()
+	return c.GetBool(k)
+}
+
+func (c *overlayConfig) GetStringSliceCtx(
+	ctx context.Context, k interface{}) []string {
+	defer traceGet(ctx, c, "GetStringSlice", k)()
+	return c.GetStringSlice(k)
+}
+
+func (c *overlayConfig) GetIntCtx(ctx context.Context, k interface{}) int {
+	defer traceGet(ctx, c, "GetInt", k)()
+	return c.GetInt(k)
+}
+
+func (c *overlayConfig) TryGetString(k interface{}) (string, bool) {
+	return c.layer(k).TryGetString(k)
+}
+
+func (c *overlayConfig) TryGetInt(k interface{}) (int, bool) {
+	return c.layer(k).TryGetInt(k)
+}
+
+func (c *overlayConfig) TryGetBool(k interface{}) (bool, bool) {
+	return c.layer(k).TryGetBool(k)
+}
+
+func (c *overlayConfig) GetStringOrDefault(k interface{}, def string) string {
+	return c.layer(k).GetStringOrDefault(k, def)
+}
+
+func (c *overlayConfig) GetIntOrDefault(k interface{}, def int) int {
+	return c.layer(k).GetIntOrDefault(k, def)
+}
+
+func (c *overlayConfig) GetBoolOrDefault(k interface{}, def bool) bool {
+	return c.layer(k).GetBoolOrDefault(k, def)
+}
+
+func (c *overlayConfig) GetFloat64OrDefault(
+	k interface{}, def float64) float64 {
+	return c.layer(k).GetFloat64OrDefault(k, def)
+}
+
+func (c *overlayConfig) GetMap(k interface{}) map[string]interface{} {
+	return c.layer(k).GetMap(k)
+}
+
+func (c *overlayConfig) GetMapString(k interface{}) map[string]string {
+	return c.layer(k).GetMapString(k)
+}
+
+func (c *overlayConfig) GetByPath(path string) (interface{}, error) {
+	return c.merged().GetByPath(path)
+}
+
+func (c *overlayConfig) Unmarshal(rawVal interface{}) error {
+	return c.merged().Unmarshal(rawVal)
+}
+
+func (c *overlayConfig) UnmarshalKey(k interface{}, rawVal interface{}) error {
+	return c.merged().UnmarshalKey(k, rawVal)
+}
+
+func (c *overlayConfig) EnvVars() []string {
+	return c.merged().EnvVars()
+}
+
+func (c *overlayConfig) EnvVarsWithPrefix(prefix string) []string {
+	return c.merged().EnvVarsWithPrefix(prefix)
+}
+
+func (c *overlayConfig) LookupEnvVar(k interface{}) (string, string, bool) {
+	return c.merged().LookupEnvVar(k)
+}
+
+func (c *overlayConfig) MarshalJSON() ([]byte, error) {
+	return c.merged().MarshalJSON()
+}
+
+func (c *overlayConfig) ToJSON() (string, error) {
+	return c.merged().ToJSON()
+}
+
+func (c *overlayConfig) ToJSONCompact() (string, error) {
+	return c.merged().ToJSONCompact()
+}
+
+func (c *overlayConfig) MaskedToJSON() (string, error) {
+	return c.merged().MaskedToJSON()
+}
+
+func (c *overlayConfig) MaskedToJSONCompact() (string, error) {
+	return c.merged().MaskedToJSONCompact()
+}
+
+func (c *overlayConfig) ToYAML() (string, error) {
+	return c.merged().ToYAML()
+}
+
+func (c *overlayConfig) ToTOML() (string, error) {
+	return c.merged().ToTOML()
+}
+
+func (c *overlayConfig) ToProto() ([]byte, error) {
+	return c.merged().ToProto()
+}
+
+func (c *overlayConfig) ExportEnvFile(w io.Writer, includeSecure bool) error {
+	return c.merged().ExportEnvFile(w, includeSecure)
+}
+
+func (c *overlayConfig) ExportShellScript(
+	w io.Writer, includeSecure bool) error {
+	return c.merged().ExportShellScript(w, includeSecure)
+}
+
+func (c *overlayConfig) ExportKubernetesConfigMap(
+	name, namespace string, w io.Writer) error {
+	return c.merged().ExportKubernetesConfigMap(name, namespace, w)
+}
+
+func (c *overlayConfig) ExportKubernetesSecret(
+	name, namespace string, w io.Writer) error {
+	return c.merged().ExportKubernetesSecret(name, namespace, w)
+}
+
+func (c *overlayConfig) WriteConfigAs(filePath, format string) error {
+	return c.merged().WriteConfigAs(filePath, format)
+}
+
+func (c *overlayConfig) WriteCompressedConfigFile(filePath string) error {
+	return c.merged().WriteCompressedConfigFile(filePath)
+}
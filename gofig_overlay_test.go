@@ -0,0 +1,121 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newOverlayTestConfig(t *testing.T, yml string) *config {
+	c := newConfigWithOptions(false, false, "config", "yml")
+	if err := c.ReadConfig(bytes.NewReader([]byte(yml))); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestOverlay(t *testing.T) {
+	wipeEnv()
+	system := newOverlayTestConfig(t, `log:
+  level: warn
+  file: /var/log/app.log
+server:
+  port: 80
+`)
+	user := newOverlayTestConfig(t, `log:
+  level: info
+`)
+	runtime := newConfigWithOptions(false, false, "config", "yml")
+
+	o := NewOverlay(runtime, user, system)
+	assert.Equal(t, "info", o.GetString("log.level"))
+	assert.Equal(t, "/var/log/app.log", o.GetString("log.file"))
+	assert.Equal(t, 80, o.GetInt("server.port"))
+	assert.True(t, o.IsSet("server.port"))
+	assert.False(t, o.IsSet("server.host"))
+
+	o.Set("log.level", "debug")
+	assert.Equal(t, "debug", o.GetString("log.level"))
+	assert.Equal(t, "debug", runtime.GetString("log.level"))
+	assert.Equal(t, "info", user.GetString("log.level"))
+	assert.Equal(t, "warn", system.GetString("log.level"))
+
+	ak := o.AllKeys()
+	assert.Subset(t, ak, []string{"log.level", "log.file", "server.port"})
+	assert.Len(t, ak, len(system.AllKeys()))
+
+	flat := Flatten(o.AllSettings())
+	assert.Equal(t, "debug", flat["log.level"])
+	assert.Equal(t, "/var/log/app.log", flat["log.file"])
+	assert.Equal(t, 80, flat["server.port"])
+
+	assert.Equal(t, "debug", o.Scope("log").GetString("level"))
+	cc, err := o.Copy()
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", cc.GetString("log.level"))
+	assert.Equal(t, 80, cc.GetInt("server.port"))
+}
+
+func TestOverlayNoConfigs(t *testing.T) {
+	assert.Panics(t, func() { NewOverlay() })
+	assert.Panics(t, func() { NewOverlay(nil) })
+}
+
+func TestOverlaySerialization(t *testing.T) {
+	wipeEnv()
+	system := newOverlayTestConfig(t, `log:
+  level: warn
+  file: /var/log/app.log
+server:
+  port: 80
+`)
+	user := newOverlayTestConfig(t, `log:
+  level: info
+`)
+	runtime := newConfigWithOptions(false, false, "config", "yml")
+	runtime.Set("server.host", "localhost")
+	system.Freeze()
+
+	o := NewOverlay(runtime, user, system)
+
+	s, err := o.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, s, `"level":"info"`)
+	assert.Contains(t, s, `"file":"/var/log/app.log"`)
+	assert.Contains(t, s, `"host":"localhost"`)
+	assert.Contains(t, s, `"port":80`)
+
+	var l struct {
+		Level string
+		File  string
+	}
+	assert.NoError(t, o.UnmarshalKey("log", &l))
+	assert.Equal(t, "info", l.Level)
+	assert.Equal(t, "/var/log/app.log", l.File)
+
+	var m struct {
+		Server struct {
+			Host string
+			Port int
+		}
+	}
+	assert.NoError(t, o.Unmarshal(&m))
+	assert.Equal(t, "localhost", m.Server.Host)
+	assert.Equal(t, 80, m.Server.Port)
+
+	v, err := o.GetByPath("server.port")
+	assert.NoError(t, err)
+	assert.Equal(t, 80, v)
+
+	ev := o.EnvVars()
+	assert.Contains(t, ev, "LOG_LEVEL=info")
+	assert.Contains(t, ev, "LOG_FILE=/var/log/app.log")
+	assert.Contains(t, ev, "SERVER_HOST=localhost")
+	assert.Contains(t, ev, "SERVER_PORT=80")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, o.ExportEnvFile(buf, false))
+	assert.Contains(t, buf.String(), "LOG_FILE=")
+	assert.True(t, system.IsFrozen())
+}
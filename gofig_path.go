@@ -0,0 +1,32 @@
+package gofig
+
+import (
+	"path/filepath"
+
+	"github.com/akutz/gotil"
+)
+
+func (c *config) AddConfigPath(path string) {
+	if c.frozen.Load() {
+		return
+	}
+	logger().Debug("adding config path", "path", path)
+	c.configPaths = append(c.configPaths, path)
+	c.loadConfigFiles(path, "added")
+}
+
+// findConfigFile returns the path of the config file. If the file path is
+// relative and does not exist then the first matching file in the config's
+// added paths is returned. If no matching file is found then the file path
+// is returned unchanged.
+func (c *config) findConfigFile(filePath string) string {
+	if filepath.IsAbs(filePath) || gotil.FileExists(filePath) {
+		return filePath
+	}
+	for _, p := range c.configPaths {
+		if fp := filepath.Join(p, filePath); gotil.FileExists(fp) {
+			return fp
+		}
+	}
+	return filePath
+}
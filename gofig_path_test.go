@@ -0,0 +1,50 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddConfigPath(t *testing.T) {
+	newConfigDirs("TestAddConfigPath", t)
+	wipeEnv()
+
+	tmpDir, err := ioutil.TempDir("", "gofig-test-TestAddConfigPath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := ioutil.WriteFile(
+		path.Join(tmpDir, "config.yml"), yamlConfig1, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(
+		path.Join(tmpDir, "plugin.yml"), yamlConfig2, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New()
+	assertString(t, c, "rexray.logLevel", "warn")
+
+	c.AddConfigPath(tmpDir)
+	assertString(t, c, "rexray.logLevel", "error")
+	assertStorageDrivers(t, c)
+
+	assert.NoError(t, c.ReadConfigFile("plugin.yml"))
+	assertString(t, c, "rexray.logLevel", "debug")
+	assert.Error(t, c.ReadConfigFile("missing.yml"))
+
+	c = New()
+	c.Freeze()
+	c.AddConfigPath(tmpDir)
+	assertString(t, c, "rexray.logLevel", "warn")
+
+	c = New()
+	ReadOnly(c).AddConfigPath(tmpDir)
+	assertString(t, c, "rexray.logLevel", "warn")
+}
@@ -2,27 +2,107 @@ package gofig
 
 import (
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
 	"github.com/spf13/pflag"
-	"github.com/spf13/viper"
+	"golang.org/x/sync/singleflight"
 
+	"github.com/akutz/gofig/backend"
 	"github.com/akutz/gofig/types"
 )
 
 // config contains the configuration information
 type config struct {
-	v                         *viper.Viper
+	b                         backend.Backend
+	rwl                       *sync.RWMutex
 	flagSets                  map[string]*pflag.FlagSet
 	disableEnvVarSubstitution bool
+	strictMode                bool
+	frozen                    atomic.Bool
+	configFilePath            string
+	configName                string
+	configType                string
+	profile                   string
+	envKeyPrefix              string
+	configPaths               []string
+	loadGlobalConfig          bool
+	loadUserConfig            bool
+	globalConfigPath          string
+	userConfigPath            string
+	registrations             []types.ConfigRegistration
+	subParent                 types.Config
+	subPrefix                 string
+	keyPrefix                 string
+	reads                     []configRead
+	keyBindings               map[string]*keyBinding
+	overrideKeys              map[string]bool
+	readKeys                  map[string]bool
+	stats                     accessStats
+	coverage                  keyCoverage
+	debugAccess               debugAccess
+	mergeStrategy             types.MergeStrategy
+	snapshot                  atomic.Value
+	deprecations              map[string]string
+	deprecationsRWL           *sync.RWMutex
+	mergedSecureKeys          map[string]bool
+	mergedSecureKeysRWL       *sync.RWMutex
+	changeCallbacks           map[int]*changeCallback
+	changeCallbacksRWL        *sync.RWMutex
+	changeCallbackID          int
+	observers                 sync.Map
+	observersRWL              *sync.RWMutex
+	observerCount             int32
+	pollDone                  chan struct{}
+	pollWG                    *sync.WaitGroup
+	pollRWL                   *sync.RWMutex
+	ttls                      ttlHeap
+	ttlKeys                   map[string]*ttlEntry
+	ttlRWL                    *sync.RWMutex
+	ttlCount                  int32
+	ttlRunning                bool
+	auditLog                  types.AuditLog
+	configVersion             int
+	compression               string
+	auditLogRWL               *sync.RWMutex
+	fetchGroup                singleflight.Group
+	watchDebounce             *debouncer
+	keyTransformer            func(string) string
 }
 
 func newConfigObj() *config {
-	return &config{
-		v:                         viper.New(),
+	c := &config{
+		b:                         backend.NewViperBackend(nil),
+		rwl:                       &sync.RWMutex{},
 		flagSets:                  map[string]*pflag.FlagSet{},
 		disableEnvVarSubstitution: DisableEnvVarSubstitution,
+		strictMode:                StrictMode,
+		profile:                   getProfile(),
+		envKeyPrefix:              getEnvKeyPrefix(),
+		globalConfigPath:          etcDirPath,
+		userConfigPath:            usrDirPath,
+		deprecations:              map[string]string{},
+		deprecationsRWL:           &sync.RWMutex{},
+		mergedSecureKeys:          map[string]bool{},
+		mergedSecureKeysRWL:       &sync.RWMutex{},
+		changeCallbacks:           map[int]*changeCallback{},
+		changeCallbacksRWL:        &sync.RWMutex{},
+		observersRWL:              &sync.RWMutex{},
+		pollWG:                    &sync.WaitGroup{},
+		pollRWL:                   &sync.RWMutex{},
+		ttlKeys:                   map[string]*ttlEntry{},
+		ttlRWL:                    &sync.RWMutex{},
+		auditLogRWL:               &sync.RWMutex{},
+		configVersion:             latestConfigVersion(),
+		compression:               CompressionGzip,
+		keyBindings:               map[string]*keyBinding{},
+		overrideKeys:              map[string]bool{},
+		readKeys:                  map[string]bool{},
 	}
+	c.watchDebounce = newDebouncer(DefaultWatchDebounce, c.reloadWatchedFile)
+	return c
 }
 
 func (c *config) FlagSets() map[string]*pflag.FlagSet {
@@ -37,48 +117,70 @@ func (c *config) processRegKeys(r types.ConfigRegistration) {
 		c.flagSets[fsn] = fs
 	}
 
+	envVars := c.normalizedEnvVars()
 	for k := range r.Keys() {
 
-		if fs.Lookup(k.FlagName()) != nil {
+		evn := keyEnvVar(c.transformKey(k.KeyName()),
+			c.envVarName(k.EnvVarName()), envVars)
+
+		// the flag already exists when the flag sets are reused, such as
+		// when the config is reset, so only the bindings are created
+		if f := fs.Lookup(k.FlagName()); f != nil {
+			c.b.BindEnv(k.KeyName(), evn)
+			c.bindFlag(k.KeyName(), f)
+			c.addKeyBinding(k, evn, f)
 			continue
 		}
 
-		evn := k.EnvVarName()
-
 		if LogRegKey {
-			log.WithFields(log.Fields{
-				"keyName":      k.KeyName(),
-				"keyType":      k.KeyType(),
-				"flagName":     k.FlagName(),
-				"envVar":       evn,
-				"defaultValue": k.DefaultValue(),
-				"usage":        k.Description(),
-			}).Debug("adding flag")
+			logger().Debug("adding flag",
+				"keyName", k.KeyName(),
+				"keyType", k.KeyType(),
+				"flagName", k.FlagName(),
+				"envVar", evn,
+				"defaultValue", k.DefaultValue(),
+				"usage", k.Description(),
+			)
 		}
 
 		// bind the environment variable
-		c.v.BindEnv(k.KeyName(), evn)
+		c.b.BindEnv(k.KeyName(), evn)
+		if os.Getenv(evn) != "" {
+			if err := c.deprecatedKeyError(k.KeyName()); err != nil {
+				logger().Error("deprecated environment variable",
+					"envVar", evn, "error", err)
+			}
+		}
 
 		if k.Short() == "" {
 			switch k.KeyType() {
-			case types.String, types.SecureString:
+			case types.String, types.SecureString, types.StringEnum:
 				fs.String(k.FlagName(), k.DefaultValue().(string), k.Description())
 			case types.Int:
 				fs.Int(k.FlagName(), k.DefaultValue().(int), k.Description())
 			case types.Bool:
 				fs.Bool(k.FlagName(), k.DefaultValue().(bool), k.Description())
+			case types.Float64:
+				fs.Float64(k.FlagName(), k.DefaultValue().(float64), k.Description())
+			case types.Duration:
+				fs.Duration(k.FlagName(), cast.ToDuration(k.DefaultValue()), k.Description())
 			}
 		} else {
 			switch k.KeyType() {
-			case types.String, types.SecureString:
+			case types.String, types.SecureString, types.StringEnum:
 				fs.StringP(k.FlagName(), k.Short(), k.DefaultValue().(string), k.Description())
 			case types.Int:
 				fs.IntP(k.FlagName(), k.Short(), k.DefaultValue().(int), k.Description())
 			case types.Bool:
 				fs.BoolP(k.FlagName(), k.Short(), k.DefaultValue().(bool), k.Description())
+			case types.Float64:
+				fs.Float64P(k.FlagName(), k.Short(), k.DefaultValue().(float64), k.Description())
+			case types.Duration:
+				fs.DurationP(k.FlagName(), k.Short(), cast.ToDuration(k.DefaultValue()), k.Description())
 			}
 		}
 
-		c.v.BindPFlag(k.KeyName(), fs.Lookup(k.FlagName()))
+		c.bindFlag(k.KeyName(), fs.Lookup(k.FlagName()))
+		c.addKeyBinding(k, evn, fs.Lookup(k.FlagName()))
 	}
 }
@@ -0,0 +1,150 @@
+package gofig
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) PollConfigURL(
+	u string,
+	interval time.Duration,
+	opts ...types.HTTPOption) (<-chan struct{}, error) {
+
+	if c.frozen.Load() {
+		return nil, ErrFrozen
+	}
+	if interval <= 0 {
+		return nil, goof.WithField(
+			"interval", interval, "poll interval must be positive")
+	}
+	uc, err := fetchURLContent(u, c.configType, "", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.readURLContent(u, uc, opts); err != nil {
+		return nil, err
+	}
+
+	c.pollRWL.Lock()
+	defer c.pollRWL.Unlock()
+	if c.pollDone == nil {
+		c.pollDone = make(chan struct{})
+	}
+	changes := make(chan struct{}, 1)
+	c.pollWG.Add(1)
+	go c.pollURL(u, interval, opts, uc, changes, c.pollDone)
+	return changes, nil
+}
+
+func (c *config) StopPolling() {
+	c.pollRWL.Lock()
+	defer c.pollRWL.Unlock()
+	if c.pollDone == nil {
+		return
+	}
+	close(c.pollDone)
+	c.pollWG.Wait()
+	c.pollDone = nil
+}
+
+// pollURL requests the file at the URL each time the interval elapses until
+// the done channel is closed. The config is updated and a value is sent on
+// the changes channel when the file changes. The poll that follows a response
+// indicating an authorization or server error is skipped.
+func (c *config) pollURL(
+	u string,
+	interval time.Duration,
+	opts []types.HTTPOption,
+	last *urlContent,
+	changes chan<- struct{},
+	done <-chan struct{}) {
+
+	defer c.pollWG.Done()
+	defer close(changes)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	skip := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+		if skip {
+			skip = false
+			continue
+		}
+
+		uc, err := fetchURLContent(u, c.configType, last.etag, opts)
+		if err != nil {
+			logger().Error("error polling config url", "url", u, "error", err)
+			skip = uc != nil && isPollSkipStatus(uc.status)
+			continue
+		}
+		if uc.notModified {
+			continue
+		}
+		if bytes.Equal(uc.buf, last.buf) {
+			last = uc
+			continue
+		}
+		if err := c.readURLContent(u, uc, opts); err != nil {
+			logger().Error("error reading polled config url",
+				"url", u, "error", err)
+			continue
+		}
+		last = uc
+
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// isPollSkipStatus returns a flag indicating whether or not the poll that
+// follows a response with the specified status should be skipped.
+func isPollSkipStatus(status int) bool {
+	return status == http.StatusUnauthorized ||
+		status == http.StatusForbidden ||
+		status >= http.StatusInternalServerError
+}
+
+// readURLContent reads the content of the file at the URL into the config. If
+// the URL was read previously then the recorded stream's contents are
+// replaced so that the config's reads do not grow with each poll.
+func (c *config) readURLContent(
+	u string, uc *urlContent, opts []types.HTTPOption) error {
+
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	if err := c.checkSettings(uc.buf, uc.format); err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	for i := len(c.reads) - 1; i >= 0; i-- {
+		if c.reads[i].url != u {
+			continue
+		}
+		if err := c.rereadConfig(i, uc.buf); err != nil {
+			return err
+		}
+		c.publishSnapshot()
+		return nil
+	}
+	return c.mergeConfigRead(configRead{
+		buf:      uc.buf,
+		format:   uc.format,
+		url:      u,
+		httpOpts: opts,
+	})
+}
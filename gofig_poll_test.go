@@ -0,0 +1,112 @@
+package gofig
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollConfigURL(t *testing.T) {
+	newConfigDirs("TestPollConfigURL", t)
+	wipeEnv()
+
+	var (
+		rwl       sync.RWMutex
+		version   = 1
+		status    = http.StatusOK
+		requests  int32
+		notModify int32
+	)
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			rwl.RLock()
+			defer rwl.RUnlock()
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+			etag := fmt.Sprintf(`"v%d"`, version)
+			if req.Header.Get("If-None-Match") == etag {
+				atomic.AddInt32(&notModify, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Content-Type", "application/x-yaml")
+			fmt.Fprintf(w, "testPoll:\n  version: %d\n", version)
+		}))
+	defer s.Close()
+
+	c := New()
+	changes, err := c.PollConfigURL(s.URL, 10*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, c.GetInt("testPoll.version"))
+
+	waitFor := func(cond func() bool) {
+		for i := 0; i < 500 && !cond(); i++ {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitFor(func() bool { return atomic.LoadInt32(&notModify) >= 2 })
+	assert.True(t, atomic.LoadInt32(&notModify) >= 2)
+	select {
+	case <-changes:
+		t.Fatal("unexpected change")
+	default:
+	}
+
+	rwl.Lock()
+	version = 2
+	rwl.Unlock()
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change")
+	}
+	assert.Equal(t, 2, c.GetInt("testPoll.version"))
+
+	rwl.Lock()
+	status = http.StatusServiceUnavailable
+	rwl.Unlock()
+	n := atomic.LoadInt32(&requests)
+	waitFor(func() bool { return atomic.LoadInt32(&requests) > n })
+	assert.Equal(t, 2, c.GetInt("testPoll.version"))
+
+	rwl.Lock()
+	status = http.StatusOK
+	version = 3
+	rwl.Unlock()
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change")
+	}
+	assert.Equal(t, 3, c.GetInt("testPoll.version"))
+
+	c.StopPolling()
+	_, ok := <-changes
+	assert.False(t, ok)
+	n = atomic.LoadInt32(&requests)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, atomic.LoadInt32(&requests))
+	c.StopPolling()
+
+	_, err = c.PollConfigURL(s.URL, 0)
+	assert.Error(t, err)
+	_, err = ReadOnly(c).PollConfigURL(s.URL, time.Second)
+	assert.Equal(t, ErrReadOnly, err)
+}
+
+func TestIsPollSkipStatus(t *testing.T) {
+	assert.True(t, isPollSkipStatus(http.StatusUnauthorized))
+	assert.True(t, isPollSkipStatus(http.StatusForbidden))
+	assert.True(t, isPollSkipStatus(http.StatusBadGateway))
+	assert.False(t, isPollSkipStatus(http.StatusNotFound))
+}
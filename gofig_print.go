@@ -0,0 +1,142 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+const (
+	printRedactedValue = "[REDACTED]"
+	printKeyColor      = "\x1b[36m"
+	printValueColor    = "\x1b[33m"
+	printResetColor    = "\x1b[0m"
+)
+
+// PrintOption is an option used to configure how Print renders a config.
+type PrintOption func(o *printOptions)
+
+type printOptions struct {
+	color bool
+	flat  bool
+}
+
+// ColorOutput configures Print to color the keys and values with ANSI escape
+// codes.
+func ColorOutput() PrintOption {
+	return func(o *printOptions) {
+		o.color = true
+	}
+}
+
+// FlatMode configures Print to render the config as key=value lines with the
+// keys in dot-notation instead of as a tree.
+func FlatMode() PrintOption {
+	return func(o *printOptions) {
+		o.flat = true
+	}
+}
+
+// Print writes the config's settings to the writer as an indented tree in
+// which each parent key is a heading for its child keys. The keys are sorted
+// alphabetically so the output is the same for the same settings. The values
+// of the secure keys, including those read from a scoped config, are rendered
+// as [REDACTED].
+func Print(c types.Config, w io.Writer, opts ...PrintOption) error {
+	o := &printOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	flat := flatConfigSettings(c)
+	for k := range flat {
+		if isSecureConfigKey(c, k) {
+			flat[k] = printRedactedValue
+		}
+	}
+	if o.flat {
+		return printFlat(w, flat, o)
+	}
+	return printTree(w, unflattenMapKeys(flat), "", o)
+}
+
+// PrintToString returns the tree that Print renders for the config.
+func PrintToString(c types.Config) string {
+	buf := &bytes.Buffer{}
+	Print(c, buf)
+	return buf.String()
+}
+
+func printFlat(
+	w io.Writer, flat map[string]interface{}, o *printOptions) error {
+
+	for _, k := range sortedKeys(flat) {
+		if _, err := fmt.Fprintf(w, "%s=%s\n",
+			o.key(k), o.value(printValue(flat[k]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printTree(
+	w io.Writer, m map[string]interface{}, indent string, o *printOptions) error {
+
+	for _, k := range sortedKeys(m) {
+		if cm, ok := m[k].(map[string]interface{}); ok {
+			if _, err := fmt.Fprintf(w, "%s%s:\n", indent, o.key(k)); err != nil {
+				return err
+			}
+			if err := printTree(w, cm, indent+"  ", o); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s%s: %s\n",
+			indent, o.key(k), o.value(printValue(m[k]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printValue returns the value as a string. The elements of a slice are
+// separated by commas and enclosed in square brackets.
+func printValue(v interface{}) string {
+	switch tv := v.(type) {
+	case []string:
+		return fmt.Sprintf("[%s]", strings.Join(tv, ", "))
+	case []interface{}:
+		return fmt.Sprintf(
+			"[%s]", strings.Join(cast.ToStringSlice(tv), ", "))
+	}
+	return cast.ToString(v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (o *printOptions) key(s string) string {
+	if !o.color {
+		return s
+	}
+	return printKeyColor + s + printResetColor
+}
+
+func (o *printOptions) value(s string) string {
+	if !o.color {
+		return s
+	}
+	return printValueColor + s + printResetColor
+}
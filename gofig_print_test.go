@@ -0,0 +1,59 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func newPrintTestConfig(t *testing.T) types.Config {
+	TestHook(t)
+	ClearRegistrations()
+	r := newRegistration("Test Print")
+	r.Key(types.SecureString, "", "", "", "testPrint.db.password")
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	c.Set("testPrint.db.password", "secret")
+	c.Set("testPrint.db.host", "db.example.com")
+	c.Set("testPrint.name", "app")
+	c.Set("testPrint.tags", []string{"a", "b"})
+	c.Set("alpha", 1)
+	return c
+}
+
+func TestPrint(t *testing.T) {
+	wipeEnv()
+	c := newPrintTestConfig(t)
+
+	assert.Equal(t, `alpha: 1
+testprint:
+  db:
+    host: db.example.com
+    password: [REDACTED]
+  name: app
+  tags: [a, b]
+`, PrintToString(c))
+	assert.Equal(t, PrintToString(c), PrintToString(c))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Print(c, buf, FlatMode()))
+	assert.Equal(t, `alpha=1
+testprint.db.host=db.example.com
+testprint.db.password=[REDACTED]
+testprint.name=app
+testprint.tags=[a, b]
+`, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, Print(c.Scope("testPrint"), buf, ColorOutput()))
+	assert.Contains(t, buf.String(),
+		"  \x1b[36mhost\x1b[0m: \x1b[33mdb.example.com\x1b[0m\n")
+	assert.Contains(t, buf.String(), "\x1b[36mdb\x1b[0m:\n")
+	assert.NotContains(t, buf.String(), "secret")
+
+	s := PrintToString(ReadOnly(c).SubConfig("testPrint").Scope("db"))
+	assert.Equal(t, "host: db.example.com\npassword: [REDACTED]\n", s)
+}
@@ -0,0 +1,36 @@
+package gofig
+
+import (
+	"os"
+	"sync"
+)
+
+var (
+	profile    string
+	profileRWL = &sync.RWMutex{}
+)
+
+// SetProfile sets the name of the profile used by Config instances created
+// after this function is called. In addition to the config file, such as
+// config.yml, the config file for the profile, such as config.prod.yml, is
+// read from the global and user config directories, and the registrations'
+// profile yaml is read after their default yaml. If no profile is set then
+// the value of the environment variable GOFIG_PROFILE is used.
+func SetProfile(name string) {
+	profileRWL.Lock()
+	defer profileRWL.Unlock()
+	profile = name
+}
+
+func getProfile() string {
+	profileRWL.RLock()
+	defer profileRWL.RUnlock()
+	if profile != "" {
+		return profile
+	}
+	return os.Getenv("GOFIG_PROFILE")
+}
+
+func (c *config) GetProfile() string {
+	return c.profile
+}
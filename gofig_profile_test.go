@@ -0,0 +1,75 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestProfile(t *testing.T) {
+	etcFile, usrFile := newConfigDirs("TestProfile", t)
+	wipeEnv()
+	TestHook(t)
+	defer SetProfile("")
+
+	if err := ioutil.WriteFile(etcFile, yamlConfig1, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(
+		path.Join(path.Dir(usrFile), "config.prod.yml"),
+		yamlConfig2, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newRegistration("Test Profile")
+	r.SetYAML(`testProfile:
+  replicas: 1
+  region: us-east-1
+`)
+	r.SetProfileYAML(map[string]string{"prod": `testProfile:
+  replicas: 3
+`})
+	r.Key(types.Int, "", 1, "", "testProfile.replicas")
+	r.Key(types.String, "", "", "", "testProfile.region")
+	Register(r)
+
+	c := New()
+	assert.Equal(t, "", c.GetProfile())
+	assertString(t, c, "rexray.logLevel", "error")
+	assertOsDrivers1(t, c)
+	assert.Equal(t, 1, c.GetInt("testProfile.replicas"))
+
+	SetProfile("prod")
+	c = New()
+	assert.Equal(t, "prod", c.GetProfile())
+	assert.Equal(t, "prod", c.Scope("rexray").GetProfile())
+	assertString(t, c, "rexray.logLevel", "debug")
+	assertOsDrivers2(t, c)
+	assertStorageDrivers(t, c)
+	assert.Equal(t, 3, c.GetInt("testProfile.replicas"))
+	assertString(t, c, "testProfile.region", "us-east-1")
+
+	SetProfile("staging")
+	c = New()
+	assertString(t, c, "rexray.logLevel", "error")
+	assert.Equal(t, 1, c.GetInt("testProfile.replicas"))
+}
+
+func TestProfileEnvVar(t *testing.T) {
+	newConfigDirs("TestProfileEnvVar", t)
+	wipeEnv()
+
+	os.Setenv("GOFIG_PROFILE", "dev")
+	defer os.Unsetenv("GOFIG_PROFILE")
+
+	assert.Equal(t, "dev", New().GetProfile())
+
+	SetProfile("prod")
+	defer SetProfile("")
+	assert.Equal(t, "prod", New().GetProfile())
+}
@@ -0,0 +1,42 @@
+package gofig
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/akutz/gofig/types"
+)
+
+// FromProto initializes a new Config instance from a protobuf message
+// created with ToProto.
+func FromProto(b []byte) (types.Config, error) {
+	s := &structpb.Struct{}
+	if err := proto.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	buf, err := json.Marshal(s.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	c := newConfig()
+	for k, v := range s.AsMap() {
+		c.b.Set(k, v)
+	}
+	c.reads = append(c.reads, configRead{buf: buf, format: "json"})
+	c.publishSnapshot()
+	return c, nil
+}
+
+func (c *config) ToProto() ([]byte, error) {
+	m, err := c.allSecureSettings(secureValuesDelete)
+	if err != nil {
+		return nil, err
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(s)
+}
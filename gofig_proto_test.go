@@ -0,0 +1,38 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestProto(t *testing.T) {
+	newConfigDirs("TestProto", t)
+	wipeEnv()
+
+	r := newRegistration("Test Proto")
+	r.Key(types.SecureString, "", "", "", "testProto.password")
+
+	c := New()
+	c.Set("testProto.password", "secret")
+	c.Set("testProto.host", "localhost")
+	c.Set("testProto.port", 8080)
+	c.Set("testProto.names", []string{"a", "b"})
+
+	b, err := c.ToProto()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "secret")
+
+	pc, err := FromProto(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", pc.GetString("testProto.host"))
+	assert.Equal(t, 8080, pc.GetInt("testProto.port"))
+	assert.Equal(t, []string{"a", "b"}, pc.GetStringSlice("testProto.names"))
+	assert.Equal(t, "tcp://:7979", pc.GetString("rexray.host"))
+	assert.False(t, pc.IsSet("testProto.password"))
+
+	_, err = FromProto([]byte("invalid"))
+	assert.Error(t, err)
+}
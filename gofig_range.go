@@ -0,0 +1,167 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akutz/goof"
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+// checkKeyRange panics if the key's range is specified for a key that is not
+// an Int, Float64, or Duration key, if the range is invalid, or if the key's
+// default value is outside the range.
+func checkKeyRange(k *configRegKey) {
+	if k.min == nil && k.max == nil {
+		return
+	}
+	if k.keyType == types.Duration {
+		checkDurationRange(k)
+		return
+	}
+	if k.keyType != types.Int && k.keyType != types.Float64 {
+		panic(goof.WithFields(map[string]interface{}{
+			"key":     k.keyName,
+			"keyType": k.keyType.String(),
+		}, "range requires an Int, Float64, or Duration key"))
+	}
+	for _, v := range []interface{}{k.min, k.max} {
+		if v == nil {
+			continue
+		}
+		if _, err := cast.ToFloat64E(v); err != nil {
+			panic(goof.WithFieldsE(map[string]interface{}{
+				"key":   k.keyName,
+				"bound": v,
+			}, "invalid range bound", err))
+		}
+	}
+	if k.min != nil && k.max != nil &&
+		cast.ToFloat64(k.min) > cast.ToFloat64(k.max) {
+		panic(goof.WithFields(map[string]interface{}{
+			"key": k.keyName,
+			"min": k.min,
+			"max": k.max,
+		}, "min is greater than max"))
+	}
+	if err := outOfRangeError(k, k.defVal); err != nil {
+		panic(err)
+	}
+}
+
+// checkDurationRange panics if the range of the Duration key is invalid or if
+// the key's default value is outside the range. The bounds are stored as
+// time.Duration values.
+func checkDurationRange(k *configRegKey) {
+	for _, v := range []*interface{}{&k.min, &k.max} {
+		if *v == nil {
+			continue
+		}
+		d, err := cast.ToDurationE(*v)
+		if err != nil {
+			panic(goof.WithFieldsE(map[string]interface{}{
+				"key":   k.keyName,
+				"bound": *v,
+			}, "invalid range bound", err))
+		}
+		*v = d
+	}
+	if k.min != nil && k.max != nil &&
+		k.min.(time.Duration) > k.max.(time.Duration) {
+		panic(goof.WithFields(map[string]interface{}{
+			"key": k.keyName,
+			"min": k.min,
+			"max": k.max,
+		}, "min is greater than max"))
+	}
+	if err := outOfRangeError(k, k.defVal); err != nil {
+		panic(err)
+	}
+}
+
+// outOfRangeError returns an error if the value is not a number or if it is
+// outside the key's range.
+func outOfRangeError(
+	k types.ConfigRegistrationKey, v interface{}) ConfigError {
+
+	if k.KeyType() == types.Duration {
+		return durationOutOfRangeError(k, v)
+	}
+	if k.Min() == nil && k.Max() == nil {
+		return nil
+	}
+	f, err := cast.ToFloat64E(v)
+	if err != nil {
+		return NewConfigError(ErrCodeInvalidType, k.KeyName(),
+			goof.WithFieldsE(map[string]interface{}{
+				"value": v,
+				"type":  k.KeyType().String(),
+			}, "value is not a number", err))
+	}
+	if (k.Min() == nil || f >= cast.ToFloat64(k.Min())) &&
+		(k.Max() == nil || f <= cast.ToFloat64(k.Max())) {
+		return nil
+	}
+	return NewConfigError(ErrCodeOutOfRange, k.KeyName(), goof.WithFields(
+		map[string]interface{}{
+			"value": v,
+			"min":   k.Min(),
+			"max":   k.Max(),
+		}, "value is out of range"))
+}
+
+// durationOutOfRangeError returns an error if the value is not a duration or
+// if it is outside the Duration key's range.
+func durationOutOfRangeError(
+	k types.ConfigRegistrationKey, v interface{}) ConfigError {
+
+	d, err := cast.ToDurationE(v)
+	if err != nil {
+		return NewConfigError(ErrCodeInvalidType, k.KeyName(),
+			goof.WithFieldsE(map[string]interface{}{
+				"value": v,
+				"type":  k.KeyType().String(),
+			}, fmt.Sprintf("%v is not a valid duration", v), err))
+	}
+	if min := k.Min(); min != nil && d < cast.ToDuration(min) {
+		return NewConfigError(ErrCodeOutOfRange, k.KeyName(), goof.Newf(
+			"%v is below minimum %v", d, cast.ToDuration(min)))
+	}
+	if max := k.Max(); max != nil && d > cast.ToDuration(max) {
+		return NewConfigError(ErrCodeOutOfRange, k.KeyName(), goof.Newf(
+			"%v is above maximum %v", d, cast.ToDuration(max)))
+	}
+	return nil
+}
+
+// checkRanges returns an error for each Int, Float64, or Duration key with a
+// range whose value in the config is outside the key's range and for each
+// Duration key whose value in the config is not a valid duration.
+func checkRanges(c types.Config) []ConfigError {
+	registrationsRWL.RLock()
+	regs := append([]types.ConfigRegistration{}, registrations...)
+	registrationsRWL.RUnlock()
+	if cc, ok := unwrapConfig(c); ok {
+		regs = append(regs, cc.registrations...)
+	}
+
+	errs := []ConfigError{}
+	seen := map[string]bool{}
+	for _, r := range regs {
+		for k := range r.Keys() {
+			lk := strings.ToLower(k.KeyName())
+			if seen[lk] || (k.Min() == nil && k.Max() == nil &&
+				k.KeyType() != types.Duration) {
+				continue
+			}
+			seen[lk] = true
+			if err := outOfRangeError(k, c.Get(k.KeyName())); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,196 @@
+package gofig
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func newRangeConfig() types.Config {
+	r := newRegistration("Test Range")
+	r.Key(types.Int, "", 8080, "The port", "testRange.port",
+		types.Min(1), types.Max(65535))
+	r.Key(types.Float64, "", 0.5, "The sample rate", "testRange.rate",
+		types.Min(0.0), types.Max(1.0))
+	Register(r)
+	return New()
+}
+
+func TestRange(t *testing.T) {
+	newConfigDirs("TestRange", t)
+	wipeEnv()
+	TestHook(t)
+
+	c := newRangeConfig()
+	assert.NoError(t, Validate(c))
+
+	for _, v := range []interface{}{1, 65535} {
+		c.Set("testRange.port", v)
+		assert.NoError(t, Validate(c))
+	}
+	for _, v := range []interface{}{0.0, 1.0} {
+		c.Set("testRange.rate", v)
+		assert.NoError(t, Validate(c))
+	}
+
+	c.Set("testRange.port", 0)
+	c.Set("testRange.rate", 1.01)
+	err := Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 2)
+		assert.Equal(t, ErrCodeOutOfRange, verr.Errors[0].Code())
+		assert.Equal(t, "testRange.port", verr.Errors[0].Key())
+		assert.Equal(t, ErrCodeOutOfRange, verr.Errors[1].Code())
+		assert.Equal(t, "testRange.rate", verr.Errors[1].Key())
+	}
+}
+
+func TestRangeEnvVar(t *testing.T) {
+	newConfigDirs("TestRangeEnvVar", t)
+	wipeEnv()
+	TestHook(t)
+
+	os.Setenv("TESTRANGE_PORT", "65536")
+	defer os.Unsetenv("TESTRANGE_PORT")
+	c := newRangeConfig()
+	err := Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeOutOfRange, verr.Errors[0].Code())
+		assert.Equal(t, "testRange.port", verr.Errors[0].Key())
+	}
+
+	os.Setenv("TESTRANGE_PORT", "http")
+	err = Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeInvalidType, verr.Errors[0].Code())
+	}
+}
+
+func TestRangeInvalidRegistration(t *testing.T) {
+	TestHook(t)
+
+	r := newRegistration("Test Range Invalid")
+	assert.Panics(t, func() {
+		r.Key(types.Int, "", 0, "", "testRange.port", types.Min(1))
+	})
+	assert.Panics(t, func() {
+		r.Key(types.Float64, "", 1.5, "", "testRange.rate", types.Max(1.0))
+	})
+	assert.Panics(t, func() {
+		r.Key(types.String, "", "", "", "testRange.name", types.Min(1))
+	})
+	assert.Panics(t, func() {
+		r.Key(types.Int, "", 5, "", "testRange.count",
+			types.Min(10), types.Max(1))
+	})
+	assert.NotPanics(t, func() {
+		r.Key(types.Int, "", 1, "", "testRange.port", types.Min(1))
+	})
+}
+
+func newDurationRangeConfig() types.Config {
+	r := newRegistration("Test Duration Range")
+	r.Key(types.Duration, "", time.Second, "The timeout", "testRange.timeout",
+		types.MinDuration(100*time.Millisecond),
+		types.MaxDuration(time.Minute))
+	Register(r)
+	return New()
+}
+
+func TestDurationRange(t *testing.T) {
+	newConfigDirs("TestDurationRange", t)
+	wipeEnv()
+	TestHook(t)
+
+	c := newDurationRangeConfig()
+	assert.NoError(t, Validate(c))
+
+	for _, v := range []interface{}{
+		100 * time.Millisecond, time.Minute, "100ms", "1m"} {
+		c.Set("testRange.timeout", v)
+		assert.NoError(t, Validate(c), v)
+	}
+
+	c.Set("testRange.timeout", 5*time.Millisecond)
+	err := Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeOutOfRange, verr.Errors[0].Code())
+		assert.Equal(t, "testRange.timeout", verr.Errors[0].Key())
+		assert.EqualError(t, verr.Errors[0],
+			"out_of_range: testRange.timeout: 5ms is below minimum 100ms")
+	}
+
+	c.Set("testRange.timeout", "1m0.001s")
+	err = Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.EqualError(t, verr.Errors[0],
+			"out_of_range: testRange.timeout: 1m0.001s is above maximum 1m0s")
+	}
+}
+
+func TestDurationEnvVar(t *testing.T) {
+	newConfigDirs("TestDurationEnvVar", t)
+	wipeEnv()
+	TestHook(t)
+
+	os.Setenv("TESTRANGE_TIMEOUT", "30s")
+	defer os.Unsetenv("TESTRANGE_TIMEOUT")
+	c := newDurationRangeConfig()
+	assert.NoError(t, Validate(c))
+
+	os.Setenv("TESTRANGE_TIMEOUT", "soon")
+	err := Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeInvalidType, verr.Errors[0].Code())
+		assert.Equal(t, "testRange.timeout", verr.Errors[0].Key())
+	}
+
+	os.Setenv("TESTRANGE_TIMEOUT", "50ms")
+	err = Validate(c)
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		assert.Len(t, verr.Errors, 1)
+		assert.Equal(t, ErrCodeOutOfRange, verr.Errors[0].Code())
+	}
+}
+
+func TestDurationInvalidRegistration(t *testing.T) {
+	TestHook(t)
+
+	r := newRegistration("Test Duration Invalid")
+	assert.Panics(t, func() {
+		r.Key(types.Duration, "", time.Millisecond, "", "testRange.timeout",
+			types.MinDuration(time.Second))
+	})
+	assert.Panics(t, func() {
+		r.Key(types.Duration, "", "2h", "", "testRange.timeout",
+			types.MaxDuration(time.Hour))
+	})
+	assert.Panics(t, func() {
+		r.Key(types.Duration, "", "soon", "", "testRange.timeout",
+			types.MaxDuration(time.Hour))
+	})
+	assert.Panics(t, func() {
+		r.Key(types.Duration, "", time.Second, "", "testRange.timeout",
+			types.MinDuration(time.Hour), types.MaxDuration(time.Minute))
+	})
+	assert.NotPanics(t, func() {
+		r.Key(types.Duration, "", "1s", "", "testRange.timeout",
+			types.MinDuration(time.Second), types.MaxDuration(time.Second))
+	})
+}
@@ -0,0 +1,161 @@
+package gofig
+
+import (
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// ErrReadOnly is the error returned, or the value with which the Set function
+// panics, when attempting to modify a read-only Config instance.
+var ErrReadOnly = goof.New("config is read-only")
+
+// readOnlyConfig is a view of a configuration that may not be modified
+type readOnlyConfig struct {
+	types.Config
+}
+
+// ReadOnly returns a view of the Config instance that may be read but not
+// modified. The Set and SetWithTTL functions panic with ErrReadOnly, and the
+// SetByPath, ReadConfig, ReadConfigFile, ReadConfigFromURL, PollConfigURL,
+// Merge, Transaction, Restore, Reset, and ResetToDefaults functions return
+// ErrReadOnly. The AddConfigPath, SetMergeStrategy, Freeze, Unfreeze, and
+// ClearExpired functions have no effect. The Copy function returns a copy
+// that may be modified.
+func ReadOnly(c types.Config) types.Config {
+	if _, ok := c.(*readOnlyConfig); ok {
+		return c
+	}
+	return &readOnlyConfig{Config: c}
+}
+
+func (c *readOnlyConfig) Parent() types.Config {
+	if p := c.Config.Parent(); p != nil {
+		return ReadOnly(p)
+	}
+	return nil
+}
+
+func (c *readOnlyConfig) Scope(scope interface{}) types.Config {
+	return ReadOnly(c.Config.Scope(scope))
+}
+
+func (c *readOnlyConfig) StrictScope(scope string) types.Config {
+	return ReadOnly(c.Config.StrictScope(scope))
+}
+
+func (c *readOnlyConfig) GetOrFetch(
+	k interface{},
+	fetch func() (interface{}, error),
+	ttl time.Duration) (interface{}, error) {
+	if c.IsSet(k) {
+		return c.Get(k), nil
+	}
+	return nil, ErrReadOnly
+}
+
+func (c *readOnlyConfig) Reload() error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) LoadEnvFile(path string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) Extend() types.Config {
+	return newChildConfig(c)
+}
+
+func (c *readOnlyConfig) Set(k interface{}, v interface{}) {
+	panic(ErrReadOnly)
+}
+
+func (c *readOnlyConfig) SetWithTTL(
+	k interface{}, v interface{}, ttl time.Duration) {
+	panic(ErrReadOnly)
+}
+
+func (c *readOnlyConfig) AddConfigPath(path string) {
+}
+
+func (c *readOnlyConfig) Freeze() {
+}
+
+func (c *readOnlyConfig) Unfreeze() {
+}
+
+func (c *readOnlyConfig) ClearExpired() {
+}
+
+func (c *readOnlyConfig) SetByPath(path string, v interface{}) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) SetConfigVersion(v int) {
+	panic(ErrReadOnly)
+}
+
+func (c *readOnlyConfig) Transaction(fn func(tx types.ConfigTx) error) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) Restore(s types.ConfigSnapshot) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) Reset() error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ResetToDefaults() error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) SetMergeStrategy(s types.MergeStrategy) {
+}
+
+func (c *readOnlyConfig) Merge(other types.Config) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadConfig(in io.Reader) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadConfigFromReader(
+	in io.Reader, format string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadConfigFS(fsys fs.FS, filePath string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadConfigFile(filePath string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadSignedConfigFile(
+	filePath, sigPath string, key []byte) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadCompressedConfigFile(filePath string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) ReadConfigFromURL(
+	url string, opts ...types.HTTPOption) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) PollConfigURL(
+	url string,
+	interval time.Duration,
+	opts ...types.HTTPOption) (<-chan struct{}, error) {
+	return nil, ErrReadOnly
+}
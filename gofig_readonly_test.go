@@ -0,0 +1,64 @@
+package gofig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestReadOnly(t *testing.T) {
+	etcFile, _ := newConfigDirs("TestReadOnly", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testReadOnly.count", 3)
+	c.Set("testReadOnly.enabled", true)
+	c.Set("testReadOnly.hosts", []string{"a", "b"})
+
+	ro := ReadOnly(c)
+	assert.Equal(t, ro, ReadOnly(ro))
+
+	assertString(t, ro, "rexray.host", "tcp://:7979")
+	assert.Equal(t, 3, ro.GetInt("testReadOnly.count"))
+	assert.True(t, ro.GetBool("testReadOnly.enabled"))
+	assert.Equal(t, []string{"a", "b"}, ro.GetStringSlice("testReadOnly.hosts"))
+	assert.Equal(t, 3, ro.Get("testReadOnly.count"))
+	assert.True(t, ro.IsSet("testReadOnly.count"))
+	assert.Equal(t, 3, ro.Scope("testReadOnly").GetInt("count"))
+	assert.Contains(t, ro.AllKeys(), "testreadonly.count")
+
+	assert.Panics(t, func() { ro.Set("testReadOnly.count", 4) })
+	assert.Panics(t, func() { ro.Scope("testReadOnly").Set("count", 4) })
+	assert.Equal(t, ErrReadOnly,
+		ro.ReadConfig(bytes.NewReader(yamlConfig1)))
+	assert.Equal(t, ErrReadOnly, ro.ReadConfigFile(etcFile))
+	assert.Equal(t, ErrReadOnly, ro.Merge(New()))
+	assert.Equal(t, ErrReadOnly, ro.SetByPath("testReadOnly.count", 4))
+	assert.Equal(t, ErrReadOnly,
+		ro.Scope("testReadOnly").SetByPath("count", 4))
+	assert.Equal(t, ErrReadOnly, ro.Reset())
+	assert.Equal(t, ErrReadOnly, ro.ResetToDefaults())
+	assert.Equal(t, 3, c.GetInt("testReadOnly.count"))
+	assertString(t, ro, "rexray.logLevel", "warn")
+
+	cc, err := ro.Copy()
+	assert.NoError(t, err)
+	assert.NotPanics(t, func() { cc.Set("testReadOnly.count", 4) })
+	assert.Equal(t, 4, cc.GetInt("testReadOnly.count"))
+	assert.Equal(t, 3, ro.GetInt("testReadOnly.count"))
+
+	assert.NoError(t, c.ReadConfig(strings.NewReader(`
+testReadOnly:
+  name: a
+`)))
+	ro.SetMergeStrategy(types.FirstWins)
+	assert.NoError(t, c.ReadConfig(strings.NewReader(`
+testReadOnly:
+  name: b
+`)))
+	assertString(t, ro, "testReadOnly.name", "b")
+}
@@ -7,13 +7,16 @@ import (
 
 	"github.com/akutz/gofig/types"
 	"github.com/akutz/goof"
-	log "github.com/sirupsen/logrus"
 )
 
 type configReg struct {
-	name string
-	yaml string
-	keys []types.ConfigRegistrationKey
+	name         string
+	yaml         string
+	profileYAML  map[string]string
+	keys         []types.ConfigRegistrationKey
+	deprecations map[string]string
+	section      string
+	sections     map[string]string
 }
 
 type configRegKey struct {
@@ -24,6 +27,13 @@ type configRegKey struct {
 	keyName    string
 	flagName   string
 	envVarName string
+	aliases    []string
+	allowed    []string
+	delim      string
+	section    string
+	tags       map[string]string
+	min        interface{}
+	max        interface{}
 }
 
 // NewRegistration creates a new registration with the given name.
@@ -32,7 +42,13 @@ func NewRegistration(name string) types.ConfigRegistration {
 }
 
 func newRegistration(name string) *configReg {
-	return &configReg{name: name, keys: []types.ConfigRegistrationKey{}}
+	return &configReg{
+		name:         name,
+		keys:         []types.ConfigRegistrationKey{},
+		profileYAML:  map[string]string{},
+		deprecations: map[string]string{},
+		sections:     map[string]string{},
+	}
 }
 
 func (r *configReg) Name() string {
@@ -53,6 +69,33 @@ func (r *configReg) Keys() <-chan types.ConfigRegistrationKey {
 func (r *configReg) YAML() string     { return r.yaml }
 func (r *configReg) SetYAML(y string) { r.yaml = y }
 
+func (r *configReg) ProfileYAML(profile string) string {
+	return r.profileYAML[profile]
+}
+
+func (r *configReg) SetProfileYAML(profiles map[string]string) {
+	for p, y := range profiles {
+		r.profileYAML[p] = y
+	}
+}
+
+func (r *configReg) Deprecate(k interface{}, message string) {
+	r.deprecations[toString(k)] = message
+}
+
+func (r *configReg) Deprecations() map[string]string {
+	return r.deprecations
+}
+
+func (r *configReg) Section(name, description string) {
+	r.section = name
+	r.sections[name] = description
+}
+
+func (r *configReg) SectionDescription(name string) string {
+	return r.sections[name]
+}
+
 func (r *configReg) Key(
 	keyType types.ConfigKeyTypes,
 	short string,
@@ -60,6 +103,39 @@ func (r *configReg) Key(
 	description string,
 	keys ...interface{}) {
 
+	var (
+		allowed []string
+		delim   string
+		names   []interface{}
+		tags    = map[string]string{}
+		min     interface{}
+		max     interface{}
+	)
+	for _, k := range keys {
+		if d, ok := k.(types.DelimOpt); ok {
+			delim = string(d)
+			continue
+		}
+		if t, ok := k.(types.TagOpt); ok {
+			tags[t.Key] = t.Value
+			continue
+		}
+		if m, ok := k.(types.MinOpt); ok {
+			min = m.Value
+			continue
+		}
+		if m, ok := k.(types.MaxOpt); ok {
+			max = m.Value
+			continue
+		}
+		if av, ok := k.([]string); ok && keyType == types.StringEnum {
+			allowed = append(allowed, av...)
+			continue
+		}
+		names = append(names, k)
+	}
+	keys = names
+
 	lk := len(keys)
 	if lk == 0 {
 		panic(goof.New("keys is empty"))
@@ -71,11 +147,22 @@ func (r *configReg) Key(
 		desc:    description,
 		defVal:  defVal,
 		keyName: toString(keys[0]),
+		allowed: allowed,
+		delim:   delim,
+		section: r.section,
+		tags:    tags,
+		min:     min,
+		max:     max,
 	}
+	checkKeyRange(rk)
 
-	if keyType == types.SecureString {
+	if _, ok := tags[SensitiveTag]; ok || keyType == types.SecureString {
 		secureKey(rk)
 	}
+	if keyType == types.StringEnum {
+		enumKey(rk)
+	}
+	registerKeyName(rk.keyName)
 
 	if lk < 2 {
 		kp := strings.Split(rk.keyName, ".")
@@ -110,6 +197,14 @@ func (r *configReg) Key(
 		rk.envVarName = toString(keys[2])
 	}
 
+	if lk > 3 {
+		for _, k := range keys[3:] {
+			ak := toString(k)
+			rk.aliases = append(rk.aliases, ak)
+			Alias(rk.keyName, ak)
+		}
+	}
+
 	r.keys = append(r.keys, rk)
 }
 
@@ -120,13 +215,27 @@ func (k *configRegKey) Description() string           { return k.desc }
 func (k *configRegKey) KeyName() string               { return k.keyName }
 func (k *configRegKey) FlagName() string              { return k.flagName }
 func (k *configRegKey) EnvVarName() string            { return k.envVarName }
+func (k *configRegKey) Aliases() []string             { return k.aliases }
+func (k *configRegKey) AllowedValues() []string       { return k.allowed }
+func (k *configRegKey) Section() string               { return k.section }
+func (k *configRegKey) Delim() string                 { return k.delim }
+func (k *configRegKey) Min() interface{}              { return k.min }
+func (k *configRegKey) Max() interface{}              { return k.max }
+
+func (k *configRegKey) Tags() map[string]string {
+	tags := map[string]string{}
+	for n, v := range k.tags {
+		tags[n] = v
+	}
+	return tags
+}
 
 func secureKey(k *configRegKey) {
 	secureKeysRWL.Lock()
 	defer secureKeysRWL.Unlock()
 	kn := strings.ToLower(k.keyName)
 	if LogSecureKey {
-		log.WithField("keyName", kn).Debug("securing key")
+		logger().Debug("securing key", "keyName", kn)
 	}
 	secureKeys[kn] = k
 }
@@ -0,0 +1,47 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestClearRegistrations(t *testing.T) {
+	newConfigDirs("TestClearRegistrations", t)
+	wipeEnv()
+
+	r := newRegistration("Test Clear")
+	r.Key(types.SecureString, "", "", "", "testClear.password")
+
+	t.Run("Clear", func(t *testing.T) {
+		TestHook(t)
+		ClearRegistrations()
+		assert.Empty(t, RegisteredKeys())
+		assert.False(t, isSecureKey("testClear.password"))
+
+		c := New()
+		assert.False(t, c.IsSet("rexray.host"))
+	})
+
+	assert.Contains(t, RegisteredKeys(), "rexray.host")
+	assert.True(t, isSecureKey("testClear.password"))
+	assertString(t, New(), "rexray.host", "tcp://:7979")
+}
+
+func TestUnregisterByName(t *testing.T) {
+	newConfigDirs("TestUnregisterByName", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Unregister")
+	r.Key(types.String, "", "", "", "testUnregister.userName")
+	Register(r)
+	assert.Contains(t, RegisteredKeys(), "testUnregister.userName")
+
+	assert.True(t, UnregisterByName("Test Unregister"))
+	assert.False(t, UnregisterByName("Test Unregister"))
+	assert.NotContains(t, RegisteredKeys(), "testUnregister.userName")
+	assert.Contains(t, RegisteredKeys(), "rexray.host")
+}
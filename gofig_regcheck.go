@@ -0,0 +1,147 @@
+package gofig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// ValidateRegistration parses the registration's YAML with ValidateYAMLString
+// and returns an error for each of the registration's keys that does not have
+// a value in the YAML, or whose value in the YAML or whose default value does
+// not match the key's type. A nil slice is returned if the registration is
+// valid.
+func ValidateRegistration(r types.ConfigRegistration) []error {
+	m, err := ValidateYAMLString(r.YAML())
+	if err != nil {
+		return []error{NewConfigError(ErrCodeParseFailed, "", err)}
+	}
+
+	keys := []types.ConfigRegistrationKey{}
+	for k := range r.Keys() {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].KeyName() < keys[j].KeyName()
+	})
+
+	var errs []error
+	for _, k := range keys {
+		if !matchesKeyType(k.KeyType(), k.DefaultValue()) {
+			errs = append(errs, NewConfigError(
+				ErrCodeInvalidType, k.KeyName(), goof.WithFields(map[string]interface{}{
+					"type":  k.KeyType().String(),
+					"value": k.DefaultValue(),
+				}, "invalid default value")))
+		}
+		v, ok := lookupYAMLPath(m, k.KeyName())
+		if !ok {
+			errs = append(errs,
+				NewConfigError(ErrCodeMissingDefault, k.KeyName(), nil))
+			continue
+		}
+		if !matchesKeyType(k.KeyType(), v) {
+			errs = append(errs, NewConfigError(
+				ErrCodeInvalidType, k.KeyName(), goof.WithFields(map[string]interface{}{
+					"type":  k.KeyType().String(),
+					"value": v,
+				}, "invalid yaml value")))
+		}
+	}
+	return errs
+}
+
+// RegisterAndValidate registers the registration and fails the test if the
+// registration is not valid according to ValidateRegistration. The t
+// argument is typically a *testing.T.
+func RegisterAndValidate(t interface {
+	Fatalf(format string, args ...interface{})
+}, r types.ConfigRegistration) {
+	Register(r)
+	errs := ValidateRegistration(r)
+	if len(errs) == 0 {
+		return
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	t.Fatalf("invalid registration %q:\n\t%s",
+		r.Name(), strings.Join(msgs, "\n\t"))
+}
+
+// lookupYAMLPath returns the value at the key's dot-notation path in the
+// parsed YAML. The path's elements are matched without regard to case.
+func lookupYAMLPath(
+	m map[interface{}]interface{}, k string) (interface{}, bool) {
+	var v interface{} = m
+	for _, p := range strings.Split(strings.ToLower(k), ".") {
+		vm, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+		found := false
+		for mk, mv := range vm {
+			if strings.ToLower(fmt.Sprintf("%v", mk)) == p {
+				v, found = mv, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// matchesKeyType returns a flag indicating whether or not the value may be
+// the value of a key of the specified type. The string key types may have a
+// list of strings as their value, and a nil value matches every type.
+func matchesKeyType(kt types.ConfigKeyTypes, v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch kt {
+	case types.String, types.SecureString, types.StringEnum:
+		switch tv := v.(type) {
+		case string, []string:
+			return true
+		case []interface{}:
+			for _, e := range tv {
+				if _, ok := e.(string); !ok {
+					return false
+				}
+			}
+			return true
+		}
+	case types.Int:
+		switch v.(type) {
+		case int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64:
+			return true
+		}
+	case types.Bool:
+		_, ok := v.(bool)
+		return ok
+	case types.Float64:
+		switch v.(type) {
+		case float32, float64, int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64:
+			return true
+		}
+	case types.Duration:
+		switch tv := v.(type) {
+		case time.Duration:
+			return true
+		case string:
+			_, err := time.ParseDuration(tv)
+			return err == nil
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package gofig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+type fatalRecorder struct {
+	msg string
+}
+
+func (r *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func TestValidateRegistration(t *testing.T) {
+	TestHook(t)
+
+	r := newRegistration("Test Reg Check")
+	r.SetYAML(`testRegCheck:
+  host: localhost
+  Port: 8080
+  tls: true
+  drivers:
+  - linux
+`)
+	r.Key(types.String, "", "localhost", "", "testRegCheck.host")
+	r.Key(types.Int, "", 8080, "", "testRegCheck.port")
+	r.Key(types.Bool, "", true, "", "testRegCheck.tls")
+	r.Key(types.String, "", []string{"linux"}, "", "testRegCheck.drivers")
+	assert.Empty(t, ValidateRegistration(r))
+	RegisterAndValidate(t, r)
+	assert.Contains(t, RegisteredKeys(), "testRegCheck.host")
+}
+
+func TestValidateRegistrationErrors(t *testing.T) {
+	TestHook(t)
+
+	r := newRegistration("Test Reg Check Errors")
+	r.SetYAML(`testRegCheck:
+  host: localhost
+  port: "8080"
+  tls:
+      enabled: true
+`)
+	r.Key(types.String, "", "localhost", "", "testRegCheck.host")
+	r.Key(types.Int, "", 8080, "", "testRegCheck.port")
+	r.Key(types.Bool, "", "yes", "", "testRegCheck.tls")
+	r.Key(types.String, "", "", "", "testRegCheck.user")
+
+	errs := ValidateRegistration(r)
+	codes := map[string]string{}
+	for _, err := range errs {
+		cerr, ok := err.(ConfigError)
+		assert.True(t, ok)
+		codes[cerr.Key()] += cerr.Code() + ","
+	}
+	assert.Len(t, errs, 4)
+	assert.Equal(t, map[string]string{
+		"testRegCheck.port": "invalid_type,",
+		"testRegCheck.tls":  "invalid_type,invalid_type,",
+		"testRegCheck.user": "missing_default,",
+	}, codes)
+
+	fr := &fatalRecorder{}
+	RegisterAndValidate(fr, r)
+	assert.Contains(t, fr.msg, "Test Reg Check Errors")
+	assert.Contains(t, fr.msg, "missing_default: testRegCheck.user")
+
+	r.SetYAML("testRegCheck:\n  host: [\n")
+	errs = ValidateRegistration(r)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "parse_failed")
+}
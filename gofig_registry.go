@@ -0,0 +1,107 @@
+package gofig
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+	"weak"
+
+	"github.com/akutz/gofig/types"
+)
+
+var (
+	namedConfigs  sync.Map
+	defaultConfig types.Config
+	defaultRWL    = &sync.RWMutex{}
+)
+
+// configRef is a reference to a named config. A config that is a pointer is
+// referenced weakly so that the reference does not prevent the config from
+// being garbage collected.
+type configRef struct {
+	typ    reflect.Type
+	ptr    weak.Pointer[byte]
+	config types.Config
+}
+
+func newConfigRef(c types.Config) *configRef {
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return &configRef{config: c}
+	}
+	return &configRef{
+		typ: v.Type(),
+		ptr: weak.Make((*byte)(v.UnsafePointer())),
+	}
+}
+
+// value returns the referenced config or false if the config was garbage
+// collected.
+func (r *configRef) value() (types.Config, bool) {
+	if r.typ == nil {
+		return r.config, true
+	}
+	p := r.ptr.Value()
+	if p == nil {
+		return nil, false
+	}
+	c, ok := reflect.NewAt(
+		r.typ.Elem(), unsafe.Pointer(p)).Interface().(types.Config)
+	return c, ok
+}
+
+// RegisterConfig registers the config with the specified name so that other
+// components may find it with LookupConfig. A config registered with a name
+// that is already registered replaces the registered config. The registry
+// does not prevent the config from being garbage collected, so the component
+// that registers a config must retain a reference to it for as long as it
+// should be found.
+func RegisterConfig(name string, c types.Config) {
+	if c == nil {
+		namedConfigs.Delete(name)
+		return
+	}
+	namedConfigs.Store(name, newConfigRef(c))
+}
+
+// LookupConfig returns the config registered with the specified name. A false
+// value is returned if no config is registered with the name or if the config
+// was garbage collected.
+func LookupConfig(name string) (types.Config, bool) {
+	v, ok := namedConfigs.Load(name)
+	if !ok {
+		return nil, false
+	}
+	c, ok := v.(*configRef).value()
+	if !ok {
+		namedConfigs.CompareAndDelete(name, v)
+	}
+	return c, ok
+}
+
+// Default returns the default config. A new config is created with New the
+// first time Default is called if no config was set with SetDefault. Unlike
+// the configs registered with RegisterConfig, the default config is not
+// garbage collected.
+func Default() types.Config {
+	defaultRWL.RLock()
+	c := defaultConfig
+	defaultRWL.RUnlock()
+	if c != nil {
+		return c
+	}
+	defaultRWL.Lock()
+	defer defaultRWL.Unlock()
+	if defaultConfig == nil {
+		defaultConfig = New()
+	}
+	return defaultConfig
+}
+
+// SetDefault sets the config returned by Default. A nil config causes Default
+// to create a new config the next time it is called.
+func SetDefault(c types.Config) {
+	defaultRWL.Lock()
+	defer defaultRWL.Unlock()
+	defaultConfig = c
+}
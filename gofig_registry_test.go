@@ -0,0 +1,104 @@
+package gofig
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterConfig(t *testing.T) {
+	wipeEnv()
+	c := newConfigWithOptions(false, false, "config", "yml")
+	c.Set("testRegistry.name", "mesh")
+	RegisterConfig("testRegistry", c)
+	defer RegisterConfig("testRegistry", nil)
+
+	lc, ok := LookupConfig("testRegistry")
+	assert.True(t, ok)
+	assert.Equal(t, "mesh", lc.GetString("testRegistry.name"))
+	assert.True(t, lc == c)
+
+	sc := c.Scope("testRegistry")
+	RegisterConfig("testRegistryScope", sc)
+	defer RegisterConfig("testRegistryScope", nil)
+	lc, ok = LookupConfig("testRegistryScope")
+	assert.True(t, ok)
+	assert.Equal(t, "mesh", lc.GetString("name"))
+
+	_, ok = LookupConfig("testRegistryUnknown")
+	assert.False(t, ok)
+
+	RegisterConfig("testRegistry", nil)
+	_, ok = LookupConfig("testRegistry")
+	assert.False(t, ok)
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(sc)
+}
+
+func TestRegisterConfigConcurrent(t *testing.T) {
+	wipeEnv()
+	configs := []*config{}
+	for x := 0; x < 10; x++ {
+		configs = append(configs,
+			newConfigWithOptions(false, false, "config", "yml"))
+	}
+
+	wg := &sync.WaitGroup{}
+	for x := 0; x < 100; x++ {
+		wg.Add(2)
+		name := fmt.Sprintf("testRegistry%d", x%10)
+		c := configs[x%10]
+		go func() {
+			defer wg.Done()
+			RegisterConfig(name, c)
+		}()
+		go func() {
+			defer wg.Done()
+			if lc, ok := LookupConfig(name); ok {
+				assert.True(t, lc == c)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for x, c := range configs {
+		name := fmt.Sprintf("testRegistry%d", x)
+		lc, ok := LookupConfig(name)
+		assert.True(t, ok)
+		assert.True(t, lc == c)
+		RegisterConfig(name, nil)
+	}
+}
+
+func TestRegisterConfigGC(t *testing.T) {
+	wipeEnv()
+	func() {
+		RegisterConfig("testRegistryGC",
+			newConfigWithOptions(false, false, "config", "yml"))
+	}()
+	collected := false
+	for x := 0; x < 10 && !collected; x++ {
+		runtime.GC()
+		_, ok := LookupConfig("testRegistryGC")
+		collected = !ok
+	}
+	assert.True(t, collected)
+}
+
+func TestDefault(t *testing.T) {
+	newConfigDirs("TestDefault", t)
+	wipeEnv()
+	defer SetDefault(nil)
+
+	SetDefault(nil)
+	c := Default()
+	assert.NotNil(t, c)
+	assert.True(t, c == Default())
+
+	nc := newConfigWithOptions(false, false, "config", "yml")
+	SetDefault(nc)
+	assert.True(t, Default() == nc)
+}
@@ -0,0 +1,10 @@
+package gofig
+
+func (c *config) Reload() error {
+	n, err := c.reloadFiles()
+	if err != nil {
+		return err
+	}
+	logger().Debug("config reloaded", "files", n)
+	return nil
+}
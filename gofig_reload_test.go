@@ -0,0 +1,57 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReload(t *testing.T) {
+	_, usrFile := newConfigDirs("TestReload", t)
+	wipeEnv()
+
+	writeFile := func(filePath, s string) {
+		if err := ioutil.WriteFile(filePath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	extraFile := filepath.Join(filepath.Dir(usrFile), "extra.yml")
+	writeFile(usrFile, "testReload:\n  host: host1\n  port: 1\n")
+	writeFile(extraFile, "testReload:\n  port: 2\n")
+
+	c := New()
+	assert.NoError(t, c.ReadConfigFile(extraFile))
+	assert.Equal(t, "host1", c.GetString("testReload.host"))
+	assert.Equal(t, 2, c.GetInt("testReload.port"))
+
+	changes := make(chan interface{}, 1)
+	h := c.OnChange("testReload.host", func(oldVal, newVal interface{}) {
+		changes <- newVal
+	})
+	defer h.Stop()
+
+	writeFile(usrFile, "testReload:\n  host: host2\n  port: 3\n")
+	assert.NoError(t, c.Reload())
+	assert.Equal(t, "host2", c.GetString("testReload.host"))
+	assert.Equal(t, 2, c.GetInt("testReload.port"))
+	select {
+	case v := <-changes:
+		assert.Equal(t, "host2", v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change callback")
+	}
+
+	assert.NoError(t, os.Remove(extraFile))
+	writeFile(usrFile, "testReload:\n  host: host3\n")
+	assert.NoError(t, c.Reload())
+	assert.Equal(t, "host3", c.GetString("testReload.host"))
+	assert.Equal(t, 2, c.GetInt("testReload.port"))
+
+	writeFile(usrFile, "testReload: [")
+	assert.Error(t, c.Reload())
+	assert.Equal(t, ErrReadOnly, ReadOnly(c).Reload())
+}
@@ -0,0 +1,115 @@
+package gofig
+
+import (
+	"bytes"
+
+	"github.com/akutz/gofig/types"
+)
+
+// configRead is a configuration stream that was read into a config instance.
+// The file path is set if the stream was read from a file, and the URL and
+// HTTP options are set if the stream was read from a URL.
+type configRead struct {
+	buf      []byte
+	format   string
+	filePath string
+	url      string
+	httpOpts []types.HTTPOption
+}
+
+func (c *config) Reset() error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	return c.reset(true)
+}
+
+func (c *config) ResetToDefaults() error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	return c.reset(false)
+}
+
+// reset replaces the config's backend with one initialized from the
+// registrations. If keepReads is true then the config streams previously read
+// into the config instance are read into the new backend as well.
+func (c *config) reset(keepReads bool) error {
+	var reads []configRead
+	if keepReads {
+		reads = c.reads
+	}
+	if err := c.resetWithReads(reads); err != nil {
+		return err
+	}
+	if !keepReads {
+		c.configFilePath = ""
+	}
+	return nil
+}
+
+// resetWithReads replaces the config's backend with one initialized from the
+// registrations and the specified config streams.
+func (c *config) resetWithReads(reads []configRead) error {
+	if err := c.resetWithOverrides(reads, nil); err != nil {
+		return err
+	}
+	c.clearTTLs()
+	return nil
+}
+
+// resetWithOverrides replaces the config's backend with one initialized from
+// the registrations, the specified config streams, and the specified override
+// values.
+func (c *config) resetWithOverrides(
+	reads []configRead, overrides map[string]interface{}) error {
+
+	nc := newConfigObj()
+	nc.configType = c.configType
+	nc.profile = c.profile
+	nc.envKeyPrefix = c.envKeyPrefix
+	nc.keyTransformer = c.keyTransformer
+	nc.flagSets = c.flagSets
+	nc.registrations = c.registrations
+
+	nc.b = c.b.New()
+
+	if err := nc.processRegistrations(); err != nil {
+		return err
+	}
+
+	for _, r := range reads {
+		if err := nc.readConfig(bytes.NewReader(r.buf), r.format); err != nil {
+			return err
+		}
+	}
+	for k, v := range overrides {
+		nc.b.Set(k, v)
+	}
+
+	var before map[string]interface{}
+	if c.hasChangeCallbacks() {
+		before = c.flatSettingsLocked()
+	}
+
+	c.b = nc.b
+	c.publishSnapshot()
+	c.overrideKeys = map[string]bool{}
+	for k := range overrides {
+		c.overrideKeys[k] = true
+	}
+	c.reads = reads
+	c.readKeys = map[string]bool{}
+	for _, r := range reads {
+		c.addReadKeys(r.buf, r.format)
+	}
+
+	if before != nil {
+		c.notifyChanges(before, c.flatSettingsLocked())
+	}
+	return nil
+}
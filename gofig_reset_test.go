@@ -0,0 +1,88 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReset(t *testing.T) {
+	newConfigDirs("TestReset", t)
+	wipeEnv()
+
+	c := New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlConfig1)))
+	c.Set("rexray.logLevel", "debug")
+	c.Set("rexray.host", "tcp://:7980")
+	c.Set("testReset.count", 3)
+
+	assert.NoError(t, c.Reset())
+	assertString(t, c, "rexray.logLevel", "error")
+	assertString(t, c, "rexray.host", "tcp://:7979")
+	assertString(t, c, "mockprovider.userName", "admin")
+	assert.False(t, c.IsSet("testReset.count"))
+}
+
+func TestResetToDefaults(t *testing.T) {
+	newConfigDirs("TestResetToDefaults", t)
+	wipeEnv()
+
+	c := New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlConfig1)))
+	c.Set("rexray.logLevel", "debug")
+	c.Set("rexray.host", "tcp://:7980")
+	c.Set("rexray.storageDrivers", []string{"ec2"})
+	c.Set("testReset.count", 3)
+
+	assert.NoError(t, c.ResetToDefaults())
+	assertString(t, c, "rexray.logLevel", "warn")
+	assertString(t, c, "rexray.host", "tcp://:7979")
+	assert.Equal(t,
+		[]string{"libstorage"}, c.GetStringSlice("rexray.storageDrivers"))
+	assert.False(t, c.IsSet("testReset.count"))
+	assert.False(t, c.IsSet("mockprovider.userName"))
+
+	c.Set("rexray.logLevel", "debug")
+	c.Freeze()
+	assert.Equal(t, ErrFrozen, c.Reset())
+	assert.Equal(t, ErrFrozen, c.ResetToDefaults())
+	assertString(t, c, "rexray.logLevel", "debug")
+}
+
+func TestResetKeepsLoadedValues(t *testing.T) {
+	newConfigDirs("TestResetKeepsLoadedValues", t)
+	wipeEnv()
+
+	c, err := FromJSON(`{"a":{"b":"c"}}`)
+	assert.NoError(t, err)
+	c.Set("a.b", "d")
+	assert.NoError(t, c.Reset())
+	assertString(t, c, "a.b", "c")
+
+	c, err = FromYAML("a:\n  b: c\n")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Reset())
+	assertString(t, c, "a.b", "c")
+
+	c, err = FromTOML("[a]\nb = \"c\"\n")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Reset())
+	assertString(t, c, "a.b", "c")
+
+	buf, err := c.ToProto()
+	assert.NoError(t, err)
+	c, err = FromProto(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Reset())
+	assertString(t, c, "a.b", "c")
+
+	c = New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlConfig1)))
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	cc.Set("rexray.logLevel", "debug")
+	assert.NoError(t, cc.Reset())
+	assertString(t, cc, "rexray.logLevel", "error")
+	assertString(t, cc, "mockprovider.userName", "admin")
+}
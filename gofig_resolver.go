@@ -0,0 +1,124 @@
+package gofig
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akutz/gofig/types"
+)
+
+// ResolverCacheTTL is the length of time a value returned by a resolver is
+// cached before the resolver is asked for the value again. A value less than
+// or equal to zero disables caching.
+var ResolverCacheTTL = time.Minute
+
+var (
+	resolvers    = map[string]*resolverReg{}
+	resolversRWL = &sync.RWMutex{}
+)
+
+// resolverReg is a resolver registered for a key prefix and the cache of the
+// values it has resolved.
+type resolverReg struct {
+	r        types.Resolver
+	cache    map[string]*resolvedValue
+	cacheRWL *sync.RWMutex
+}
+
+// resolvedValue is a cached value returned by a resolver.
+type resolvedValue struct {
+	val     string
+	expires time.Time
+}
+
+// RegisterResolver registers a resolver for the keys whose names start with
+// the specified prefix. The GetString function returns the value the resolver
+// returns for such a key instead of the key's configured value. If more than
+// one prefix matches a key then the resolver registered for the longest prefix
+// is used. A nil resolver removes the resolver registered for the prefix.
+//
+// The values returned by resolvers are never stored in a config instance and
+// therefore are not included when the config is marshaled.
+func RegisterResolver(prefix string, r types.Resolver) {
+	lp := strings.ToLower(prefix)
+	resolversRWL.Lock()
+	defer resolversRWL.Unlock()
+	if r == nil {
+		delete(resolvers, lp)
+		return
+	}
+	resolvers[lp] = &resolverReg{
+		r:        r,
+		cache:    map[string]*resolvedValue{},
+		cacheRWL: &sync.RWMutex{},
+	}
+}
+
+// getResolver returns the resolver registered for the longest prefix that
+// matches the key.
+func getResolver(k string) (*resolverReg, bool) {
+	lk := strings.ToLower(k)
+	resolversRWL.RLock()
+	defer resolversRWL.RUnlock()
+	var (
+		match string
+		rr    *resolverReg
+	)
+	for p, r := range resolvers {
+		if strings.HasPrefix(lk, p) && (rr == nil || len(p) > len(match)) {
+			match = p
+			rr = r
+		}
+	}
+	return rr, rr != nil
+}
+
+// isResolvedKey returns a flag indicating whether or not a resolver is
+// registered for the key.
+func isResolvedKey(k string) bool {
+	_, ok := getResolver(k)
+	return ok
+}
+
+// resolveValue returns the value of the key from the resolver registered for
+// the key's prefix. The second return value is false if no resolver is
+// registered for the key or the resolver returns an error, in which case the
+// key's configured value should be used.
+func resolveValue(k string) (string, bool) {
+	rr, ok := getResolver(k)
+	if !ok {
+		return "", false
+	}
+	v, err := rr.resolve(k)
+	if err != nil {
+		logger().Error("error resolving key", "key", k, "error", err)
+		return "", false
+	}
+	return v, true
+}
+
+// resolve returns the cached value of the key if it has not expired,
+// otherwise the value is requested from the resolver and cached.
+func (rr *resolverReg) resolve(k string) (string, error) {
+	lk := strings.ToLower(k)
+	now := time.Now()
+
+	rr.cacheRWL.RLock()
+	rv, ok := rr.cache[lk]
+	rr.cacheRWL.RUnlock()
+	if ok && now.Before(rv.expires) {
+		return rv.val, nil
+	}
+
+	v, err := rr.r.Resolve(k)
+	if err != nil {
+		return "", err
+	}
+	if ttl := ResolverCacheTTL; ttl > 0 {
+		rr.cacheRWL.Lock()
+		rr.cache[lk] = &resolvedValue{val: v, expires: now.Add(ttl)}
+		rr.cacheRWL.Unlock()
+	}
+	return v, nil
+}
@@ -0,0 +1,78 @@
+package gofig
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akutz/goof"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+// mockResolver is a resolver that returns values from a map and counts the
+// number of times it is called.
+type mockResolver struct {
+	vals  map[string]string
+	calls int32
+}
+
+func (r *mockResolver) Resolve(key string) (string, error) {
+	atomic.AddInt32(&r.calls, 1)
+	v, ok := r.vals[key]
+	if !ok {
+		return "", goof.WithField("key", key, "key not found")
+	}
+	return v, nil
+}
+
+func TestRegisterResolver(t *testing.T) {
+	newConfigDirs("TestRegisterResolver", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Resolver")
+	r.Key(types.SecureString, "", "", "", "testResolver.password")
+	r.Key(types.SecureString, "", "", "", "testResolver.db.password")
+	r.Key(types.String, "", "", "", "testResolver.missing")
+	Register(r)
+
+	mr := &mockResolver{vals: map[string]string{
+		"testResolver.password": "p1",
+	}}
+	dbr := &mockResolver{vals: map[string]string{
+		"testResolver.db.password": "p2",
+	}}
+	RegisterResolver("testResolver.", mr)
+	RegisterResolver("testResolver.db.", dbr)
+	defer RegisterResolver("testResolver.", nil)
+	defer RegisterResolver("testResolver.db.", nil)
+
+	c := New()
+	c.Set("testResolver.missing", "configured")
+
+	assert.Equal(t, "p1", c.GetString("testResolver.password"))
+	assert.Equal(t, "p1", c.Scope("testResolver").GetString("password"))
+	assert.Equal(t, "p2", c.GetString("testResolver.db.password"))
+	assert.Equal(t, "configured", c.GetString("testResolver.missing"))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&mr.calls))
+
+	jsonStr, err := c.ToJSON()
+	assert.NoError(t, err)
+	assert.NotContains(t, jsonStr, "p1")
+	assert.NotContains(t, jsonStr, "p2")
+
+	ttl := ResolverCacheTTL
+	defer func() { ResolverCacheTTL = ttl }()
+
+	ResolverCacheTTL = time.Millisecond
+	RegisterResolver("testResolver.", mr)
+	assert.Equal(t, "p1", c.GetString("testResolver.password"))
+	time.Sleep(5 * time.Millisecond)
+	mr.vals["testResolver.password"] = "p3"
+	assert.Equal(t, "p3", c.GetString("testResolver.password"))
+
+	RegisterResolver("testResolver.", nil)
+	assert.Equal(t, "", c.GetString("testResolver.password"))
+}
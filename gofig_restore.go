@@ -0,0 +1,32 @@
+package gofig
+
+import (
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) Snapshot() types.ConfigSnapshot {
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	return types.NewConfigSnapshot(c.b.AllSettings())
+}
+func (c *scopedConfig) Snapshot() types.ConfigSnapshot {
+	return c.Config.Snapshot()
+}
+
+func (c *config) Restore(s types.ConfigSnapshot) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	buf, err := yaml.Marshal(s.Settings())
+	if err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	return c.resetWithReads([]configRead{{buf: buf, format: "yml"}})
+}
+func (c *scopedConfig) Restore(s types.ConfigSnapshot) error {
+	return c.Config.Restore(s)
+}
@@ -0,0 +1,59 @@
+package gofig
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	newConfigDirs("TestSnapshotRestore", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testRestore.host", "host0")
+	c.Set("testRestore.port", 0)
+	s := c.Snapshot()
+
+	c.Set("testRestore.host", "host1")
+	c.Set("testRestore.added", true)
+	assert.NoError(t, c.ReadConfig(strings.NewReader(`
+testRestore:
+  timeout: 30
+`)))
+	assert.Equal(t, 30, c.GetInt("testRestore.timeout"))
+
+	assert.NoError(t, c.Restore(s))
+	assert.Equal(t, "host0", c.GetString("testRestore.host"))
+	assert.Equal(t, 0, c.GetInt("testRestore.port"))
+	assert.False(t, c.IsSet("testRestore.added"))
+	assert.False(t, c.IsSet("testRestore.timeout"))
+	assert.Equal(t, "tcp://:7979", c.GetString("rexray.host"))
+
+	os.Setenv("REXRAY_HOST", "tcp://:8080")
+	defer os.Unsetenv("REXRAY_HOST")
+	assert.Equal(t, "tcp://:8080", c.GetString("rexray.host"))
+
+	c.Freeze()
+	assert.Equal(t, ErrFrozen, c.Restore(s))
+	c.Unfreeze()
+	assert.Equal(t, ErrReadOnly, ReadOnly(c).Restore(s))
+}
+
+func TestSnapshotRestoreDefer(t *testing.T) {
+	newConfigDirs("TestSnapshotRestoreDefer", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testRestore.host", "host0")
+
+	func() {
+		defer c.Restore(c.Snapshot())
+		c.Scope("testRestore").Set("host", "host1")
+		assert.Equal(t, "host1", c.GetString("testRestore.host"))
+	}()
+
+	assert.Equal(t, "host0", c.GetString("testRestore.host"))
+}
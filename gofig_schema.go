@@ -0,0 +1,114 @@
+package gofig
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/akutz/goof"
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+// jsonSchemaDraft07 is the URI of the JSON Schema draft-07 meta-schema.
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// GenerateJSONSchema returns a JSON Schema (draft-07) document that describes
+// the keys of the registrations. The keys are nested according to their
+// names, so the key "rexray.host" is the property "host" of the object
+// property "rexray".
+func GenerateJSONSchema(regs []types.ConfigRegistration) ([]byte, error) {
+	root := newJSONSchemaObject()
+	root["$schema"] = jsonSchemaDraft07
+
+	for _, r := range regs {
+		for k := range r.Keys() {
+			if err := addJSONSchemaKey(root, k); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// newJSONSchemaObject returns a JSON Schema object with no properties.
+func newJSONSchemaObject() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// addJSONSchemaKey adds the key to the JSON Schema object as a property,
+// creating the object properties for the key's parent path segments as
+// needed.
+func addJSONSchemaKey(
+	root map[string]interface{}, k types.ConfigRegistrationKey) error {
+
+	segs := strings.Split(k.KeyName(), ".")
+	obj := root
+	for _, s := range segs[:len(segs)-1] {
+		props := obj["properties"].(map[string]interface{})
+		p, ok := props[s]
+		if !ok {
+			p = newJSONSchemaObject()
+			props[s] = p
+		}
+		po := p.(map[string]interface{})
+		if _, ok := po["properties"]; !ok {
+			return goof.WithField(
+				"key", k.KeyName(), "key conflicts with another key")
+		}
+		obj = po
+	}
+
+	props := obj["properties"].(map[string]interface{})
+	ls := segs[len(segs)-1]
+	if _, ok := props[ls]; ok {
+		return goof.WithField(
+			"key", k.KeyName(), "key conflicts with another key")
+	}
+	props[ls] = jsonSchemaProperty(k)
+	return nil
+}
+
+// jsonSchemaProperty returns the JSON Schema property that describes the key.
+func jsonSchemaProperty(k types.ConfigRegistrationKey) map[string]interface{} {
+	p := map[string]interface{}{}
+	switch k.KeyType() {
+	case types.Int:
+		p["type"] = "integer"
+	case types.Bool:
+		p["type"] = "boolean"
+	case types.Float64:
+		p["type"] = "number"
+	default:
+		p["type"] = "string"
+	}
+	if d := k.Description(); d != "" {
+		p["description"] = d
+	}
+	if dv := k.DefaultValue(); dv != nil {
+		p["default"] = dv
+	}
+	switch k.KeyType() {
+	case types.SecureString:
+		p["format"] = "password"
+	case types.StringEnum:
+		p["enum"] = k.AllowedValues()
+	case types.Duration:
+		// durations are described as strings so there is no numeric range
+		if dv, ok := p["default"]; ok {
+			p["default"] = cast.ToDuration(dv).String()
+		}
+		return p
+	}
+	if min := k.Min(); min != nil {
+		p["minimum"] = min
+	}
+	if max := k.Max(); max != nil {
+		p["maximum"] = max
+	}
+	return p
+}
@@ -0,0 +1,76 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/akutz/goof"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	TestHook(t)
+
+	r := newRegistration("Test Schema")
+	r.Key(types.String, "", "localhost", "The host", "testSchema.host")
+	r.Key(types.Int, "", 8080, "The port", "testSchema.port")
+	r.Key(types.Bool, "", false, "", "testSchema.tls.enabled")
+	r.Key(types.SecureString, "", "", "The password", "testSchema.password")
+	r.Key(types.StringEnum, "", "info", "The log level",
+		"testSchema.logLevel", []string{"debug", "info"})
+
+	buf, err := GenerateJSONSchema([]types.ConfigRegistration{r})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "testSchema": {
+      "type": "object",
+      "properties": {
+        "host": {
+          "type": "string",
+          "description": "The host",
+          "default": "localhost"
+        },
+        "port": {
+          "type": "integer",
+          "description": "The port",
+          "default": 8080
+        },
+        "tls": {
+          "type": "object",
+          "properties": {
+            "enabled": {
+              "type": "boolean",
+              "default": false
+            }
+          }
+        },
+        "password": {
+          "type": "string",
+          "description": "The password",
+          "default": "",
+          "format": "password"
+        },
+        "logLevel": {
+          "type": "string",
+          "description": "The log level",
+          "default": "info",
+          "enum": ["debug", "info"]
+        }
+      }
+    }
+  }
+}`, string(buf))
+
+	r = newRegistration("Test Schema Conflict")
+	r.Key(types.String, "", "", "", "testSchema.host")
+	r.Key(types.String, "", "", "", "testSchema.host.name")
+	_, err = GenerateJSONSchema([]types.ConfigRegistration{r})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "key conflicts with another key")
+	assert.Equal(t,
+		"testSchema.host.name", err.(*goof.Goof).Fields()["key"])
+}
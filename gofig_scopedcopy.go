@@ -0,0 +1,24 @@
+package gofig
+
+import (
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) ScopedCopy() (types.Config, error) {
+	return c.Copy()
+}
+func (c *scopedConfig) ScopedCopy() (types.Config, error) {
+	sc := newSubConfig(c.Config, c.scope)
+	// the copy is detached from this config, but it keeps the qualified name
+	// of its scope so the secure keys below the scope are still redacted
+	sc.keyPrefix = qualifiedConfigKey(c.Config, c.scope)
+	sc.subParent = nil
+	sc.subPrefix = ""
+	return sc, nil
+}
+func (c *childConfig) ScopedCopy() (types.Config, error) {
+	return c.Copy()
+}
+func (c *overlayConfig) ScopedCopy() (types.Config, error) {
+	return c.Copy()
+}
@@ -0,0 +1,84 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestScopedCopy(t *testing.T) {
+	wipeEnv()
+	c := newSubConfigTestConfig(t)
+	c.Set("database.tags", []interface{}{"a", "b"})
+
+	cc, err := c.Scope("database").ScopedCopy()
+	assert.NoError(t, err)
+	assert.Equal(t, "db.example.com", cc.GetString("host"))
+	assert.Equal(t, 5432, cc.GetInt("port"))
+	assert.Equal(t, 10, cc.GetInt("pool.size"))
+	assert.Nil(t, cc.Parent())
+	assert.Empty(t, cc.GetString("server.host"))
+
+	flat := Flatten(cc.AllSettings())
+	assert.Len(t, flat, 4)
+	_, ok := flat["server.host"]
+	assert.False(t, ok)
+
+	c.Set("database.host", "changed.example.com")
+	c.Set("database.tags", []interface{}{"c"})
+	if err := c.ReadConfig(bytes.NewReader(
+		[]byte("database:\n  port: 6543\n"))); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "db.example.com", cc.GetString("host"))
+	assert.Equal(t, 5432, cc.GetInt("port"))
+	assert.Equal(t, []string{"a", "b"}, cc.GetStringSlice("tags"))
+
+	cc.Set("host", "copy.example.com")
+	assert.Equal(t, "changed.example.com", c.GetString("database.host"))
+
+	pc, err := c.Scope("database").Scope("pool").ScopedCopy()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"size"}, pc.AllKeys())
+
+	rc, err := c.ScopedCopy()
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com", rc.GetString("server.host"))
+}
+
+func TestScopedCopySecureKeys(t *testing.T) {
+	wipeEnv()
+	TestHook(t)
+	r := newRegistration("Test ScopedCopy Secure")
+	r.Key(types.SecureString, "", "", "", "database.password")
+	r.Key(types.SecureString, "", "", "", "database.pool.token")
+
+	c := newSubConfigTestConfig(t)
+	c.Set("database.password", "s3cret")
+	c.Set("database.pool.token", "t0ken")
+
+	cc, err := c.Scope("database").ScopedCopy()
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", cc.GetString("password"))
+	s, err := cc.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, s, `"host":"db.example.com"`)
+	assert.NotContains(t, s, "s3cret")
+	assert.NotContains(t, s, "t0ken")
+
+	ccc, err := cc.Copy()
+	assert.NoError(t, err)
+	s, err = ccc.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.NotContains(t, s, "s3cret")
+
+	pc, err := c.Scope("database").Scope("pool").ScopedCopy()
+	assert.NoError(t, err)
+	s, err = pc.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, s, `"size":10`)
+	assert.NotContains(t, s, "t0ken")
+}
@@ -0,0 +1,108 @@
+package gofig
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// ErrInvalidSignature is the error returned when a config's signature does
+// not match its settings.
+var ErrInvalidSignature = goof.New("config signature is invalid")
+
+// SignConfig returns the hex-encoded HMAC-SHA256 signature of the config's
+// settings computed with the specified key. The signature is computed over
+// the JSON representation of the config's AllSettings map, which includes the
+// values of secure keys.
+func SignConfig(c types.Config, key []byte) ([]byte, error) {
+	if c == nil {
+		return nil, goof.New("config is nil")
+	}
+	buf, err := json.Marshal(c.AllSettings())
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(buf)
+	sum := mac.Sum(nil)
+	sig := make([]byte, hex.EncodedLen(len(sum)))
+	hex.Encode(sig, sum)
+	return sig, nil
+}
+
+// VerifyConfig returns ErrInvalidSignature if the hex-encoded signature is
+// not the signature of the config's settings computed with the specified key.
+func VerifyConfig(c types.Config, key []byte, signature []byte) error {
+	expected, err := SignConfig(c, key)
+	if err != nil {
+		return err
+	}
+	actual := make([]byte, hex.DecodedLen(len(bytes.TrimSpace(signature))))
+	if _, err := hex.Decode(actual, bytes.TrimSpace(signature)); err != nil {
+		return goof.WithFieldE(
+			"signature", string(signature), "invalid config signature", err)
+	}
+	sum := make([]byte, hex.DecodedLen(len(expected)))
+	hex.Decode(sum, expected)
+	if !hmac.Equal(sum, actual) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignConfigFile returns the hex-encoded HMAC-SHA256 signature of the
+// settings in the config file computed with the specified key. The signature
+// is the one expected by ReadSignedConfigFile.
+func SignConfigFile(filePath string, key []byte) ([]byte, error) {
+	fc, _, _, err := readFileConfig(filePath, "yml")
+	if err != nil {
+		return nil, err
+	}
+	return SignConfig(fc, key)
+}
+
+func (c *config) ReadSignedConfigFile(
+	filePath, sigPath string, key []byte) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	filePath = c.findConfigFile(filePath)
+	fc, buf, format, err := readFileConfig(filePath, c.configType)
+	if err != nil {
+		return err
+	}
+	sig, err := ioutil.ReadFile(sigPath)
+	if err != nil {
+		return err
+	}
+	if err := VerifyConfig(fc, key, sig); err != nil {
+		return goof.WithFieldE(
+			"path", filePath, "config file failed verification", err)
+	}
+	return c.mergeConfigFile(filePath, buf, format)
+}
+
+// readFileConfig returns a config that contains only the settings in the
+// config file, along with the file's content and format. The config type is
+// used as the file's format if it cannot be determined.
+func readFileConfig(
+	filePath, configType string) (*config, []byte, string, error) {
+
+	fc := newConfigObj()
+	fc.configType = configType
+	buf, format, err := fc.readConfigFileBuf(filePath)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err := fc.readConfig(bytes.NewReader(buf), format); err != nil {
+		return nil, nil, "", err
+	}
+	return fc, buf, format, nil
+}
@@ -0,0 +1,69 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestSignConfig(t *testing.T) {
+	newConfigDirs("TestSignConfig", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Sign")
+	r.Key(types.String, "", "localhost", "", "testSign.host")
+	r.Key(types.SecureString, "", "", "", "testSign.password")
+	Register(r)
+
+	key := []byte("signing-key")
+	c := New()
+	c.Set("testSign.password", "secret")
+
+	sig, err := SignConfig(c, key)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 64)
+	assert.NoError(t, VerifyConfig(c, key, sig))
+	assert.Equal(t,
+		ErrInvalidSignature, VerifyConfig(c, []byte("other-key"), sig))
+	assert.Error(t, VerifyConfig(c, key, []byte("not hex")))
+
+	c.Set("testSign.password", "changed")
+	assert.Equal(t, ErrInvalidSignature, VerifyConfig(c, key, sig))
+}
+
+func TestReadSignedConfigFile(t *testing.T) {
+	newConfigDirs("TestReadSignedConfigFile", t)
+	wipeEnv()
+	TestHook(t)
+
+	dir, err := ioutil.TempDir("", "gofig-test-TestReadSignedConfigFile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := []byte("signing-key")
+	filePath := filepath.Join(dir, "config.yml")
+	sigPath := filepath.Join(dir, "config.yml.sig")
+	assert.NoError(t, ioutil.WriteFile(
+		filePath, []byte("testSign:\n  host: 127.0.0.1\n"), 0644))
+	sig, err := SignConfigFile(filePath, key)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(sigPath, sig, 0644))
+
+	c := New()
+	assert.NoError(t, c.ReadSignedConfigFile(filePath, sigPath, key))
+	assert.Equal(t, "127.0.0.1", c.GetString("testSign.host"))
+
+	assert.NoError(t, ioutil.WriteFile(
+		filePath, []byte("testSign:\n  host: 10.0.0.1\n"), 0644))
+	c = New()
+	err = c.ReadSignedConfigFile(filePath, sigPath, key)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "config file failed verification")
+	assert.Empty(t, c.GetString("testSign.host"))
+}
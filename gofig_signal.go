@@ -0,0 +1,122 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/akutz/goof"
+	"github.com/akutz/gotil"
+
+	"github.com/akutz/gofig/types"
+)
+
+// signalReloadConfig returns the config instance whose files are reloaded
+// when a reload signal is received for the specified config.
+func signalReloadConfig(c types.Config) (*config, error) {
+	if rc, ok := unwrapConfig(c); ok {
+		return rc, nil
+	}
+	return nil, goof.New("config does not support signal reload")
+}
+
+// unwrapConfig returns the config instance that stores the settings of the
+// specified config, such as the config that was scoped or made read-only.
+func unwrapConfig(c types.Config) (*config, bool) {
+	for {
+		switch tc := c.(type) {
+		case *config:
+			return tc, true
+		case *childConfig:
+			return tc.config, true
+		case *scopedConfig:
+			c = tc.Config
+		case *scopedStrictConfig:
+			c = tc.Config
+		case *readOnlyConfig:
+			c = tc.Config
+		case *overlayConfig:
+			c = tc.Config
+		default:
+			return nil, false
+		}
+	}
+}
+
+// reloadOnSignal reloads the config's files and logs the outcome.
+func reloadOnSignal(c *config, sig os.Signal) {
+	n, err := c.reloadFiles()
+	if err != nil {
+		logger().Error("config reload failed",
+			"signal", sig.String(), "error", err)
+		return
+	}
+	logger().Info("config reloaded", "signal", sig.String(), "files", n)
+}
+
+// reloadFiles reads the config files and URLs previously read into the
+// config again and rebuilds the config from their current contents. A file
+// that no longer exists is skipped. The config is not modified if any of the
+// files or URLs cannot be read, and the change callbacks are invoked only
+// once the config is rebuilt. The number of files and URLs that were
+// reloaded is returned.
+func (c *config) reloadFiles() (int, error) {
+	if c.frozen.Load() {
+		return 0, ErrFrozen
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+
+	n := 0
+	reads := append([]configRead{}, c.reads...)
+	for i, r := range reads {
+		var (
+			buf []byte
+			err error
+		)
+		switch {
+		case r.filePath != "":
+			if !gotil.FileExists(r.filePath) {
+				logger().Warn("skipped reloading missing config file",
+					"path", r.filePath)
+				continue
+			}
+			if buf, err = ioutil.ReadFile(r.filePath); err == nil {
+				buf, err = decompressIfCompressed(buf)
+			}
+			if err == nil {
+				buf, err = expandIncludes(r.filePath, buf, r.format)
+			}
+		case r.url != "":
+			buf, _, err = fetchURL(r.url, r.format, r.httpOpts)
+		default:
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err := c.checkSettings(buf, r.format); err != nil {
+			return 0, err
+		}
+		reads[i].buf = buf
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	var before map[string]interface{}
+	if c.hasAuditLog() {
+		before = c.flatSettingsLocked()
+	}
+	overrides := map[string]interface{}{}
+	for k := range c.overrideKeys {
+		overrides[k] = c.b.Get(k)
+	}
+	if err := c.resetWithOverrides(reads, overrides); err != nil {
+		return 0, err
+	}
+	if before != nil {
+		c.recordChanges(before, c.flatSettingsLocked(), types.File)
+	}
+	return n, nil
+}
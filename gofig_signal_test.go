@@ -0,0 +1,137 @@
+//go:build !windows
+// +build !windows
+
+package gofig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableSignalReload(t *testing.T) {
+	_, usrFile := newConfigDirs("TestEnableSignalReload", t)
+	wipeEnv()
+
+	filePath := filepath.Join(filepath.Dir(usrFile), "signal.yml")
+	writeFile := func(s string) {
+		if err := ioutil.WriteFile(filePath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("testSignal:\n  host: host1\n")
+
+	c := New()
+	assert.NoError(t, c.ReadConfigFile(filePath))
+	c.Set("testSignal.port", 1)
+	assert.Equal(t, "host1", c.GetString("testSignal.host"))
+
+	changes := make(chan interface{}, 1)
+	h := c.OnChange("testSignal.host", func(oldVal, newVal interface{}) {
+		changes <- newVal
+	})
+	defer h.Stop()
+
+	stop, err := EnableSignalReload(c.Scope("testSignal"))
+	assert.NoError(t, err)
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile("testSignal:\n  host: host2\n  port: 2\n")
+	assert.NoError(t, p.Signal(syscall.SIGHUP))
+	select {
+	case v := <-changes:
+		assert.Equal(t, "host2", v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+	assert.Equal(t, "host2", c.GetString("testSignal.host"))
+	assert.Equal(t, 1, c.GetInt("testSignal.port"))
+
+	stop()
+	stop()
+
+	stop, err = EnableSignalReload(c, syscall.SIGUSR1)
+	assert.NoError(t, err)
+	defer stop()
+
+	writeFile("testSignal:\n  host: host3\n")
+	assert.NoError(t, p.Signal(syscall.SIGUSR1))
+	select {
+	case v := <-changes:
+		assert.Equal(t, "host3", v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	_, err = EnableSignalReload(nil)
+	assert.Error(t, err)
+}
+
+func TestReloadFilesMultiple(t *testing.T) {
+	_, usrFile := newConfigDirs("TestReloadFilesMultiple", t)
+	wipeEnv()
+
+	dir := filepath.Dir(usrFile)
+	writeFile := func(name, s string) string {
+		filePath := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(filePath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return filePath
+	}
+	f1 := writeFile("reload1.yml", "testReload:\n  host: host1\n  port: 1\n")
+	f2 := writeFile("reload2.yml", "testReload:\n  host: host2\n")
+
+	c := newConfig()
+	assert.NoError(t, c.ReadConfigFile(f1))
+	assert.NoError(t, c.ReadConfigFile(f2))
+	assert.Equal(t, "host2", c.GetString("testReload.host"))
+	assert.Equal(t, 1, c.GetInt("testReload.port"))
+
+	hosts := make(chan interface{}, 4)
+	hh := c.OnChange("testReload.host", func(oldVal, newVal interface{}) {
+		hosts <- newVal
+	})
+	defer hh.Stop()
+	ports := make(chan interface{}, 4)
+	ph := c.OnChange("testReload.port", func(oldVal, newVal interface{}) {
+		ports <- newVal
+	})
+	defer ph.Stop()
+
+	writeFile("reload1.yml", "testReload:\n  host: host3\n  port: 2\n")
+	n, err := c.reloadFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	select {
+	case v := <-ports:
+		assert.Equal(t, 2, v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+	assert.Equal(t, "host2", c.GetString("testReload.host"))
+	assert.Equal(t, 2, c.GetInt("testReload.port"))
+
+	writeFile("reload1.yml", "testReload:\n  port: 3\n")
+	writeFile("reload2.yml", "testReload: [\n")
+	_, err = c.reloadFiles()
+	assert.Error(t, err)
+	assert.Equal(t, 2, c.GetInt("testReload.port"))
+
+	select {
+	case v := <-hosts:
+		t.Fatalf("unexpected host change %v", v)
+	case v := <-ports:
+		t.Fatalf("unexpected port change %v", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
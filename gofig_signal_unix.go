@@ -0,0 +1,61 @@
+//go:build !windows
+// +build !windows
+
+package gofig
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/akutz/gofig/types"
+)
+
+// EnableSignalReload reloads the files previously read into the config each
+// time one of the specified signals is received. The config's change
+// callbacks are invoked for the keys whose values change as a result. If no
+// signals are specified then the config is reloaded on SIGHUP.
+//
+// The returned function stops listening for the signals.
+func EnableSignalReload(
+	c types.Config, sigs ...os.Signal) (stop func(), err error) {
+
+	rc, err := signalReloadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+
+	sigc := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigc, sigs...)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-sigc:
+				reloadOnSignal(rc, sig)
+			}
+		}
+	}()
+
+	once := &sync.Once{}
+	return func() {
+		once.Do(func() {
+			signal.Stop(sigc)
+			close(done)
+			for {
+				select {
+				case <-sigc:
+				default:
+					return
+				}
+			}
+		})
+	}, nil
+}
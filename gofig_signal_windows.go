@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package gofig
+
+import (
+	"os"
+
+	"github.com/akutz/gofig/types"
+)
+
+// EnableSignalReload is a no-op on Windows since it does not support the
+// signals used to trigger a reload.
+//
+// The returned function does nothing.
+func EnableSignalReload(
+	c types.Config, sigs ...os.Signal) (stop func(), err error) {
+
+	if _, err := signalReloadConfig(c); err != nil {
+		return nil, err
+	}
+	logger().Warn("signal reload is not supported on windows")
+	return func() {}, nil
+}
@@ -0,0 +1,50 @@
+package gofig
+
+import (
+	"os"
+	"strings"
+)
+
+// snapshot is an immutable copy of a config's settings that is published
+// after every write to the config's backend.
+type snapshot map[string]interface{}
+
+// publishSnapshot stores a new snapshot of the config's settings. It must be
+// called after every write to the config's backend.
+func (c *config) publishSnapshot() {
+	s := snapshot{}
+	for _, k := range c.backendKeys() {
+		s[strings.ToLower(k)] = c.b.Get(k)
+	}
+	c.snapshot.Store(s)
+}
+
+// get returns the value of the key from the config's snapshot. The value is
+// read from the backend, while holding the config's read lock, if the key is
+// not in the snapshot or if the key's value is provided by an environment
+// variable or flag, since either may change without a write to the config.
+func (c *config) get(k string) interface{} {
+	c.expireTTLs()
+	lk := strings.ToLower(k)
+	if s, ok := c.snapshot.Load().(snapshot); ok && !c.isBoundValueSet(lk) {
+		if v, ok := s[lk]; ok {
+			return v
+		}
+	}
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	return c.b.Get(k)
+}
+
+// isBoundValueSet returns a flag indicating whether or not the key's value is
+// provided by its bound environment variable or flag.
+func (c *config) isBoundValueSet(k string) bool {
+	kb, ok := c.keyBindings[k]
+	if !ok {
+		return false
+	}
+	if kb.flag != nil && kb.flag.Changed {
+		return true
+	}
+	return kb.envVar != "" && os.Getenv(kb.envVar) != ""
+}
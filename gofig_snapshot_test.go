@@ -0,0 +1,132 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	newConfigDirs("TestSnapshot", t)
+	wipeEnv()
+
+	c := newConfig()
+	assert.Equal(t, "tcp://:7979", c.GetString("rexray.host"))
+
+	c.Set("testSnapshot.count", 3)
+	assert.Equal(t, 3, c.GetInt("testSnapshot.count"))
+	assert.Equal(t, "3", c.GetString("testSnapshot.count"))
+
+	assert.NoError(t, c.ReadConfig(strings.NewReader(`
+testSnapshot:
+  enabled: true
+  names:
+  - a
+  - b
+`)))
+	assert.True(t, c.GetBool("testSnapshot.enabled"))
+	assert.Equal(t, []string{"a", "b"}, c.GetStringSlice("testSnapshot.names"))
+	assert.Equal(t, 3, c.Get("testSnapshot.count"))
+
+	os.Setenv("REXRAY_HOST", "tcp://:8080")
+	assert.Equal(t, "tcp://:8080", c.GetString("rexray.host"))
+	os.Unsetenv("REXRAY_HOST")
+	assert.Equal(t, "tcp://:7979", c.GetString("rexray.host"))
+
+	assert.NoError(t, c.ResetToDefaults())
+	assert.Equal(t, 0, c.GetInt("testSnapshot.count"))
+	assert.False(t, c.GetBool("testSnapshot.enabled"))
+}
+
+func TestSnapshotConcurrentReadWrite(t *testing.T) {
+	newConfigDirs("TestSnapshotConcurrentReadWrite", t)
+	wipeEnv()
+
+	c := newConfig()
+	c.Set("testSnapshot.count", 0)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				n := c.GetInt("testSnapshot.count")
+				assert.True(t, n >= 0 && n < 100)
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		c.Set("testSnapshot.count", i)
+	}
+	wg.Wait()
+	assert.Equal(t, 99, c.GetInt("testSnapshot.count"))
+}
+
+func TestSnapshotConcurrentBackendReads(t *testing.T) {
+	newConfigDirs("TestSnapshotConcurrentBackendReads", t)
+	wipeEnv()
+
+	c := newConfig()
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				assert.Equal(t, "", c.GetString("testSnapshot.missing"))
+				c.IsSet("testSnapshot.count")
+				c.AllSettings()
+				c.AllKeys()
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		c.Set(fmt.Sprintf("testSnapshot.key%d", i), i)
+	}
+	wg.Wait()
+	assert.Equal(t, 199, c.GetInt("testSnapshot.key199"))
+}
+
+func BenchmarkGetString(b *testing.B) {
+	wipeEnv()
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	c.Set("testSnapshot.name", "gofig")
+
+	for _, n := range []int{8, 32, 128} {
+		b.Run(fmt.Sprintf("viper-%d", n), func(b *testing.B) {
+			benchmarkConcurrentReads(b, n, func() {
+				cast.ToString(c.b.Get("testSnapshot.name"))
+			})
+		})
+		b.Run(fmt.Sprintf("snapshot-%d", n), func(b *testing.B) {
+			benchmarkConcurrentReads(b, n, func() {
+				cast.ToString(c.get("testSnapshot.name"))
+			})
+		})
+	}
+}
+
+// benchmarkConcurrentReads runs the read function b.N times, split across the
+// specified number of goroutines.
+func benchmarkConcurrentReads(b *testing.B, readers int, read func()) {
+	wg := &sync.WaitGroup{}
+	b.ResetTimer()
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := i; j < b.N; j += readers {
+				read()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,87 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/akutz/gofig/types"
+)
+
+// keyBinding is the environment variable and flag bound to a registered key
+// and the delimiter used to split the environment variable's value.
+type keyBinding struct {
+	envVar string
+	flag   *pflag.Flag
+	delim  string
+}
+
+func (c *config) addKeyBinding(
+	k types.ConfigRegistrationKey, envVar string, flag *pflag.Flag) {
+
+	c.keyBindings[strings.ToLower(k.KeyName())] = &keyBinding{
+		envVar: envVar,
+		flag:   flag,
+		delim:  k.Delim(),
+	}
+}
+
+// addReadKeys records the keys present in a config stream that was read into
+// the config instance.
+func (c *config) addReadKeys(buf []byte, format string) {
+	m, err := readSettings(buf, format)
+	if err != nil {
+		return
+	}
+	for _, k := range flatMapKeys(m) {
+		c.readKeys[strings.ToLower(c.realKey(k))] = true
+	}
+}
+
+func (c *config) GetSource(k interface{}) types.ConfigSource {
+	szK := strings.ToLower(c.realKey(toString(k)))
+	c.rwl.RLock()
+	defer c.rwl.RUnlock()
+	if hasRelatedKey(c.overrideKeys, szK) {
+		return types.Override
+	}
+	if kb, ok := c.keyBindings[szK]; ok {
+		if kb.flag != nil && kb.flag.Changed {
+			return types.Flag
+		}
+		if kb.envVar != "" && os.Getenv(kb.envVar) != "" {
+			return types.EnvVar
+		}
+	}
+	if hasRelatedKey(c.readKeys, szK) {
+		return types.File
+	}
+	return types.Default
+}
+func (c *scopedConfig) GetSource(k interface{}) types.ConfigSource {
+	szK := toString(k)
+	sk := fmt.Sprintf("%s.%s", c.scope, szK)
+	if c.Config.IsSet(sk) {
+		return c.Config.GetSource(sk)
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetSource(szK)
+	}
+	return types.Default
+}
+
+// hasRelatedKey returns a flag indicating whether or not the map contains the
+// key, one of the key's ancestors, or one of the key's descendants.
+func hasRelatedKey(m map[string]bool, k string) bool {
+	if isRegisteredKey(k, m) {
+		return true
+	}
+	for mk := range m {
+		if strings.HasPrefix(mk, k+".") {
+			return true
+		}
+	}
+	return false
+}
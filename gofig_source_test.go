@@ -0,0 +1,59 @@
+package gofig
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestGetSource(t *testing.T) {
+	newConfigDirs("TestGetSource", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Source")
+	r.Key(types.String, "", "", "", "testSource.envVar")
+	r.Key(types.String, "", "", "", "testSource.flag")
+	r.Key(types.String, "", "", "", "testSource.file")
+	Register(r)
+
+	os.Setenv("TESTSOURCE_ENVVAR", "env")
+	defer os.Setenv("TESTSOURCE_ENVVAR", "")
+
+	c := New()
+	assert.NoError(t, c.FlagSets()["Test Source Flags"].Parse(
+		[]string{"--testSourceFlag=flag"}))
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(`
+testSource:
+  file: file
+  nested:
+    value: 1
+`))))
+
+	assert.Equal(t, types.Default, c.GetSource("rexray.host"))
+	assert.Equal(t, types.Default, c.GetSource("testSource.missing"))
+	assert.Equal(t, types.EnvVar, c.GetSource("testSource.envVar"))
+	assert.Equal(t, types.Flag, c.GetSource("testSource.flag"))
+	assert.Equal(t, types.File, c.GetSource("testSource.file"))
+	assert.Equal(t, types.File, c.GetSource("testSource.nested.value"))
+	assert.Equal(t, types.File, c.Scope("testSource").GetSource("file"))
+
+	c.Set("testSource.file", "override")
+	c.Set("testSource.envVar", "override")
+	assert.Equal(t, types.Override, c.GetSource("testSource.file"))
+	assert.Equal(t, types.Override, c.GetSource("testSource.envVar"))
+	assert.Equal(t, types.Override, c.Scope("testSource").GetSource("file"))
+	assert.Equal(t, "Override", c.GetSource("testSource.file").String())
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Equal(t, types.Override, cc.GetSource("testSource.file"))
+
+	assert.NoError(t, c.Reset())
+	assert.Equal(t, types.File, c.GetSource("testSource.file"))
+	assert.Equal(t, types.EnvVar, c.GetSource("testSource.envVar"))
+}
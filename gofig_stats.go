@@ -0,0 +1,45 @@
+//go:build !nogofigstats
+// +build !nogofigstats
+
+package gofig
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// accessStats are the number of times each of a config's keys were read
+type accessStats struct {
+	counts sync.Map
+}
+
+// recordAccess increments the access count of the key.
+func (c *config) recordAccess(k string) {
+	c.recordCoverage(k)
+	c.recordDebugAccess(k)
+	lk := strings.ToLower(k)
+	n, ok := c.stats.counts.Load(lk)
+	if !ok {
+		n, _ = c.stats.counts.LoadOrStore(lk, new(uint64))
+	}
+	atomic.AddUint64(n.(*uint64), 1)
+}
+
+func (c *config) AccessStats() map[string]uint64 {
+	m := map[string]uint64{}
+	c.stats.counts.Range(func(k, n interface{}) bool {
+		if v := atomic.LoadUint64(n.(*uint64)); v > 0 {
+			m[k.(string)] = v
+		}
+		return true
+	})
+	return m
+}
+
+func (c *config) ResetStats() {
+	c.stats.counts.Range(func(k, n interface{}) bool {
+		atomic.StoreUint64(n.(*uint64), 0)
+		return true
+	})
+}
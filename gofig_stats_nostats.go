@@ -0,0 +1,18 @@
+//go:build nogofigstats
+// +build nogofigstats
+
+package gofig
+
+// accessStats are not collected when built with nogofigstats
+type accessStats struct{}
+
+func (c *config) recordAccess(k string) {
+	c.recordCoverage(k)
+	c.recordDebugAccess(k)
+}
+
+func (c *config) AccessStats() map[string]uint64 {
+	return map[string]uint64{}
+}
+
+func (c *config) ResetStats() {}
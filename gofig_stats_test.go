@@ -0,0 +1,45 @@
+//go:build !nogofigstats
+// +build !nogofigstats
+
+package gofig
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessStats(t *testing.T) {
+	newConfigDirs("TestAccessStats", t)
+	wipeEnv()
+
+	c := New()
+	assert.Empty(t, c.AccessStats())
+
+	wg := &sync.WaitGroup{}
+	for x := 0; x < 10; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.GetString("rexray.host")
+			c.Scope("rexray").GetString("logLevel")
+		}()
+	}
+	wg.Wait()
+	c.GetStringSlice("rexray.storageDrivers")
+
+	stats := c.AccessStats()
+	assert.Equal(t, uint64(10), stats["rexray.host"])
+	assert.Equal(t, uint64(10), stats["rexray.loglevel"])
+	assert.Equal(t, uint64(1), stats["rexray.storagedrivers"])
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Empty(t, cc.AccessStats())
+
+	c.ResetStats()
+	assert.Empty(t, c.AccessStats())
+	c.Get("rexray.host")
+	assert.Equal(t, map[string]uint64{"rexray.host": 1}, c.AccessStats())
+}
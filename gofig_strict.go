@@ -0,0 +1,112 @@
+package gofig
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// RegisteredKeys returns the names of the keys from all of the registrations
+// that have been registered with the config package.
+func RegisteredKeys() []string {
+	registrationsRWL.RLock()
+	defer registrationsRWL.RUnlock()
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, r := range registrations {
+		for k := range r.Keys() {
+			lk := strings.ToLower(k.KeyName())
+			if seen[lk] {
+				continue
+			}
+			seen[lk] = true
+			keys = append(keys, k.KeyName())
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *config) StrictMode(enabled bool) {
+	c.strictMode = enabled
+}
+
+// checkUnknownSettings returns a *ValidationError listing the keys present
+// in the settings read from a config stream that do not belong to any
+// registration. Values nested below a registered key are considered part of
+// that key.
+func (c *config) checkUnknownSettings(m map[string]interface{}) error {
+	if !c.strictMode {
+		return nil
+	}
+
+	regKeys := map[string]bool{}
+	for _, k := range RegisteredKeys() {
+		regKeys[strings.ToLower(k)] = true
+	}
+	for _, r := range c.registrations {
+		for k := range r.Keys() {
+			regKeys[strings.ToLower(k.KeyName())] = true
+		}
+	}
+
+	unknown := []string{}
+	for _, k := range flatMapKeys(m) {
+		if !isRegisteredKey(strings.ToLower(realKey(k)), regKeys) {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	verr := &ValidationError{}
+	for _, k := range unknown {
+		verr.Errors = append(
+			verr.Errors, NewConfigError(ErrCodeUnknownKey, k, nil))
+	}
+	return verr
+}
+
+// isRegisteredKey returns a flag indicating whether or not the key, or one of
+// the key's ancestors, is a registered key.
+func isRegisteredKey(k string, regKeys map[string]bool) bool {
+	for {
+		if regKeys[k] {
+			return true
+		}
+		x := strings.LastIndex(k, ".")
+		if x < 0 {
+			return false
+		}
+		k = k[:x]
+	}
+}
+
+// readSettings parses a config stream and returns its settings as a tree of
+// nested maps.
+func readSettings(buf []byte, format string) (map[string]interface{}, error) {
+	tv := viper.New()
+	tv.SetConfigType(format)
+	if err := tv.ReadConfig(bytes.NewReader(buf)); err != nil {
+		return nil, err
+	}
+	return tv.AllSettings(), nil
+}
+
+// flatMapKeys returns the dot-notation names of the leaf keys in a tree of
+// nested maps.
+func flatMapKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for k, v := range m {
+		switch tv := v.(type) {
+		case map[string]interface{}:
+			flattenArrayKeys(k, tv, &keys)
+		default:
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,81 @@
+package gofig
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func assertUnknownKeys(t *testing.T, err error, keys string) {
+	var verr *ValidationError
+	if !assert.True(t, errors.As(err, &verr)) {
+		return
+	}
+	unknown := []string{}
+	for _, cerr := range verr.Errors {
+		assert.Equal(t, ErrCodeUnknownKey, cerr.Code())
+		unknown = append(unknown, cerr.Key())
+	}
+	assert.Equal(t, keys, strings.Join(unknown, ","))
+}
+
+func TestRegisteredKeys(t *testing.T) {
+	rk := RegisteredKeys()
+	assert.Contains(t, rk, "rexray.host")
+	assert.Contains(t, rk, "rexray.logLevel")
+	assert.Contains(t, rk, "rexray.storageDrivers")
+}
+
+func TestStrictMode(t *testing.T) {
+	newConfigDirs("TestStrictMode", t)
+	wipeEnv()
+
+	r := newRegistration("Test Strict Mode")
+	r.Key(types.String, "", "30s", "", "testStrict.timeout")
+	r.Key(types.String, "", "", "", "testStrict.modules")
+	Register(r)
+
+	c := New()
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(`
+testStrict:
+  timeot: 5s
+`))))
+
+	c.StrictMode(true)
+	err := c.ReadConfig(bytes.NewReader([]byte(`
+testStrict:
+  timeot: 5s
+  host: tcp://:7979
+`)))
+	assertUnknownKeys(t, err, "teststrict.host,teststrict.timeot")
+	assert.Equal(t, "30s", c.GetString("testStrict.timeout"))
+
+	assert.NoError(t, c.ReadConfig(bytes.NewReader([]byte(`
+testStrict:
+  timeout: 5s
+  modules:
+    default:
+      host: tcp://:7979
+`))))
+	assert.Equal(t, "5s", c.GetString("testStrict.timeout"))
+}
+
+func TestStrictModeGlobal(t *testing.T) {
+	newConfigDirs("TestStrictModeGlobal", t)
+	wipeEnv()
+	StrictMode = true
+	defer func() { StrictMode = false }()
+
+	c := New()
+	err := c.ReadConfig(bytes.NewReader(yamlConfig1))
+	assertUnknownKeys(t, err,
+		"mockprovider.docker.minvolsize,mockprovider.usecerts,"+
+			"mockprovider.username")
+	assert.NoError(t, c.ReadConfig(bytes.NewReader(yamlConfig2)))
+	assert.Equal(t, "debug", c.GetString("rexray.logLevel"))
+}
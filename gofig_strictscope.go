@@ -0,0 +1,98 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// scopedStrictConfig is a scoped config whose AllKeys, AllSettings, and
+// EnvVars functions return only the keys, settings, and environment variables
+// that belong to the scope, with the scope prefix removed.
+type scopedStrictConfig struct {
+	scopedConfig
+}
+
+func newScopedStrictConfig(
+	parent types.Config, scope string) *scopedStrictConfig {
+	return &scopedStrictConfig{
+		scopedConfig: scopedConfig{Config: parent, scope: scope},
+	}
+}
+
+func (c *config) StrictScope(scope string) types.Config {
+	return newScopedStrictConfig(c, scope)
+}
+func (c *scopedConfig) StrictScope(scope string) types.Config {
+	return newScopedStrictConfig(c, scope)
+}
+func (c *childConfig) StrictScope(scope string) types.Config {
+	return newScopedStrictConfig(c, scope)
+}
+
+func (c *scopedStrictConfig) AllKeys() []string {
+	prefix := fmt.Sprintf("%s.", c.scope)
+	ak := keysWithPrefix(c.Config.AllKeys(), prefix)
+	for i, k := range ak {
+		ak[i] = strings.ToLower(k[len(prefix):])
+	}
+	return ak
+}
+
+func (c *scopedStrictConfig) AllSettings() map[string]interface{} {
+	flat := map[string]interface{}{}
+	for _, k := range c.AllKeys() {
+		flat[k] = c.Config.Get(fmt.Sprintf("%s.%s", c.scope, k))
+	}
+	return unflattenMapKeys(flat)
+}
+
+// EnvVars returns the parent's environment variables for the keys in the
+// scope. The part of each variable's name that corresponds to the scope is
+// removed, so with the scope "a" the variable A_B=1 is returned as B=1. Any
+// prefix configured with WithEnvKeyPrefix or SetEnvKeyPrefix is kept.
+func (c *scopedStrictConfig) EnvVars() []string {
+	return c.scopeEnvVars(c.Config.EnvVars())
+}
+
+// EnvVarsWithPrefix returns the variables returned by the parent's
+// EnvVarsWithPrefix function for the keys in the scope with the part of each
+// variable's name that corresponds to the scope removed.
+func (c *scopedStrictConfig) EnvVarsWithPrefix(prefix string) []string {
+	return prefixEnvVars(prefix, c.scopeEnvVars(c.Config.EnvVarsWithPrefix("")))
+}
+
+// scopeEnvVars returns the parent's environment variables for the keys in the
+// scope with the part of each variable's name that corresponds to the scope
+// removed.
+func (c *scopedStrictConfig) scopeEnvVars(envVars []string) []string {
+	scope := strictScopeEnvName(c.scope)
+	keys := map[string]string{}
+	for _, k := range c.AllKeys() {
+		keys[fmt.Sprintf("%s_%s", scope, strictScopeEnvName(k))] =
+			strictScopeEnvName(k)
+	}
+
+	var evArr []string
+	for _, ev := range envVars {
+		evParts := strings.SplitN(ev, "=", 2)
+		for suffix, name := range keys {
+			if evParts[0] != suffix &&
+				!strings.HasSuffix(evParts[0], "_"+suffix) {
+				continue
+			}
+			prefix := evParts[0][:len(evParts[0])-len(suffix)]
+			evArr = append(
+				evArr, fmt.Sprintf("%s%s=%s", prefix, name, evParts[1]))
+			break
+		}
+	}
+	return evArr
+}
+
+// strictScopeEnvName returns the environment variable name for the key or
+// scope without any prefix.
+func strictScopeEnvName(k string) string {
+	return strings.ToUpper(strings.Replace(k, ".", "_", -1))
+}
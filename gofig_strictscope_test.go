@@ -0,0 +1,83 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+var strictScopeConfig = []byte(`tenantA:
+  host: a.example.com
+  limits:
+    cpu: 2
+tenantB:
+  host: b.example.com
+  port: 8080
+`)
+
+func newStrictScopeTestConfig(t *testing.T) types.Config {
+	c := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	if err := c.ReadConfig(bytes.NewReader(strictScopeConfig)); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestStrictScopeAllKeys(t *testing.T) {
+	wipeEnv()
+	c := newStrictScopeTestConfig(t)
+
+	sc := c.StrictScope("tenantA")
+	assert.Equal(t, []string{"host", "limits.cpu"}, sc.AllKeys())
+	assert.Equal(t, "a.example.com", sc.GetString("host"))
+	assert.Equal(t, "tenantA", sc.GetScope())
+
+	ak := c.StrictScope("tenantB").AllKeys()
+	assert.Equal(t, []string{"host", "port"}, ak)
+
+	assert.Empty(t, c.StrictScope("tenant").AllKeys())
+	assert.Equal(t,
+		[]string{"cpu"}, sc.StrictScope("limits").AllKeys())
+}
+
+func TestStrictScopeAllSettings(t *testing.T) {
+	wipeEnv()
+	c := newStrictScopeTestConfig(t)
+
+	as := c.StrictScope("tenantA").AllSettings()
+	assert.Equal(t, "a.example.com", as["host"])
+	limits, ok := as["limits"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, 2, limits["cpu"])
+	_, ok = as["port"]
+	assert.False(t, ok)
+	_, ok = as["tenantb"]
+	assert.False(t, ok)
+}
+
+func TestStrictScopeEnvVars(t *testing.T) {
+	wipeEnv()
+	c := newStrictScopeTestConfig(t)
+
+	ev := c.StrictScope("tenantB").EnvVars()
+	assert.Len(t, ev, 2)
+	assert.Contains(t, ev, "HOST=b.example.com")
+	assert.Contains(t, ev, "PORT=8080")
+	assert.Contains(t,
+		c.Scope("tenantB").EnvVars(), "TENANTA_HOST=a.example.com")
+
+	pc := NewConfig(
+		WithGlobalConfig(false),
+		WithUserConfig(false),
+		WithEnvKeyPrefix("myapp"))
+	if err := pc.ReadConfig(bytes.NewReader(strictScopeConfig)); err != nil {
+		t.Fatal(err)
+	}
+	ev = pc.StrictScope("tenantA").EnvVars()
+	assert.Contains(t, ev, "MYAPP_HOST=a.example.com")
+	assert.Contains(t, ev, "MYAPP_LIMITS_CPU=2")
+	assert.NotContains(t, ev, "MYAPP_HOST=b.example.com")
+}
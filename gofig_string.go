@@ -0,0 +1,46 @@
+package gofig
+
+import (
+	"fmt"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) String() string {
+	return configString(c, "")
+}
+func (c *scopedConfig) String() string {
+	return configString(c, c.scope)
+}
+func (c *childConfig) String() string {
+	return configString(c, "")
+}
+
+func (c *config) GoString() string {
+	return configGoString(c)
+}
+func (c *scopedConfig) GoString() string {
+	return configGoString(c)
+}
+func (c *childConfig) GoString() string {
+	return configGoString(c)
+}
+
+// configString returns a summary of the config that does not include any of
+// the config's values so that the config may be logged safely.
+func configString(c types.Config, scope string) string {
+	return fmt.Sprintf("Config{keys: %d, scope: %q, frozen: %t}",
+		len(c.AllKeys()), scope, c.IsFrozen())
+}
+
+// configGoString returns the config's settings as a Go-syntax nested map
+// without the settings of the secure keys.
+func configGoString(c types.Config) string {
+	flat := c.Flatten()
+	for k := range flat {
+		if isSecureConfigKey(c, k) {
+			delete(flat, k)
+		}
+	}
+	return fmt.Sprintf("%#v", unflattenMapKeys(flat))
+}
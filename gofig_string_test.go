@@ -0,0 +1,51 @@
+package gofig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestString(t *testing.T) {
+	wipeEnv()
+	TestHook(t)
+	ClearRegistrations()
+	r := newRegistration("Test String")
+	r.Key(types.SecureString, "", "", "", "testString.db.password")
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	c.Set("testString.db.password", "secret")
+	c.Set("testString.db.host", "db.example.com")
+	c.Set("alpha", 1)
+
+	assert.Equal(t, `Config{keys: 3, scope: "", frozen: false}`,
+		fmt.Sprintf("%v", c))
+	sc := c.Scope("testString")
+	assert.Equal(t, `Config{keys: 2, scope: "testString", frozen: false}`,
+		fmt.Sprintf("%s", sc))
+	c.Freeze()
+	assert.Equal(t, `Config{keys: 3, scope: "", frozen: true}`, c.String())
+
+	assert.Equal(t,
+		`map[string]interface {}{"alpha":1, "teststring":`+
+			`map[string]interface {}{"db":`+
+			`map[string]interface {}{"host":"db.example.com"}}}`,
+		fmt.Sprintf("%#v", c))
+	assert.Equal(t,
+		`map[string]interface {}{"db":`+
+			`map[string]interface {}{"host":"db.example.com"}}`,
+		fmt.Sprintf("%#v", sc))
+
+	for _, s := range []string{
+		fmt.Sprintf("%v", c),
+		fmt.Sprintf("%#v", c),
+		fmt.Sprintf("%v", sc),
+		fmt.Sprintf("%#v", sc),
+		fmt.Sprintf("%#v", c.Extend()),
+	} {
+		assert.NotContains(t, s, "secret")
+	}
+}
@@ -0,0 +1,40 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) SubConfig(prefix string) types.Config {
+	return newSubConfig(c, prefix)
+}
+func (c *scopedConfig) SubConfig(prefix string) types.Config {
+	return newSubConfig(c, prefix)
+}
+func (c *childConfig) SubConfig(prefix string) types.Config {
+	return newSubConfig(c, prefix)
+}
+
+// newSubConfig returns a new config with the values of the keys below the
+// prefix in the parent config, with the prefix removed from their names.
+func newSubConfig(parent types.Config, prefix string) *config {
+	sc := newConfigObj()
+	sc.configName = "config"
+	sc.configType = "yml"
+	sc.subParent = parent
+	sc.subPrefix = prefix
+	lp := fmt.Sprintf("%s.", strings.ToLower(prefix))
+	for _, k := range parent.AllKeys() {
+		lk := strings.ToLower(k)
+		if !strings.HasPrefix(lk, lp) {
+			continue
+		}
+		if v := parent.Get(k); v != nil {
+			sc.b.Set(lk[len(lp):], copyValue(v))
+		}
+	}
+	sc.publishSnapshot()
+	return sc
+}
@@ -0,0 +1,109 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+var subConfigYAML = []byte(`database:
+  host: db.example.com
+  port: 5432
+  pool:
+    size: 10
+server:
+  host: www.example.com
+`)
+
+func newSubConfigTestConfig(t *testing.T) *config {
+	c := newConfigWithOptions(false, false, "config", "yml")
+	if err := c.ReadConfig(bytes.NewReader(subConfigYAML)); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSubConfig(t *testing.T) {
+	wipeEnv()
+	c := newSubConfigTestConfig(t)
+
+	sc := c.SubConfig("database")
+	assert.Equal(t, "db.example.com", sc.GetString("host"))
+	assert.Equal(t, 5432, sc.GetInt("port"))
+	assert.Equal(t, 10, sc.GetInt("pool.size"))
+	assert.Nil(t, sc.Parent())
+
+	as := sc.AllSettings()
+	assert.Len(t, as, 3)
+	assert.Equal(t, "db.example.com", as["host"])
+	_, ok := as["server"]
+	assert.False(t, ok)
+	assert.Empty(t, sc.GetString("server.host"))
+	assert.Empty(t, sc.GetString("database.host"))
+
+	pool := c.SubConfig("database").SubConfig("pool")
+	assert.Equal(t, []string{"size"}, pool.AllKeys())
+
+	assert.Empty(t, c.SubConfig("missing").AllKeys())
+}
+
+func TestSubConfigIsolation(t *testing.T) {
+	wipeEnv()
+	c := newSubConfigTestConfig(t)
+	sc := c.SubConfig("database")
+
+	sc.Set("host", "localhost")
+	sc.Set("user", "admin")
+	assert.Equal(t, "db.example.com", c.GetString("database.host"))
+	assert.Empty(t, c.GetString("database.user"))
+	assert.Empty(t, c.GetString("user"))
+
+	c.Set("database.port", 3306)
+	c.Set("database.name", "app")
+	assert.Equal(t, 5432, sc.GetInt("port"))
+	assert.Empty(t, sc.GetString("name"))
+}
+
+func TestSubConfigScoped(t *testing.T) {
+	wipeEnv()
+	c := newSubConfigTestConfig(t)
+
+	sc := c.Scope("database").SubConfig("pool")
+	assert.Equal(t, 10, sc.GetInt("size"))
+
+	ec := c.Extend()
+	ec.Set("database.host", "child.example.com")
+	sc = ec.SubConfig("database")
+	assert.Equal(t, "child.example.com", sc.GetString("host"))
+	assert.Equal(t, 5432, sc.GetInt("port"))
+}
+
+func TestSubConfigSecureKeys(t *testing.T) {
+	wipeEnv()
+	TestHook(t)
+	r := newRegistration("Test SubConfig Secure")
+	r.Key(types.SecureString, "", "", "", "database.password")
+	r.Key(types.SecureString, "", "", "", "app.database.password")
+
+	c := newSubConfigTestConfig(t)
+	c.Set("database.password", "s3cret")
+	c.Set("app.database.password", "s3cret")
+	c.Set("app.database.host", "app.example.com")
+
+	s, err := c.SubConfig("database").ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, s, `"host":"db.example.com"`)
+	assert.NotContains(t, s, "s3cret")
+
+	s, err = c.SubConfig("database").MaskedToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, s, `"password":"`+types.RedactedValue+`"`)
+
+	s, err = c.Scope("app").SubConfig("database").ToJSONCompact()
+	assert.NoError(t, err)
+	assert.Contains(t, s, `"host":"app.example.com"`)
+	assert.NotContains(t, s, "s3cret")
+}
@@ -0,0 +1,62 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// SensitiveTag is the name of the tag that, when attached to a key, causes
+// the key's value to be treated like the value of a SecureString key even if
+// the key is of another type.
+const SensitiveTag = "sensitive"
+
+func (c *config) GetKeyTags(k interface{}) (map[string]string, error) {
+	szK := c.realKey(toString(k))
+	if rk, ok := c.registeredKey(szK); ok {
+		return rk.Tags(), nil
+	}
+	if c.subParent != nil {
+		return c.subParent.GetKeyTags(fmt.Sprintf("%s.%s", c.subPrefix, szK))
+	}
+	return nil, goof.WithField("key", szK, "key is not registered")
+}
+func (c *scopedConfig) GetKeyTags(k interface{}) (map[string]string, error) {
+	szK := toString(k)
+	tags, err := c.Config.GetKeyTags(fmt.Sprintf("%s.%s", c.scope, szK))
+	if err == nil {
+		return tags, nil
+	}
+	if c.Parent() != nil {
+		return c.Parent().GetKeyTags(szK)
+	}
+	return nil, err
+}
+func (c *childConfig) GetKeyTags(k interface{}) (map[string]string, error) {
+	if tags, err := c.config.GetKeyTags(k); err == nil {
+		return tags, nil
+	}
+	return c.parent.GetKeyTags(k)
+}
+
+// registeredKey returns the registered key with the specified name from the
+// registrations registered with the config package or with the config.
+func (c *config) registeredKey(k string) (types.ConfigRegistrationKey, bool) {
+	registrationsRWL.RLock()
+	regs := append([]types.ConfigRegistration{}, registrations...)
+	registrationsRWL.RUnlock()
+
+	var found types.ConfigRegistrationKey
+	lk := strings.ToLower(k)
+	for _, r := range append(regs, c.registrations...) {
+		for rk := range r.Keys() {
+			if found == nil && strings.ToLower(rk.KeyName()) == lk {
+				found = rk
+			}
+		}
+	}
+	return found, found != nil
+}
@@ -0,0 +1,107 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func registerTagsTestKeys() {
+	r := newRegistration("Test Tags")
+	r.Key(types.String, "", "localhost", "The host", "testTags.db.host",
+		types.Tag("label", "Database Host"),
+		types.Tag("category", "database"))
+	r.Key(types.String, "", "", "The token", "testTags.db.token",
+		types.Tag(SensitiveTag, "true"))
+	r.Key(types.Int, "", 5432, "The port", "testTags.db.port")
+	Register(r)
+}
+
+func TestGetKeyTags(t *testing.T) {
+	newConfigDirs("TestGetKeyTags", t)
+	wipeEnv()
+	TestHook(t)
+	registerTagsTestKeys()
+
+	c := New()
+	tags, err := c.GetKeyTags("testTags.db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"label":    "Database Host",
+		"category": "database",
+	}, tags)
+
+	tags["label"] = "modified"
+	tags, err = c.GetKeyTags("testTags.db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "Database Host", tags["label"])
+
+	tags, err = c.GetKeyTags("testTags.db.port")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = c.GetKeyTags("testTags.db.missing")
+	assert.Error(t, err)
+
+	tags, err = c.Scope("testTags").Scope("db").GetKeyTags("host")
+	assert.NoError(t, err)
+	assert.Equal(t, "database", tags["category"])
+}
+
+func TestGetKeyTagsCopy(t *testing.T) {
+	newConfigDirs("TestGetKeyTagsCopy", t)
+	wipeEnv()
+	TestHook(t)
+	registerTagsTestKeys()
+
+	c := New()
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	tags, err := cc.GetKeyTags("testTags.db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "Database Host", tags["label"])
+
+	tags, err = c.Extend().GetKeyTags("testTags.db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "Database Host", tags["label"])
+
+	sc := c.SubConfig("testTags.db")
+	tags, err = sc.GetKeyTags("host")
+	assert.NoError(t, err)
+	assert.Equal(t, "Database Host", tags["label"])
+	scc, err := sc.Copy()
+	assert.NoError(t, err)
+	tags, err = scc.GetKeyTags("host")
+	assert.NoError(t, err)
+	assert.Equal(t, "Database Host", tags["label"])
+
+	r := newRegistration("Test Tags Builder")
+	r.Key(types.String, "", "", "", "testTagsBuilder.host",
+		types.Tag("label", "Builder Host"))
+	bc, err := Builder().WithRegistration(r).Build()
+	assert.NoError(t, err)
+	bcc, err := bc.Copy()
+	assert.NoError(t, err)
+	tags, err = bcc.GetKeyTags("testTagsBuilder.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "Builder Host", tags["label"])
+}
+
+func TestSensitiveTag(t *testing.T) {
+	newConfigDirs("TestSensitiveTag", t)
+	wipeEnv()
+	TestHook(t)
+	registerTagsTestKeys()
+
+	c := New()
+	c.Set("testTags.db.token", "s3cr3t")
+	c.Set("testTags.db.host", "db.example.com")
+	assert.Equal(t, "s3cr3t", c.GetString("testTags.db.token"))
+
+	j, err := c.ToJSONCompact()
+	assert.NoError(t, err)
+	assert.NotContains(t, j, "s3cr3t")
+	assert.Contains(t, j, "db.example.com")
+}
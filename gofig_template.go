@@ -0,0 +1,134 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akutz/gotil"
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+var (
+	// DisableTemplateExpansion determines whether or not Gofig will replace
+	// ${key} references in the values returned by the GetString function with
+	// the values of the referenced keys.
+	DisableTemplateExpansion, _ = strconv.ParseBool(
+		os.Getenv("GOFIG_DISABLE_TEMPLATE_EXPANSION"))
+
+	// TemplateExpansionMaxDepth is the maximum length of a chain of ${key}
+	// references that Gofig will expand.
+	TemplateExpansionMaxDepth = 10
+)
+
+var templateRx = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// TemplateExpansionError is the error that occurs when the ${key} references
+// in a key's value cannot be expanded. The Validate function returns a
+// ConfigError with the ErrCodeTemplateExpansion code and this error as its
+// cause for each such key.
+type TemplateExpansionError struct {
+
+	// Key is the key whose value could not be expanded.
+	Key string
+
+	// Refs is the chain of keys referenced from the key's value, ending with
+	// the key that could not be expanded.
+	Refs []string
+
+	// Circular is true if the chain of references refers back to one of the
+	// keys in the chain.
+	Circular bool
+}
+
+func (e *TemplateExpansionError) Error() string {
+	chain := strings.Join(append([]string{e.Key}, e.Refs...), " -> ")
+	if e.Circular {
+		return fmt.Sprintf("circular template reference: %s", chain)
+	}
+	return fmt.Sprintf(
+		"template references exceed max depth of %d: %s",
+		TemplateExpansionMaxDepth, chain)
+}
+
+// expandString replaces the ${key} references in the value of the key. If
+// the references cannot be expanded the error is logged and the value is
+// returned unchanged.
+func (c *config) expandString(k, s string) string {
+	if DisableTemplateExpansion {
+		return s
+	}
+	es, err := c.expandTemplates(k, s)
+	if err != nil {
+		logger().Error(
+			"error expanding config templates", "key", k, "error", err)
+		return s
+	}
+	return es
+}
+
+// checkTemplates returns an error for each key in the config whose ${key}
+// references cannot be expanded.
+func checkTemplates(c types.Config) []ConfigError {
+	cc, ok := unwrapConfig(c)
+	if !ok || DisableTemplateExpansion {
+		return nil
+	}
+	keys := cc.AllKeys()
+	sort.Strings(keys)
+	errs := []ConfigError{}
+	for _, k := range keys {
+		s, ok := cc.get(k).(string)
+		if !ok || !templateRx.MatchString(s) {
+			continue
+		}
+		if _, err := cc.expandTemplates(k, s); err != nil {
+			errs = append(errs, NewConfigError(
+				ErrCodeTemplateExpansion, strings.ToLower(k), err))
+		}
+	}
+	return errs
+}
+
+// expandTemplates replaces the ${key} references in the value of the key
+// with the values of the referenced keys. References to keys that are not
+// set are not replaced.
+func (c *config) expandTemplates(k, s string) (string, error) {
+	return c.expandTemplateRefs(strings.ToLower(k), s, nil)
+}
+
+func (c *config) expandTemplateRefs(
+	k, s string, refs []string) (string, error) {
+
+	var err error
+	s = templateRx.ReplaceAllStringFunc(s, func(m string) string {
+		if err != nil {
+			return m
+		}
+		rk := strings.ToLower(realKey(templateRx.FindStringSubmatch(m)[1]))
+		rrefs := append(append([]string{}, refs...), rk)
+		if rk == k || gotil.StringInSlice(rk, refs) {
+			err = &TemplateExpansionError{Key: k, Refs: rrefs, Circular: true}
+			return m
+		}
+		if len(rrefs) > TemplateExpansionMaxDepth {
+			err = &TemplateExpansionError{Key: k, Refs: rrefs}
+			return m
+		}
+		c.rwl.RLock()
+		v := c.b.Get(rk)
+		c.rwl.RUnlock()
+		if v == nil {
+			return m
+		}
+		var es string
+		es, err = c.expandTemplateRefs(k, cast.ToString(v), rrefs)
+		return es
+	})
+	return s, err
+}
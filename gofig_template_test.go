@@ -0,0 +1,103 @@
+package gofig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestTemplateExpansion(t *testing.T) {
+	newConfigDirs("TestTemplateExpansion", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("app.dataDir", "/var/lib/app")
+	c.Set("app.logDir", "${app.dataDir}/logs")
+	c.Set("app.logFile", "${app.logDir}/app.log")
+	c.Set("app.missing", "${app.unknown}/x")
+
+	assertString(t, c, "app.logDir", "/var/lib/app/logs")
+	assertString(t, c, "app.logFile", "/var/lib/app/logs/app.log")
+	assertString(t, c, "app.missing", "${app.unknown}/x")
+	assert.Equal(t, "/var/lib/app/logs", c.Scope("app").GetString("logDir"))
+	assert.Equal(t, "${app.dataDir}/logs", c.Get("app.logDir"))
+
+	DisableTemplateExpansion = true
+	assertString(t, c, "app.logDir", "${app.dataDir}/logs")
+	DisableTemplateExpansion = false
+}
+
+func TestTemplateExpansionSecureKey(t *testing.T) {
+	newConfigDirs("TestTemplateExpansionSecureKey", t)
+	wipeEnv()
+
+	r := newRegistration("Test Template Expansion")
+	r.Key(types.SecureString, "", "", "", "testTemplate.password")
+
+	c := New()
+	c.Set("testTemplate.password", "secret")
+	c.Set("testTemplate.dsn", "user:${testTemplate.password}@db")
+	assertString(t, c, "testTemplate.dsn", "user:secret@db")
+	assert.False(t, isSecureKey("testTemplate.dsn"))
+}
+
+func TestTemplateExpansionErrors(t *testing.T) {
+	newConfigDirs("TestTemplateExpansionErrors", t)
+	wipeEnv()
+
+	c := newConfig()
+	c.Set("a", "${b}")
+	c.Set("b", "${c}")
+	c.Set("c", "${a}")
+
+	_, err := c.expandTemplates("a", c.b.Get("a").(string))
+	if assert.IsType(t, &TemplateExpansionError{}, err) {
+		terr := err.(*TemplateExpansionError)
+		assert.True(t, terr.Circular)
+		assert.Equal(t, []string{"b", "c", "a"}, terr.Refs)
+	}
+	assertString(t, c, "a", "${b}")
+
+	defer func(d int) { TemplateExpansionMaxDepth = d }(
+		TemplateExpansionMaxDepth)
+	TemplateExpansionMaxDepth = 1
+	c.Set("c", "end")
+	_, err = c.expandTemplates("a", c.b.Get("a").(string))
+	if assert.IsType(t, &TemplateExpansionError{}, err) {
+		assert.False(t, err.(*TemplateExpansionError).Circular)
+	}
+	TemplateExpansionMaxDepth = 2
+	assertString(t, c, "a", "end")
+}
+
+func TestValidateTemplateExpansion(t *testing.T) {
+	newConfigDirs("TestValidateTemplateExpansion", t)
+	wipeEnv()
+
+	c := newConfig()
+	c.Set("testTemplate.dir", "/var/lib/app")
+	c.Set("testTemplate.logDir", "${testTemplate.dir}/logs")
+	assert.NoError(t, Validate(c))
+
+	c.Set("testTemplate.a", "${testTemplate.b}")
+	c.Set("testTemplate.b", "${testTemplate.a}")
+	err := Validate(c.Scope("testTemplate"))
+	if assert.IsType(t, &ValidationError{}, err) {
+		verr := err.(*ValidationError)
+		if assert.Len(t, verr.Errors, 2) {
+			assert.Equal(t, ErrCodeTemplateExpansion, verr.Errors[0].Code())
+			assert.Equal(t, "testtemplate.a", verr.Errors[0].Key())
+			var terr *TemplateExpansionError
+			assert.True(t, errors.As(verr.Errors[0], &terr))
+			assert.True(t, terr.Circular)
+			assert.Equal(t, "testtemplate.b", verr.Errors[1].Key())
+		}
+	}
+
+	DisableTemplateExpansion = true
+	assert.NoError(t, Validate(c))
+	DisableTemplateExpansion = false
+}
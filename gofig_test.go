@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"os"
 	"path"
 	"reflect"
@@ -11,8 +12,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/akutz/goof"
 	"github.com/akutz/gotil"
-	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	//jww "github.com/spf13/jwalterweatherman"
 
@@ -25,7 +26,7 @@ var (
 
 func TestMain(m *testing.M) {
 	if debug, _ := strconv.ParseBool(os.Getenv("GOFIG_DEBUG")); debug {
-		log.SetLevel(log.DebugLevel)
+		SetLogger(newDebugLogger())
 		//jww.SetStdoutThreshold(jww.LevelTrace)
 	}
 	Register(testReg1())
@@ -46,12 +47,12 @@ func newConfigDirs(testName string, t *testing.T) (string, string) {
 
 	etcDirPath := fmt.Sprintf("%s/etc/gofig", tmpDir)
 	usrDirPath := fmt.Sprintf("%s/home/gofig", tmpDir)
-	SetGlobalConfigPath(etcDirPath)
-	SetUserConfigPath(usrDirPath)
-
 	os.MkdirAll(etcDirPath, 0755)
 	os.MkdirAll(usrDirPath, 0755)
 
+	MustSetGlobalConfigPath(etcDirPath)
+	MustSetUserConfigPath(usrDirPath)
+
 	etcFilePath := fmt.Sprintf("%s/config.yml", etcDirPath)
 	usrFilePath := fmt.Sprintf("%s/config.yml", usrDirPath)
 
@@ -146,12 +147,17 @@ func assertConfigsEqual(c1 types.Config, c2 types.Config, t *testing.T) bool {
 }
 
 func TestSetLogLevel(t *testing.T) {
-	log.SetLevel(log.DebugLevel)
+	SetLogger(newDebugLogger())
 	New()
-	log.SetLevel(log.InfoLevel)
+	SetLogger(nil)
 	New()
 }
 
+func newDebugLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(
+		os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
 func TestValidateYAML(t *testing.T) {
 
 	var err error
@@ -196,6 +202,35 @@ hi:
 	assert.NoError(t, err)
 }
 
+func TestValidateJSON(t *testing.T) {
+
+	m, err := ValidateJSONString(`{"hi": {"hello": "you"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "you", m["hi"].(map[string]interface{})["hello"])
+
+	m, err = ValidateJSONString(`{"a": {"b": {"c": {"d": {"e": [1, 2]}}}}}`)
+	assert.NoError(t, err)
+	m = m["a"].(map[string]interface{})["b"].(map[string]interface{})
+	m = m["c"].(map[string]interface{})["d"].(map[string]interface{})
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, m["e"])
+
+	_, err = ValidateJSONString(`{"hi": "you",}`)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "byte offset 14")
+	assert.EqualValues(t, 14, err.(*goof.Goof).Fields()["offset"])
+
+	_, err = ValidateJSONString(`{"hi": `)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "byte offset")
+
+	_, err = ValidateJSONString(`[{"hi": "you"}]`)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not an object")
+
+	_, err = ValidateJSONString(`"hi"`)
+	assert.Error(t, err)
+}
+
 func TestAssertConfigDefaults(t *testing.T) {
 	newConfigDirs("TestAssertConfigDefaults", t)
 	wipeEnv()
@@ -361,6 +396,110 @@ func TestFromJSONWithErrors(t *testing.T) {
 	}
 }
 
+func TestToYAML(t *testing.T) {
+	newConfigDirs("TestToYAML", t)
+	wipeEnv()
+	c := New()
+	if err := c.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newRegistration("Test ToYAML")
+	r.Key(types.SecureString, "", "", "", "mockProvider.password")
+	c.Set("mockProvider.password", "i should be hidden")
+
+	yamlStr, err := c.ToYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(yamlStr)
+
+	_, err = ValidateYAMLString(yamlStr)
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(yamlStr, "i should be hidden"))
+	assert.True(t, strings.Contains(yamlStr, "\n  loglevel: error\n"))
+
+	c2, err := FromYAML(yamlStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c2, "rexray.logLevel", "error")
+	assertString(t, c2, "mockProvider.password", "")
+	assertStorageDrivers(t, c2)
+	assertOsDrivers1(t, c2)
+	assert.Equal(t, 32, c2.GetInt("mockProvider.docker.minVolSize"))
+}
+
+func TestFromYAMLWithErrors(t *testing.T) {
+	_, err := FromYAML("hello, world")
+	if err == nil {
+		t.Fatal("expected unmarshalling error")
+	}
+}
+
+func TestToTOML(t *testing.T) {
+	newConfigDirs("TestToTOML", t)
+	wipeEnv()
+	c := New()
+	if err := c.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+
+	tomlStr, err := c.ToTOML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(tomlStr)
+
+	c2, err := FromTOML(tomlStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c2, "rexray.logLevel", "error")
+	assertStorageDrivers(t, c2)
+	assertOsDrivers1(t, c2)
+	assert.Equal(t, 32, c2.GetInt("mockProvider.docker.minVolSize"))
+}
+
+func TestFromTOMLWithErrors(t *testing.T) {
+	_, err := FromTOML("///*")
+	if err == nil {
+		t.Fatal("expected unmarshalling error")
+	}
+}
+
+func TestReadConfigTOML(t *testing.T) {
+	newConfigDirs("TestReadConfigTOML", t)
+	wipeEnv()
+	c := New()
+	if err := c.ReadConfig(bytes.NewReader(tomlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c, "rexray.logLevel", "error")
+	assertStorageDrivers(t, c)
+	assertOsDrivers1(t, c)
+
+	if err := c.ReadConfig(bytes.NewReader(yamlConfig2)); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c, "rexray.logLevel", "debug")
+	assertOsDrivers2(t, c)
+}
+
+func TestNewWithUserConfigFileTOML(t *testing.T) {
+	_, usrCfgFilePath := newConfigDirs("TestNewWithUserConfigFileTOML", t)
+	wipeEnv()
+	usrCfgFilePath = path.Join(path.Dir(usrCfgFilePath), "config.toml")
+	gotil.WriteStringToFile(string(tomlConfig1), usrCfgFilePath)
+
+	c := NewConfig(WithConfigType("toml"))
+
+	assertString(t, c, "rexray.host", "tcp://:7979")
+	assertString(t, c, "rexray.logLevel", "error")
+	assertStorageDrivers(t, c)
+	assertOsDrivers1(t, c)
+}
+
 func TestEnvVars(t *testing.T) {
 	newConfigDirs("TestEnvVars", t)
 	wipeEnv()
@@ -379,7 +518,7 @@ func TestEnvVars(t *testing.T) {
 
 	assertEnvVar("REXRAY_HOST=tcp://:7979", fev, t)
 	assertEnvVar("REXRAY_LOGLEVEL=error", fev, t)
-	assertEnvVar("REXRAY_STORAGEDRIVERS=ec2 xtremio", fev, t)
+	assertEnvVar("REXRAY_STORAGEDRIVERS=ec2,xtremio", fev, t)
 	assertEnvVar("REXRAY_OSDRIVERS=linux", fev, t)
 	assertEnvVar("REXRAY_VOLUMEDRIVERS=docker", fev, t)
 	assertEnvVar("MOCKPROVIDER_USERNAME=admin", fev, t)
@@ -497,7 +636,7 @@ func TestReadConfigFile(t *testing.T) {
 }
 
 func TestReadConfig(t *testing.T) {
-	c := NewConfig(false, false, "config", "yml")
+	c := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
 	if err := c.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
 		t.Fatal(err)
 	}
@@ -515,6 +654,35 @@ func TestReadConfig(t *testing.T) {
 	assertOsDrivers2(t, c)
 }
 
+func TestReadConfigFromReader(t *testing.T) {
+	wipeEnv()
+	c := NewConfig(
+		WithGlobalConfig(false), WithUserConfig(false), WithConfigType("yml"))
+	if err := c.ReadConfigFromReader(strings.NewReader(
+		`{"rexray": {"logLevel": "warn"}}`), "json"); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c, "rexray.logLevel", "warn")
+
+	flow := `{rexray: {logLevel: info}}`
+	if err := c.ReadConfig(strings.NewReader(flow)); err == nil {
+		t.Fatal("expected error reading yaml flow mapping as json")
+	}
+	if err := c.Scope("rexray").ReadConfigFromReader(
+		strings.NewReader(flow), "YAML"); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c, "rexray.logLevel", "info")
+
+	if err := c.ReadConfigFromReader(
+		strings.NewReader(flow), "ini"); err == nil {
+		t.Fatal("expected unsupported format error")
+	}
+	if err := c.ReadConfigFromReader(nil, "json"); err == nil {
+		t.Fatal("expected nil config error")
+	}
+}
+
 func TestReadNilConfig(t *testing.T) {
 	if err := New().ReadConfig(nil); err == nil {
 		t.Fatal("expected nil config error")
@@ -562,6 +730,62 @@ func TestScope(t *testing.T) {
 	assert.Equal(t, false, scc.GetBool("loggingEnabled"))
 }
 
+func TestScopeAllKeysAndSettings(t *testing.T) {
+	newConfigDirs("TestScopeAllKeysAndSettings", t)
+	wipeEnv()
+	c := New()
+	if err := c.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+
+	sc := c.Scope("mockProvider")
+
+	ak := sc.AllKeys()
+	t.Log(ak)
+	assert.Contains(t, ak, "username")
+	assert.Contains(t, ak, "usecerts")
+	assert.Contains(t, ak, "docker.minvolsize")
+	for _, k := range ak {
+		assert.False(t, strings.HasPrefix(k, "rexray"))
+		assert.False(t, strings.HasPrefix(k, "mockprovider"))
+	}
+
+	as := sc.AllSettings()
+	assert.Equal(t, "admin", as["username"])
+	assert.Equal(t, true, as["usecerts"])
+	docker, ok := as["docker"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, 32, docker["minvolsize"])
+	_, ok = as["rexray"]
+	assert.False(t, ok)
+
+	scc := sc.Scope("docker")
+	assert.Contains(t, scc.AllKeys(), "minvolsize")
+	assert.NotContains(t, scc.AllKeys(), "username")
+	assert.Equal(t, 32, scc.AllSettings()["minvolsize"])
+
+	ak = c.Scope("rexray").AllKeys()
+	assert.Contains(t, ak, "loglevel")
+	assert.NotContains(t, ak, "username")
+}
+
+func TestGetScopeChain(t *testing.T) {
+	newConfigDirs("TestGetScopeChain", t)
+	wipeEnv()
+	c := New()
+
+	assert.Equal(t, "", c.GetScope())
+	assert.Empty(t, c.GetScopeChain())
+
+	sc := c.Scope("mockProvider").Scope("docker")
+	assert.Equal(t, "docker", sc.GetScope())
+	assert.Equal(t, []string{"mockProvider", "docker"}, sc.GetScopeChain())
+	assert.Equal(t, []string{"mockProvider"}, sc.Parent().GetScopeChain())
+	assert.Equal(t,
+		[]string{"mockProvider", "docker", "size"},
+		ReadOnly(sc).Scope("size").GetScopeChain())
+}
+
 func TestKeyNames(t *testing.T) {
 	r := newRegistration("Test Reg 4")
 	r.Key(types.String, "", "", "", "testReg4.host")
@@ -730,7 +954,7 @@ func printKeys(title string, c types.Config, t *testing.T) {
 
 func printViperKeys(title string, c types.Config, t *testing.T) {
 	tc := c.(*config)
-	for _, k := range tc.v.AllKeys() {
+	for _, k := range tc.backendKeys() {
 		if title == "" {
 			t.Logf(k)
 		} else {
@@ -827,6 +1051,20 @@ rexray:
     - linux
 `)
 
+var tomlConfig1 = []byte(`
+[rexray]
+logLevel = "error"
+storageDrivers = ["ec2", "xtremio"]
+osDrivers = ["linux"]
+
+[mockProvider]
+userName = "admin"
+useCerts = true
+
+[mockProvider.docker]
+MinVolSize = 32
+`)
+
 var jsonConfigBaseline = `{
     "mockprovider": {
         "docker": {
@@ -943,3 +1181,33 @@ func testReg3a() *configReg {
 	r.Key(types.String, "", "", "", "testReg3.keyFiles.prvKey")
 	return r
 }
+
+func TestSetConfigPath(t *testing.T) {
+	newConfigDirs("TestSetConfigPath", t)
+	etc, usr := etcDirPath, usrDirPath
+	defer func() {
+		etcDirPath, usrDirPath = etc, usr
+	}()
+
+	assert.Error(t, SetGlobalConfigPath("etc/gofig"))
+	assert.Error(t, SetUserConfigPath("home/gofig"))
+	assert.Equal(t, etc, etcDirPath)
+	assert.Equal(t, usr, usrDirPath)
+	assert.Panics(t, func() { MustSetGlobalConfigPath("etc/gofig") })
+	assert.Panics(t, func() { MustSetUserConfigPath("") })
+
+	f := path.Join(etc, "config.yml")
+	assert.NoError(t, ioutil.WriteFile(f, []byte("a: b\n"), 0644))
+	assert.Error(t, SetGlobalConfigPath(f))
+
+	missing := path.Join(path.Dir(etc), "missing")
+	assert.NoError(t, SetGlobalConfigPath(missing))
+	assert.Equal(t, missing, etcDirPath)
+
+	strict := StrictMode
+	StrictMode = true
+	defer func() { StrictMode = strict }()
+	assert.Error(t, SetUserConfigPath(missing))
+	assert.Equal(t, usr, usrDirPath)
+	assert.NoError(t, SetUserConfigPath(usr))
+}
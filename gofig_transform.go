@@ -0,0 +1,96 @@
+package gofig
+
+import (
+	"bytes"
+	"unicode"
+)
+
+var keyTransformer func(string) string
+
+// SetKeyTransformer sets the function used to transform the names of keys
+// before they are resolved to registered keys. The transformer is applied to
+// the keys passed to the Get and Set functions and to the names of the
+// registered keys when their environment variables are bound, so callers may
+// use a different spelling of a key than the one used to register it. The
+// transformer should return its input unchanged if the input was already
+// transformed. A nil function removes the transformer.
+//
+// The CamelToSnake and SnakeToCamel functions are built-in transformers.
+func SetKeyTransformer(fn func(string) string) {
+	normalizedKeysRWL.Lock()
+	defer normalizedKeysRWL.Unlock()
+	keyTransformer = fn
+}
+
+// WithKeyTransformer configures the function used to transform the names of
+// the config's keys instead of the transformer set with SetKeyTransformer.
+func WithKeyTransformer(fn func(string) string) ConfigOption {
+	return func(c *config) {
+		c.keyTransformer = fn
+	}
+}
+
+// CamelToSnake transforms a camelCase key name into a snake_case key name,
+// ex. "service.maxRetries" becomes "service.max_retries".
+func CamelToSnake(k string) string {
+	b := &bytes.Buffer{}
+	var prev rune
+	for _, r := range k {
+		if unicode.IsUpper(r) &&
+			(unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+		prev = r
+	}
+	return b.String()
+}
+
+// SnakeToCamel transforms a snake_case key name into a camelCase key name,
+// ex. "service.max_retries" becomes "service.maxRetries".
+func SnakeToCamel(k string) string {
+	b := &bytes.Buffer{}
+	var prev rune
+	for _, r := range k {
+		switch {
+		case r == '_':
+		case prev == '_' && unicode.IsLetter(r) &&
+			b.Len() > 0 && !bytes.HasSuffix(b.Bytes(), []byte(".")):
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// transformKey returns the key transformed by the package's key transformer.
+func transformKey(k string) string {
+	normalizedKeysRWL.RLock()
+	fn := keyTransformer
+	normalizedKeysRWL.RUnlock()
+	if fn == nil {
+		return k
+	}
+	return fn(k)
+}
+
+// realKey returns the name of the registered key for the key after the key
+// is transformed by the config's key transformer, if there is one, or the
+// package's key transformer.
+func (c *config) realKey(k string) string {
+	if c.keyTransformer == nil {
+		return realKey(k)
+	}
+	return normalizeKey(aliasedKey(c.keyTransformer(k)))
+}
+
+// transformKey returns the key transformed by the config's key transformer,
+// if there is one, or the package's key transformer.
+func (c *config) transformKey(k string) string {
+	if c.keyTransformer == nil {
+		return transformKey(k)
+	}
+	return c.keyTransformer(k)
+}
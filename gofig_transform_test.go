@@ -0,0 +1,85 @@
+package gofig
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestCamelToSnake(t *testing.T) {
+	assert.Equal(t, "service.max_retries", CamelToSnake("service.maxRetries"))
+	assert.Equal(t, "service.max_retries", CamelToSnake("service.max_retries"))
+	assert.Equal(t, "v2_api.url", CamelToSnake("v2Api.url"))
+	assert.Equal(t, "", CamelToSnake(""))
+}
+
+func TestSnakeToCamel(t *testing.T) {
+	assert.Equal(t, "service.maxRetries", SnakeToCamel("service.max_retries"))
+	assert.Equal(t, "service.maxRetries", SnakeToCamel("service.maxRetries"))
+	assert.Equal(t, "service.retries", SnakeToCamel("service._retries"))
+	assert.Equal(t, "", SnakeToCamel(""))
+}
+
+func TestKeyTransformer(t *testing.T) {
+	newConfigDirs("TestKeyTransformer", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test Transformer")
+	r.Key(types.Int, "", 3, "The max retries", "testXform.maxRetries")
+	Register(r)
+
+	SetKeyTransformer(func(k string) string {
+		return strings.Replace(k, "::", ".", -1)
+	})
+	c := New()
+	assert.Equal(t, 3, c.GetInt("testXform::maxRetries"))
+	c.Set("testXform::maxRetries", 5)
+	assert.Equal(t, 5, c.GetInt("testXform.maxRetries"))
+	assert.True(t, c.IsSet("testXform::maxRetries"))
+
+	SetKeyTransformer(CamelToSnake)
+	os.Setenv("TESTXFORM_MAX_RETRIES", "7")
+	defer os.Unsetenv("TESTXFORM_MAX_RETRIES")
+	c = New()
+	assert.Equal(t, 7, c.GetInt("testXform.maxRetries"))
+	assert.Equal(t, 7, c.GetInt("test_xform.max_retries"))
+	assert.Equal(t, types.EnvVar, c.GetSource("testXform.maxRetries"))
+	os.Setenv("TESTXFORM_MAX_RETRIES", "")
+
+	SetKeyTransformer(nil)
+	c = New()
+	assert.Equal(t, 0, c.GetInt("testXform::maxRetries"))
+	assert.Equal(t, 3, c.GetInt("testXform.maxRetries"))
+}
+
+func TestWithKeyTransformer(t *testing.T) {
+	newConfigDirs("TestWithKeyTransformer", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test With Transformer")
+	r.Key(types.Int, "", 3, "The max retries", "testXform.maxRetries")
+	Register(r)
+
+	prefix := func(k string) string {
+		if strings.HasPrefix(k, "testXform.") {
+			return k
+		}
+		return "testXform." + k
+	}
+	c := New(WithKeyTransformer(prefix))
+	assert.Equal(t, 3, c.GetInt("maxRetries"))
+	c.Set("max_retries", 4)
+	assert.Equal(t, 4, c.GetInt("testXform.maxRetries"))
+
+	cc, err := c.Copy()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, cc.GetInt("maxRetries"))
+
+	assert.Equal(t, 0, New().GetInt("maxRetries"))
+}
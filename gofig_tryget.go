@@ -0,0 +1,53 @@
+package gofig
+
+import (
+	"fmt"
+)
+
+func (c *config) TryGetString(k interface{}) (string, bool) {
+	s := c.GetString(k)
+	return s, s != ""
+}
+func (c *scopedConfig) TryGetString(k interface{}) (string, bool) {
+	szK := toString(k)
+	if v, ok := c.Config.TryGetString(
+		fmt.Sprintf("%s.%s", c.scope, szK)); ok {
+		return v, true
+	}
+	if c.Parent() != nil {
+		return c.Parent().TryGetString(szK)
+	}
+	return "", false
+}
+
+func (c *config) TryGetInt(k interface{}) (int, bool) {
+	i := c.GetInt(k)
+	return i, i != 0
+}
+func (c *scopedConfig) TryGetInt(k interface{}) (int, bool) {
+	szK := toString(k)
+	if v, ok := c.Config.TryGetInt(
+		fmt.Sprintf("%s.%s", c.scope, szK)); ok {
+		return v, true
+	}
+	if c.Parent() != nil {
+		return c.Parent().TryGetInt(szK)
+	}
+	return 0, false
+}
+
+func (c *config) TryGetBool(k interface{}) (bool, bool) {
+	b := c.GetBool(k)
+	return b, b
+}
+func (c *scopedConfig) TryGetBool(k interface{}) (bool, bool) {
+	szK := toString(k)
+	if v, ok := c.Config.TryGetBool(
+		fmt.Sprintf("%s.%s", c.scope, szK)); ok {
+		return v, true
+	}
+	if c.Parent() != nil {
+		return c.Parent().TryGetBool(szK)
+	}
+	return false, false
+}
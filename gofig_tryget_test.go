@@ -0,0 +1,101 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestTryGet(t *testing.T) {
+	newConfigDirs("TestTryGet", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testTryGet.name", "gofig")
+	c.Set("testTryGet.count", 3)
+	c.Set("testTryGet.enabled", true)
+
+	s, ok := c.TryGetString("testTryGet.name")
+	assert.True(t, ok)
+	assert.Equal(t, "gofig", s)
+	i, ok := c.TryGetInt("testTryGet.count")
+	assert.True(t, ok)
+	assert.Equal(t, 3, i)
+	b, ok := c.TryGetBool("testTryGet.enabled")
+	assert.True(t, ok)
+	assert.True(t, b)
+
+	s, ok = c.TryGetString("testTryGet.missing")
+	assert.False(t, ok)
+	assert.Equal(t, "", s)
+
+	sc := c.Scope("testTryGet")
+	i, ok = sc.TryGetInt("count")
+	assert.True(t, ok)
+	assert.Equal(t, 3, i)
+	s, ok = sc.TryGetString("rexray.host")
+	assert.True(t, ok)
+	assert.Equal(t, "tcp://:7979", s)
+	_, ok = sc.TryGetBool("missing")
+	assert.False(t, ok)
+}
+
+func TestTryGetZeroValue(t *testing.T) {
+	newConfigDirs("TestTryGetZeroValue", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testTryGet.name", "")
+	c.Set("testTryGet.count", 0)
+	c.Set("testTryGet.enabled", false)
+
+	assert.True(t, c.IsSet("testTryGet.name"))
+	assert.True(t, c.IsSet("testTryGet.count"))
+	assert.True(t, c.IsSet("testTryGet.enabled"))
+
+	s, ok := c.TryGetString("testTryGet.name")
+	assert.False(t, ok)
+	assert.Equal(t, "", s)
+	i, ok := c.TryGetInt("testTryGet.count")
+	assert.False(t, ok)
+	assert.Equal(t, 0, i)
+	b, ok := c.TryGetBool("testTryGet.enabled")
+	assert.False(t, ok)
+	assert.False(t, b)
+}
+
+func TestTryGetStringLikeGetString(t *testing.T) {
+	newConfigDirs("TestTryGetStringLikeGetString", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test TryGet")
+	r.Key(types.StringEnum, "", "info", "The log level",
+		"testTryGet.logLevel", []string{"debug", "info"})
+	r.Key(types.SecureString, "", "", "", "testTryGet.password")
+	Register(r)
+	RegisterResolver("testTryGet.password", &mockResolver{
+		vals: map[string]string{"testTryGet.password": "p1"}})
+	defer RegisterResolver("testTryGet.password", nil)
+
+	c := New()
+	c.Set("testTryGet.dir", "/var/lib/app")
+	c.Set("testTryGet.logDir", "${testTryGet.dir}/logs")
+	c.Set("testTryGet.logLevel", "trace")
+
+	for _, k := range []string{
+		"testTryGet.logDir", "testTryGet.logLevel", "testTryGet.password",
+	} {
+		s, ok := c.TryGetString(k)
+		assert.True(t, ok, k)
+		assert.Equal(t, c.GetString(k), s, k)
+	}
+	s, _ := c.TryGetString("testTryGet.logDir")
+	assert.Equal(t, "/var/lib/app/logs", s)
+	s, _ = c.TryGetString("testTryGet.logLevel")
+	assert.Equal(t, "info", s)
+	s, _ = c.Scope("testTryGet").TryGetString("password")
+	assert.Equal(t, "p1", s)
+}
@@ -0,0 +1,218 @@
+package gofig
+
+import (
+	"container/heap"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/akutz/gofig/backend"
+)
+
+// TTLCheckInterval is the interval at which the override values set with
+// SetWithTTL are checked in the background for expiration.
+var TTLCheckInterval = time.Second
+
+// ttlEntry is the expiration time of an override value.
+type ttlEntry struct {
+	key     string
+	expires time.Time
+	index   int
+}
+
+// ttlHeap is a min-heap of expiration entries ordered by expiration time.
+type ttlHeap []*ttlEntry
+
+func (h ttlHeap) Len() int {
+	return len(h)
+}
+
+func (h ttlHeap) Less(i, j int) bool {
+	return h[i].expires.Before(h[j].expires)
+}
+
+func (h ttlHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ttlHeap) Push(x interface{}) {
+	e := x.(*ttlEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *ttlHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+func (c *config) SetWithTTL(k interface{}, v interface{}, ttl time.Duration) {
+	szK := c.realKey(toString(k))
+	c.Set(szK, v)
+	c.addTTL(szK, time.Now().Add(ttl))
+}
+func (c *scopedConfig) SetWithTTL(
+	k interface{}, v interface{}, ttl time.Duration) {
+	szK := toString(k)
+	c.Config.SetWithTTL(fmt.Sprintf("%s.%s", c.scope, szK), v, ttl)
+}
+
+func (c *config) ClearExpired() {
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	c.clearExpired(time.Now())
+}
+
+// addTTL records the expiration time of the key's override value, replacing
+// any previous expiration time, and starts the background expiration check
+// if it is not running.
+func (c *config) addTTL(k string, expires time.Time) {
+	lk := strings.ToLower(k)
+	c.ttlRWL.Lock()
+	defer c.ttlRWL.Unlock()
+	if e, ok := c.ttlKeys[lk]; ok {
+		e.expires = expires
+		heap.Fix(&c.ttls, e.index)
+	} else {
+		e := &ttlEntry{key: lk, expires: expires}
+		heap.Push(&c.ttls, e)
+		c.ttlKeys[lk] = e
+		atomic.AddInt32(&c.ttlCount, 1)
+	}
+	if !c.ttlRunning {
+		c.ttlRunning = true
+		go c.expireTTLsPeriodically(TTLCheckInterval)
+	}
+}
+
+// removeTTL removes the expiration time of the key's override value.
+func (c *config) removeTTL(k string) {
+	if atomic.LoadInt32(&c.ttlCount) == 0 {
+		return
+	}
+	lk := strings.ToLower(k)
+	c.ttlRWL.Lock()
+	defer c.ttlRWL.Unlock()
+	if e, ok := c.ttlKeys[lk]; ok {
+		heap.Remove(&c.ttls, e.index)
+		delete(c.ttlKeys, lk)
+		atomic.AddInt32(&c.ttlCount, -1)
+	}
+}
+
+// clearTTLs removes the expiration times of all override values.
+func (c *config) clearTTLs() {
+	c.ttlRWL.Lock()
+	defer c.ttlRWL.Unlock()
+	c.ttls = nil
+	c.ttlKeys = map[string]*ttlEntry{}
+	atomic.StoreInt32(&c.ttlCount, 0)
+}
+
+// popExpiredTTLs removes the expiration entries that expired before the
+// specified time and returns their keys.
+func (c *config) popExpiredTTLs(now time.Time) map[string]bool {
+	c.ttlRWL.Lock()
+	defer c.ttlRWL.Unlock()
+	keys := map[string]bool{}
+	for c.ttls.Len() > 0 && !c.ttls[0].expires.After(now) {
+		e := heap.Pop(&c.ttls).(*ttlEntry)
+		delete(c.ttlKeys, e.key)
+		atomic.AddInt32(&c.ttlCount, -1)
+		keys[e.key] = true
+	}
+	return keys
+}
+
+// hasExpiredTTLs returns a flag indicating whether or not any override values
+// expired before the specified time.
+func (c *config) hasExpiredTTLs(now time.Time) bool {
+	c.ttlRWL.RLock()
+	defer c.ttlRWL.RUnlock()
+	return c.ttls.Len() > 0 && !c.ttls[0].expires.After(now)
+}
+
+// clearExpired removes the override values that expired before the specified
+// time. The config's lock must be held. The config is rebuilt without the
+// expired values only if its backend cannot remove an override value.
+func (c *config) clearExpired(now time.Time) {
+	keys := c.popExpiredTTLs(now)
+	if len(keys) == 0 {
+		return
+	}
+	u, ok := c.b.(backend.Unsetter)
+	if !ok {
+		c.rebuildWithoutOverrides(keys)
+		return
+	}
+	notify := c.hasChangeCallbacks()
+	oldVals := map[string]interface{}{}
+	for k := range keys {
+		if notify {
+			oldVals[k] = c.b.Get(k)
+		}
+		u.Unset(k)
+		delete(c.overrideKeys, k)
+	}
+	c.publishSnapshot()
+	if !notify {
+		return
+	}
+	for k, oldVal := range oldVals {
+		c.notifyChange(k, oldVal, c.b.Get(k))
+	}
+}
+
+// rebuildWithoutOverrides rebuilds the config without the override values of
+// the specified keys. The config's lock must be held.
+func (c *config) rebuildWithoutOverrides(keys map[string]bool) {
+	overrides := map[string]interface{}{}
+	for k := range c.overrideKeys {
+		if !keys[k] {
+			overrides[k] = c.b.Get(k)
+		}
+	}
+	if err := c.resetWithOverrides(c.reads, overrides); err != nil {
+		logger().Error("error clearing expired values", "error", err)
+	}
+}
+
+// expireTTLs removes the expired override values when a key is read so that
+// the read returns the value the key has without the expired override. The
+// read waits for the config's lock if any of the values have expired.
+func (c *config) expireTTLs() {
+	if atomic.LoadInt32(&c.ttlCount) == 0 {
+		return
+	}
+	now := time.Now()
+	if !c.hasExpiredTTLs(now) {
+		return
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	c.clearExpired(now)
+}
+
+// expireTTLsPeriodically removes the expired override values at the
+// specified interval until there are no more values with expiration times.
+func (c *config) expireTTLsPeriodically(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for range t.C {
+		c.ClearExpired()
+		c.ttlRWL.Lock()
+		if c.ttls.Len() == 0 {
+			c.ttlRunning = false
+			c.ttlRWL.Unlock()
+			return
+		}
+		c.ttlRWL.Unlock()
+	}
+}
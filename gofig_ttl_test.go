@@ -0,0 +1,149 @@
+package gofig
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/backend"
+	"github.com/akutz/gofig/types"
+)
+
+func TestSetWithTTL(t *testing.T) {
+	newConfigDirs("TestSetWithTTL", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test TTL")
+	r.Key(types.Int, "", 10, "The rate limit", "testTTL.rateLimit")
+	Register(r)
+
+	c := New()
+	c.Set("testTTL.host", "host0")
+
+	c.SetWithTTL("testTTL.rateLimit", 20, 50*time.Millisecond)
+	c.SetWithTTL("testTTL.flag", true, 50*time.Millisecond)
+	assert.Equal(t, 20, c.GetInt("testTTL.rateLimit"))
+	assert.True(t, c.IsSet("testTTL.flag"))
+	assert.True(t, c.GetBool("testTTL.flag"))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, 10, c.GetInt("testTTL.rateLimit"))
+	assert.False(t, c.IsSet("testTTL.flag"))
+	assert.Equal(t, "host0", c.GetString("testTTL.host"))
+	assert.Equal(t, types.Override, c.GetSource("testTTL.host"))
+
+	sc := c.Scope("testTTL")
+	sc.SetWithTTL("rateLimit", 30, 50*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	sc.SetWithTTL("rateLimit", 40, 50*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	c.ClearExpired()
+	assert.Equal(t, 40, c.GetInt("testTTL.rateLimit"))
+	time.Sleep(30 * time.Millisecond)
+	c.ClearExpired()
+	assert.Equal(t, 10, c.GetInt("testTTL.rateLimit"))
+
+	c.SetWithTTL("testTTL.rateLimit", 50, 10*time.Millisecond)
+	c.Set("testTTL.rateLimit", 60)
+	time.Sleep(20 * time.Millisecond)
+	c.ClearExpired()
+	assert.Equal(t, 60, c.GetInt("testTTL.rateLimit"))
+}
+
+func TestSetWithTTLReadWhileLocked(t *testing.T) {
+	newConfigDirs("TestSetWithTTLReadWhileLocked", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test TTL")
+	r.Key(types.Int, "", 10, "The rate limit", "testTTL.rateLimit")
+	Register(r)
+
+	c := newConfig()
+	c.SetWithTTL("testTTL.rateLimit", 20, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	// the expired value is not returned while another reader holds the lock
+	c.rwl.RLock()
+	time.AfterFunc(20*time.Millisecond, c.rwl.RUnlock)
+	assert.Equal(t, 10, c.GetInt("testTTL.rateLimit"))
+
+	c.SetWithTTL("testTTL.rateLimit", 30, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	ReadOnly(c).ClearExpired()
+	assert.Equal(t, 1, c.ttls.Len())
+	assert.Equal(t, 10, c.GetInt("testTTL.rateLimit"))
+}
+
+func TestSetWithTTLBackground(t *testing.T) {
+	newConfigDirs("TestSetWithTTLBackground", t)
+	wipeEnv()
+
+	interval := TTLCheckInterval
+	TTLCheckInterval = 10 * time.Millisecond
+	defer func() { TTLCheckInterval = interval }()
+
+	c := newConfig()
+	changes := make(chan interface{}, 1)
+	h := c.OnChange("testTTL.flag", func(oldVal, newVal interface{}) {
+		changes <- newVal
+	})
+	defer h.Stop()
+
+	c.SetWithTTL("testTTL.flag", true, 20*time.Millisecond)
+	assert.Equal(t, true, <-changes)
+	select {
+	case v := <-changes:
+		assert.Nil(t, v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for expiration")
+	}
+	assert.False(t, c.IsSet("testTTL.flag"))
+	assert.Panics(t, func() {
+		ReadOnly(c).SetWithTTL("testTTL.flag", true, time.Second)
+	})
+}
+
+func TestClearExpiredKeepsBackend(t *testing.T) {
+	newConfigDirs("TestClearExpiredKeepsBackend", t)
+	wipeEnv()
+	TestHook(t)
+
+	r := newRegistration("Test TTL Backend")
+	r.Key(types.Int, "", 10, "The rate limit", "testTTL.rateLimit")
+	Register(r)
+
+	for _, b := range []backend.Backend{
+		backend.NewViperBackend(nil),
+		backend.NewMapBackend(),
+	} {
+		c := New(WithBackend(b))
+		c.Set("testTTL.host", "host0")
+		changes := make(chan string, 4)
+		h := c.OnChange("", func(oldVal, newVal interface{}) {
+			changes <- fmt.Sprint(newVal)
+		})
+
+		c.SetWithTTL("testTTL.rateLimit", 20, time.Millisecond)
+		assert.Equal(t, "20", <-changes)
+		time.Sleep(5 * time.Millisecond)
+		c.ClearExpired()
+		assert.Equal(t, "10", <-changes)
+		h.Stop()
+
+		assert.True(t, b == c.(*config).b)
+		assert.Equal(t, 10, c.GetInt("testTTL.rateLimit"))
+		assert.False(t, c.IsSet("testTTL.rateLimit"))
+		assert.Equal(t, "host0", c.GetString("testTTL.host"))
+		assert.Equal(t, types.Override, c.GetSource("testTTL.host"))
+		assert.Equal(t, types.Default, c.GetSource("testTTL.rateLimit"))
+		select {
+		case v := <-changes:
+			t.Fatalf("unexpected change %q", v)
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
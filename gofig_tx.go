@@ -0,0 +1,68 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akutz/gofig/types"
+)
+
+// configTx is a transaction that records the values to set when the
+// transaction is committed.
+type configTx struct {
+	c    *config
+	sets []configTxSet
+}
+
+// configTxSet is a value set with a transaction.
+type configTxSet struct {
+	k string
+	v interface{}
+}
+
+func (tx *configTx) Set(k interface{}, v interface{}) {
+	tx.sets = append(tx.sets, configTxSet{k: tx.c.realKey(toString(k)), v: v})
+}
+
+// scopedConfigTx is a transaction that sets the values in a scope.
+type scopedConfigTx struct {
+	types.ConfigTx
+	scope string
+}
+
+func (tx *scopedConfigTx) Set(k interface{}, v interface{}) {
+	tx.ConfigTx.Set(fmt.Sprintf("%s.%s", tx.scope, toString(k)), v)
+}
+
+func (c *config) Transaction(fn func(tx types.ConfigTx) error) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	tx := &configTx{c: c}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	var before map[string]interface{}
+	if c.hasChangeCallbacks() {
+		before = c.flatSettingsLocked()
+	}
+	for _, s := range tx.sets {
+		c.overrideKeys[strings.ToLower(s.k)] = true
+		c.removeTTL(s.k)
+		c.b.Set(s.k, s.v)
+	}
+	c.publishSnapshot()
+	if before != nil {
+		after := c.flatSettingsLocked()
+		c.notifyChanges(before, after)
+		c.recordChanges(before, after, types.Override)
+	}
+	return nil
+}
+func (c *scopedConfig) Transaction(fn func(tx types.ConfigTx) error) error {
+	return c.Config.Transaction(func(tx types.ConfigTx) error {
+		return fn(&scopedConfigTx{ConfigTx: tx, scope: c.scope})
+	})
+}
@@ -0,0 +1,162 @@
+package gofig
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/akutz/goof"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func TestTransaction(t *testing.T) {
+	newConfigDirs("TestTransaction", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testTx.host", "host0")
+	c.Set("testTx.port", 0)
+
+	err := c.Transaction(func(tx types.ConfigTx) error {
+		tx.Set("testTx.host", "host1")
+		tx.Set("testTx.port", 1)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "host1", c.GetString("testTx.host"))
+	assert.Equal(t, 1, c.GetInt("testTx.port"))
+	assert.Equal(t, types.Override, c.GetSource("testTx.port"))
+
+	sc := c.Scope("testTx")
+	err = sc.Transaction(func(tx types.ConfigTx) error {
+		tx.Set("host", "host2")
+		tx.Set("port", 2)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "host2", c.GetString("testTx.host"))
+	assert.Equal(t, 2, c.GetInt("testTx.port"))
+}
+
+func TestTransactionError(t *testing.T) {
+	newConfigDirs("TestTransactionError", t)
+	wipeEnv()
+
+	c := New()
+	c.Set("testTx.host", "host0")
+	c.Set("testTx.port", 0)
+
+	txErr := goof.New("tx failed")
+	err := c.Transaction(func(tx types.ConfigTx) error {
+		tx.Set("testTx.host", "host1")
+		tx.Set("testTx.port", 1)
+		return txErr
+	})
+	assert.Equal(t, txErr, err)
+	assert.Equal(t, "host0", c.GetString("testTx.host"))
+	assert.Equal(t, 0, c.GetInt("testTx.port"))
+
+	noop := func(tx types.ConfigTx) error { return nil }
+	c.Freeze()
+	assert.Equal(t, ErrFrozen, c.Transaction(noop))
+	c.Unfreeze()
+	assert.Equal(t, ErrReadOnly, ReadOnly(c).Transaction(noop))
+}
+
+func TestTransactionConcurrentReaders(t *testing.T) {
+	newConfigDirs("TestTransactionConcurrentReaders", t)
+	wipeEnv()
+
+	c := newConfig()
+	c.Set("testTx.host", "host0")
+	c.Set("testTx.port", 0)
+
+	done := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				c.rwl.RLock()
+				host := c.GetString("testTx.host")
+				port := c.GetInt("testTx.port")
+				c.rwl.RUnlock()
+				if host != fmt.Sprintf("host%d", port) {
+					t.Errorf("partial update: host=%s port=%d", host, port)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 1; i <= 100; i++ {
+		err := c.Transaction(func(tx types.ConfigTx) error {
+			tx.Set("testTx.host", fmt.Sprintf("host%d", i))
+			tx.Set("testTx.port", i)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+	close(done)
+	wg.Wait()
+
+	assert.Equal(t, "host100", c.GetString("testTx.host"))
+	assert.Equal(t, 100, c.GetInt("testTx.port"))
+}
+
+func TestTransactionConcurrentReadersOfNewKeys(t *testing.T) {
+	newConfigDirs("TestTransactionConcurrentReadersOfNewKeys", t)
+	wipeEnv()
+
+	c := newConfig()
+	c.Set("testTx.port", 0)
+
+	done := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// the host key is not in the snapshot until the transaction
+				// that sets it is committed along with the port
+				next := c.GetInt("testTx.port") + 1
+				k := fmt.Sprintf("testTx.host%d", next)
+				if c.GetString(k) == "" {
+					continue
+				}
+				if port := c.GetInt("testTx.port"); port < next {
+					t.Errorf("partial update: %s set but port=%d", k, port)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 1; i <= 100; i++ {
+		err := c.Transaction(func(tx types.ConfigTx) error {
+			tx.Set(fmt.Sprintf("testTx.host%d", i), fmt.Sprintf("host%d", i))
+			tx.Set("testTx.port", i)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+	close(done)
+	wg.Wait()
+
+	assert.Equal(t, "host100", c.GetString("testTx.host100"))
+	assert.Equal(t, 100, c.GetInt("testTx.port"))
+}
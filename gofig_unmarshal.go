@@ -0,0 +1,45 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+func (c *config) Unmarshal(rawVal interface{}) error {
+	return decode(c.nestedSettings(true), rawVal)
+}
+
+func (c *config) UnmarshalKey(k interface{}, rawVal interface{}) error {
+	szK := strings.ToLower(c.realKey(toString(k)))
+	var v interface{} = c.nestedSettings(true)
+	for _, kp := range strings.Split(szK, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if v, ok = m[kp]; !ok {
+			return nil
+		}
+	}
+	return decode(v, rawVal)
+}
+func (c *scopedConfig) UnmarshalKey(k interface{}, rawVal interface{}) error {
+	szK := toString(k)
+	return c.Config.UnmarshalKey(fmt.Sprintf("%s.%s", c.scope, szK), rawVal)
+}
+
+// decode decodes the input into the output using the same weakly typed
+// mapstructure configuration as Viper.
+func decode(input, output interface{}) error {
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           output,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+	})
+	if err != nil {
+		return err
+	}
+	return d.Decode(input)
+}
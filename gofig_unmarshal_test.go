@@ -0,0 +1,91 @@
+package gofig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+type testMockProviderConfig struct {
+	UserName string `mapstructure:"userName"`
+	Password string `mapstructure:"password"`
+	UseCerts bool   `mapstructure:"useCerts"`
+	Docker   struct {
+		MinVolSize int `mapstructure:"minVolSize"`
+	} `mapstructure:"docker"`
+}
+
+type testUnmarshalConfig struct {
+	RexRay struct {
+		LogLevel       string   `mapstructure:"logLevel"`
+		StorageDrivers []string `mapstructure:"storageDrivers"`
+	} `mapstructure:"rexray"`
+	MockProvider testMockProviderConfig `mapstructure:"mockProvider"`
+}
+
+var yamlConfigUnmarshal = []byte(`
+mockProvider:
+  password: i should be hidden
+`)
+
+func newUnmarshalConfig(testName string, t *testing.T) types.Config {
+	newConfigDirs(testName, t)
+	wipeEnv()
+
+	r := newRegistration("Test Unmarshal")
+	r.Key(types.SecureString, "", "", "", "mockProvider.password")
+
+	c := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	if err := c.ReadConfig(bytes.NewReader(yamlConfig1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReadConfig(bytes.NewReader(yamlConfigUnmarshal)); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c, "mockProvider.password", "i should be hidden")
+	return c
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := newUnmarshalConfig("TestUnmarshal", t)
+
+	var uc testUnmarshalConfig
+	if err := c.Unmarshal(&uc); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "error", uc.RexRay.LogLevel)
+	assert.EqualValues(t, []string{"ec2", "xtremio"}, uc.RexRay.StorageDrivers)
+	assert.Equal(t, "admin", uc.MockProvider.UserName)
+	assert.Equal(t, "", uc.MockProvider.Password)
+	assert.True(t, uc.MockProvider.UseCerts)
+	assert.Equal(t, 32, uc.MockProvider.Docker.MinVolSize)
+}
+
+func TestUnmarshalKey(t *testing.T) {
+	c := newUnmarshalConfig("TestUnmarshalKey", t)
+
+	var mpc testMockProviderConfig
+	if err := c.UnmarshalKey("mockProvider", &mpc); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "admin", mpc.UserName)
+	assert.Equal(t, "", mpc.Password)
+	assert.Equal(t, 32, mpc.Docker.MinVolSize)
+
+	var logLevel string
+	if err := c.Scope("rexray").UnmarshalKey("logLevel", &logLevel); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "error", logLevel)
+
+	mpc = testMockProviderConfig{}
+	sc := c.Scope("mockProvider")
+	if err := sc.UnmarshalKey("docker", &mpc.Docker); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 32, mpc.Docker.MinVolSize)
+}
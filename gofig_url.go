@@ -0,0 +1,200 @@
+package gofig
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/akutz/goof"
+
+	"github.com/akutz/gofig/types"
+)
+
+// DefaultHTTPTimeout is the timeout used when reading a configuration file
+// over HTTP if no timeout is specified.
+const DefaultHTTPTimeout = 10 * time.Second
+
+// WithBearerToken configures the request to send the specified bearer token
+// in its Authorization header.
+func WithBearerToken(token string) types.HTTPOption {
+	return func(o *types.HTTPOptions) {
+		o.BearerToken = token
+	}
+}
+
+// WithBasicAuth configures the request to use HTTP basic authentication with
+// the specified user name and password.
+func WithBasicAuth(user, pass string) types.HTTPOption {
+	return func(o *types.HTTPOptions) {
+		o.Username = user
+		o.Password = pass
+	}
+}
+
+// WithClientCert configures the request to present the specified client
+// certificate to the server.
+func WithClientCert(cert tls.Certificate) types.HTTPOption {
+	return func(o *types.HTTPOptions) {
+		o.Certificates = append(o.Certificates, cert)
+	}
+}
+
+// WithTimeout configures the maximum amount of time the request may take. A
+// timeout less than or equal to zero means DefaultHTTPTimeout is used.
+func WithTimeout(timeout time.Duration) types.HTTPOption {
+	return func(o *types.HTTPOptions) {
+		o.Timeout = timeout
+	}
+}
+
+func (c *config) ReadConfigFromURL(
+	u string, opts ...types.HTTPOption) error {
+	if c.frozen.Load() {
+		return ErrFrozen
+	}
+	buf, format, err := fetchURL(u, c.configType, opts)
+	if err != nil {
+		return err
+	}
+	if err := c.checkSettings(buf, format); err != nil {
+		return err
+	}
+	c.rwl.Lock()
+	defer c.rwl.Unlock()
+	return c.mergeConfigRead(configRead{
+		buf:      buf,
+		format:   format,
+		url:      u,
+		httpOpts: opts,
+	})
+}
+
+// urlContent is the content of a configuration file read from a URL.
+type urlContent struct {
+	buf         []byte
+	format      string
+	etag        string
+	status      int
+	notModified bool
+}
+
+// fetchURL returns the contents of the file at the specified URL and its
+// format.
+func fetchURL(
+	u, defaultFormat string,
+	opts []types.HTTPOption) ([]byte, string, error) {
+
+	uc, err := fetchURLContent(u, defaultFormat, "", opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return uc.buf, uc.format, nil
+}
+
+// fetchURLContent returns the content of the file at the specified URL. If
+// the etag is not empty it is sent in the request's If-None-Match header and
+// the returned content is marked as not modified if the server responds with
+// 304 Not Modified. The returned content is not nil if the server responded,
+// even if an error is returned, so that the response's status may be
+// inspected.
+func fetchURLContent(
+	u, defaultFormat, etag string,
+	opts []types.HTTPOption) (*urlContent, error) {
+
+	o := &types.HTTPOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultHTTPTimeout
+	}
+
+	client := &http.Client{Timeout: o.Timeout}
+	if len(o.Certificates) > 0 {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = &tls.Config{Certificates: o.Certificates}
+		client.Transport = t
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	switch {
+	case o.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+o.BearerToken)
+	case o.Username != "":
+		req.SetBasicAuth(o.Username, o.Password)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	uc := &urlContent{status: res.StatusCode, etag: res.Header.Get("ETag")}
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if etag != "" {
+			uc.etag = etag
+			uc.notModified = true
+			return uc, nil
+		}
+		fallthrough
+	default:
+		return uc, goof.WithFields(map[string]interface{}{
+			"url":    u,
+			"status": res.StatusCode,
+		}, "error reading config from url")
+	}
+	if uc.buf, err = ioutil.ReadAll(res.Body); err != nil {
+		return nil, err
+	}
+	uc.format = urlConfigFormat(res, uc.buf, defaultFormat)
+	return uc, nil
+}
+
+// urlConfigFormat returns the format of the configuration file in the
+// response as indicated by the response's content type, or the extension of
+// the request URL's path. If neither indicates the format then the format is
+// detected from the file's content.
+func urlConfigFormat(
+	res *http.Response, buf []byte, defaultFormat string) string {
+
+	mt, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	switch mt {
+	case "application/json":
+		return "json"
+	case "application/toml":
+		return "toml"
+	case "application/yaml", "application/x-yaml",
+		"text/yaml", "text/x-yaml":
+		return "yml"
+	}
+	if res.Request != nil {
+		if format := urlPathFormat(res.Request.URL); format != "" {
+			return format
+		}
+	}
+	return detectConfigFormat(buf, defaultFormat)
+}
+
+// urlPathFormat returns the config format indicated by the extension of the
+// URL's path, or an empty string if the extension is not a supported format.
+func urlPathFormat(u *url.URL) string {
+	format := strings.ToLower(strings.TrimPrefix(path.Ext(u.Path), "."))
+	if !isSupportedConfigFormat(format) {
+		return ""
+	}
+	return format
+}
@@ -0,0 +1,71 @@
+package gofig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadConfigFromURL(t *testing.T) {
+	newConfigDirs("TestReadConfigFromURL", t)
+	wipeEnv()
+
+	host := "host1"
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/bearer":
+				if req.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"testURL": {"host": "` + host + `"}}`))
+			case "/basic":
+				if u, p, ok := req.BasicAuth(); !ok ||
+					u != "user" || p != "pass" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "application/x-yaml")
+				w.Write([]byte("testURL:\n  port: 8080\n"))
+			case "/config.toml":
+				w.Write([]byte("[testURL]\nenabled = true\n"))
+			case "/slow":
+				time.Sleep(200 * time.Millisecond)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer s.Close()
+
+	c := New()
+	assert.NoError(t, c.ReadConfigFromURL(
+		s.URL+"/bearer", WithBearerToken("token")))
+	assert.Equal(t, "host1", c.GetString("testURL.host"))
+
+	assert.NoError(t, c.ReadConfigFromURL(
+		s.URL+"/basic", WithBasicAuth("user", "pass")))
+	assert.Equal(t, 8080, c.GetInt("testURL.port"))
+
+	assert.NoError(t, c.ReadConfigFromURL(s.URL+"/config.toml"))
+	assert.True(t, c.GetBool("testURL.enabled"))
+
+	assert.Error(t, c.ReadConfigFromURL(s.URL+"/bearer"))
+	assert.Error(t, c.ReadConfigFromURL(s.URL+"/missing"))
+	assert.Error(t, c.ReadConfigFromURL(
+		s.URL+"/slow", WithTimeout(10*time.Millisecond)))
+
+	host = "host2"
+	n, err := c.(*config).reloadFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "host2", c.GetString("testURL.host"))
+
+	assert.Equal(t, ErrReadOnly, ReadOnly(c).ReadConfigFromURL(s.URL))
+	c.Freeze()
+	assert.Equal(t, ErrFrozen, c.ReadConfigFromURL(s.URL))
+}
@@ -0,0 +1,70 @@
+package gofig
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/akutz/goof"
+	"github.com/hashicorp/vault/api"
+	"github.com/spf13/cast"
+)
+
+// VaultResolver is a resolver that reads the values of keys from HashiCorp
+// Vault.
+type VaultResolver struct {
+	client *api.Client
+	path   *template.Template
+}
+
+// vaultPathData is the data used to execute a VaultResolver's path template.
+type vaultPathData struct {
+	Key string
+}
+
+// NewVaultResolver returns a new resolver that reads the values of keys from
+// Vault using the specified client. The path template is executed with the
+// key name as .Key to get the path of the secret that contains the key's
+// value, for example "secret/data/myapp/{{.Key}}". The value is read from the
+// secret's "value" field. The data of secrets read from version 2 of the KV
+// secrets engine is unwrapped automatically.
+func NewVaultResolver(
+	client *api.Client, pathTemplate string) (*VaultResolver, error) {
+
+	if client == nil {
+		return nil, goof.New("vault client is nil")
+	}
+	t, err := template.New("path").Parse(pathTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &VaultResolver{client: client, path: t}, nil
+}
+
+// Resolve reads the value of the key from Vault.
+func (r *VaultResolver) Resolve(key string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := r.path.Execute(buf, &vaultPathData{Key: key}); err != nil {
+		return "", err
+	}
+	path := buf.String()
+
+	s, err := r.client.Logical().Read(path)
+	if err != nil {
+		return "", err
+	}
+	if s == nil || s.Data == nil {
+		return "", goof.WithField("path", path, "vault secret not found")
+	}
+
+	data := s.Data
+	if d, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = d
+		}
+	}
+	v, ok := data["value"]
+	if !ok {
+		return "", goof.WithField("path", path, "vault secret has no value")
+	}
+	return cast.ToString(v), nil
+}
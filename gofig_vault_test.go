@@ -0,0 +1,61 @@
+package gofig
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultResolver(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			var data map[string]interface{}
+			switch req.URL.Path {
+			case "/v1/secret/data/myapp/testVault.password":
+				data = map[string]interface{}{
+					"data":     map[string]interface{}{"value": "p1"},
+					"metadata": map[string]interface{}{"version": 1},
+				}
+			case "/v1/kv/myapp/testVault.password":
+				data = map[string]interface{}{"value": "p2"}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}))
+	defer s.Close()
+
+	cfg := api.DefaultConfig()
+	cfg.Address = s.URL
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetToken("token")
+
+	r, err := NewVaultResolver(client, "secret/data/myapp/{{.Key}}")
+	assert.NoError(t, err)
+	v, err := r.Resolve("testVault.password")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", v)
+
+	_, err = r.Resolve("testVault.missing")
+	assert.Error(t, err)
+
+	r, err = NewVaultResolver(client, "kv/myapp/{{.Key}}")
+	assert.NoError(t, err)
+	v, err = r.Resolve("testVault.password")
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", v)
+
+	_, err = NewVaultResolver(nil, "kv/{{.Key}}")
+	assert.Error(t, err)
+	_, err = NewVaultResolver(client, "kv/{{.Key")
+	assert.Error(t, err)
+}
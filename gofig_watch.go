@@ -0,0 +1,18 @@
+package gofig
+
+import (
+	"fmt"
+
+	"github.com/akutz/gofig/types"
+)
+
+func (c *config) WatchKey(
+	k interface{}, fn func(newVal interface{})) types.CancelFunc {
+	h := c.OnChange(k, func(oldVal, newVal interface{}) { fn(newVal) })
+	return h.Stop
+}
+func (c *scopedConfig) WatchKey(
+	k interface{}, fn func(newVal interface{})) types.CancelFunc {
+	szK := toString(k)
+	return c.Config.WatchKey(fmt.Sprintf("%s.%s", c.scope, szK), fn)
+}
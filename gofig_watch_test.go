@@ -0,0 +1,61 @@
+package gofig
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchKey(t *testing.T) {
+	wipeEnv()
+	filePath := filepath.Join(t.TempDir(), "config.yml")
+	writeFile := func(s string) {
+		if err := ioutil.WriteFile(filePath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("testWatch:\n  host: host1\n  port: 1\n")
+
+	c := newConfigWithOptions(false, false, "config", "yml")
+	assert.NoError(t, c.ReadConfigFile(filePath))
+	c.SetWatchDebounce(10 * time.Millisecond)
+
+	hosts1 := make(chan interface{}, 1)
+	hosts2 := make(chan interface{}, 1)
+	var portChanges int32
+	c.WatchKey("testWatch.host", func(v interface{}) { hosts1 <- v })
+	cancel := c.Scope("testWatch").WatchKey(
+		"host", func(v interface{}) { hosts2 <- v })
+	stopPort := c.WatchKey("testWatch.port", func(v interface{}) {
+		atomic.AddInt32(&portChanges, 1)
+	})
+	defer stopPort()
+
+	receive := func(ch chan interface{}) interface{} {
+		select {
+		case v := <-ch:
+			return v
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watcher")
+		}
+		return nil
+	}
+
+	writeFile("testWatch:\n  host: host2\n  port: 1\n")
+	c.notifyFileEvent(filePath)
+	assert.Equal(t, "host2", receive(hosts1))
+	assert.Equal(t, "host2", receive(hosts2))
+	time.Sleep(50 * time.Millisecond)
+	assert.EqualValues(t, 0, atomic.LoadInt32(&portChanges))
+
+	cancel()
+	c.Set("testWatch.host", "host3")
+	assert.Equal(t, "host3", receive(hosts1))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, hosts2)
+	assert.EqualValues(t, 0, atomic.LoadInt32(&portChanges))
+}
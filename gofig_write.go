@@ -0,0 +1,107 @@
+package gofig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/akutz/goof"
+	toml "github.com/pelletier/go-toml"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func (c *config) WriteConfigFile() error {
+	return c.writeConfigFile(true)
+}
+
+func (c *config) WriteConfigFileRaw() error {
+	return c.writeConfigFile(false)
+}
+
+func (c *config) WriteConfigAs(filePath, format string) error {
+	return c.writeConfig(filePath, format, true)
+}
+
+func (c *config) writeConfigFile(secure bool) error {
+	if c.configFilePath == "" {
+		return goof.New("no config file has been read")
+	}
+	format := strings.TrimPrefix(filepath.Ext(c.configFilePath), ".")
+	return c.writeConfig(c.configFilePath, format, secure)
+}
+
+func (c *config) writeConfig(filePath, format string, secure bool) error {
+	buf, err := marshalConfig(c.nestedSettings(secure), format)
+	if err != nil {
+		return err
+	}
+	var perm os.FileMode = 0644
+	if !secure {
+		perm = 0600
+	}
+	logger().Debug("writing config file",
+		"path", filePath,
+		"format", format,
+	)
+	return ioutil.WriteFile(filePath, buf, perm)
+}
+
+// nestedSettings returns the config's settings as a tree of nested maps. If
+// secure is true then the values of secure keys are replaced with empty
+// strings.
+func (c *config) nestedSettings(secure bool) map[string]interface{} {
+	flat := c.flatSettings()
+	if secure {
+		for k := range flat {
+			if c.isSecureKey(k) {
+				flat[k] = ""
+			}
+		}
+	}
+	return unflattenMapKeys(flat)
+}
+
+// nestedSecureSettings returns the config's settings as a tree of nested maps
+// with the secure keys omitted.
+func (c *config) nestedSecureSettings() map[string]interface{} {
+	flat := c.flatSettings()
+	for k := range flat {
+		if c.isSecureKey(k) {
+			delete(flat, k)
+		}
+	}
+	return unflattenMapKeys(flat)
+}
+
+// unflattenMapKeys transforms a map with dot-notation keys into a tree of
+// nested maps.
+func unflattenMapKeys(flat map[string]interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	for k, v := range flat {
+		setNestedValue(m, k, v)
+	}
+	return m
+}
+
+// marshalConfig marshals a map of settings using the specified format.
+func marshalConfig(m map[string]interface{}, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "yml", "yaml":
+		return yaml.Marshal(m)
+	case "json":
+		return json.MarshalIndent(m, "", "  ")
+	case "toml":
+		t, err := toml.TreeFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		s, err := t.ToTomlString()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+	}
+	return nil, goof.WithField("format", format, "unsupported config format")
+}
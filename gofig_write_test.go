@@ -0,0 +1,111 @@
+package gofig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/akutz/gotil"
+	toml "github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig/types"
+)
+
+func newWriteConfig(testName string, t *testing.T) (types.Config, string) {
+	_, usrCfgFilePath := newConfigDirs(testName, t)
+	wipeEnv()
+	gotil.WriteStringToFile(string(yamlConfig1), usrCfgFilePath)
+
+	r := newRegistration("Test Write Config")
+	r.Key(types.SecureString, "", "", "", "mockProvider.password")
+
+	c := New()
+	c.Set("rexray.logLevel", "info")
+	c.Set("mockProvider.password", "i should be hidden")
+	return c, usrCfgFilePath
+}
+
+func TestWriteConfigFile(t *testing.T) {
+	c, usrCfgFilePath := newWriteConfig("TestWriteConfigFile", t)
+
+	if err := c.WriteConfigFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	c2 := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	if err := c2.ReadConfigFile(usrCfgFilePath); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c2, "rexray.logLevel", "info")
+	assertString(t, c2, "mockProvider.password", "")
+	assertStorageDrivers(t, c2)
+
+	buf, err := ioutil.ReadFile(usrCfgFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := ValidateYAMLString(string(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok := m["rexray"]
+	assert.True(t, ok)
+	_, ok = m["rexray.loglevel"]
+	assert.False(t, ok)
+}
+
+func TestWriteConfigFileRaw(t *testing.T) {
+	c, usrCfgFilePath := newWriteConfig("TestWriteConfigFileRaw", t)
+
+	if err := c.WriteConfigFileRaw(); err != nil {
+		t.Fatal(err)
+	}
+
+	c2 := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	if err := c2.ReadConfigFile(usrCfgFilePath); err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, c2, "rexray.logLevel", "info")
+	assertString(t, c2, "mockProvider.password", "i should be hidden")
+}
+
+func TestWriteConfigFileWithErrors(t *testing.T) {
+	c := NewConfig(WithGlobalConfig(false), WithUserConfig(false))
+	assert.Error(t, c.WriteConfigFile())
+}
+
+func TestWriteConfigAs(t *testing.T) {
+	c, usrCfgFilePath := newWriteConfig("TestWriteConfigAs", t)
+	dir := path.Dir(usrCfgFilePath)
+
+	jsonFilePath := path.Join(dir, "config.json")
+	if err := c.WriteConfigAs(jsonFilePath, "json"); err != nil {
+		t.Fatal(err)
+	}
+	buf, err := ioutil.ReadFile(jsonFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jm := map[string]interface{}{}
+	if err := json.Unmarshal(buf, &jm); err != nil {
+		t.Fatal(err)
+	}
+	rexray, ok := jm["rexray"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "info", rexray["loglevel"])
+
+	tomlFilePath := path.Join(dir, "config.toml")
+	if err := c.WriteConfigAs(tomlFilePath, "toml"); err != nil {
+		t.Fatal(err)
+	}
+	tt, err := toml.LoadFile(tomlFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "info", tt.Get("rexray.loglevel"))
+	assert.Equal(t, "", tt.Get("mockprovider.password"))
+
+	assert.Error(t, c.WriteConfigAs(path.Join(dir, "config.ini"), "ini"))
+}
@@ -0,0 +1,204 @@
+// Package prometheus instruments a config with Prometheus metrics. The
+// package is a separate import so that the gofig package does not depend on
+// the Prometheus client library.
+package prometheus
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cast"
+
+	"github.com/akutz/gofig/types"
+)
+
+// metricsConfig is a config that records the reads of its keys and its
+// reloads with Prometheus metrics.
+type metricsConfig struct {
+	types.Config
+	reads          *prometheus.CounterVec
+	reloads        prometheus.Counter
+	reloadDuration prometheus.Histogram
+}
+
+// RegisterMetrics registers the following metrics with the registerer and
+// returns a config that records them for the specified config:
+//
+//   - gofig_key_reads_total, the number of reads of each key, labeled by key
+//   - gofig_reloads_total, the number of reloads
+//   - gofig_reload_duration_seconds, the duration of the reloads
+//   - gofig_registered_keys, the number of registered keys in the config
+//
+// Only the reads and reloads made with the returned config are recorded. An
+// error is returned if the metrics cannot be registered, such as when the
+// metrics are already registered with the registerer.
+func RegisterMetrics(
+	c types.Config, reg prometheus.Registerer) (types.Config, error) {
+
+	mc := &metricsConfig{
+		Config: c,
+		reads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gofig",
+			Name:      "key_reads_total",
+			Help:      "The number of reads of each config key.",
+		}, []string{"key"}),
+		reloads: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "gofig",
+			Name:      "reloads_total",
+			Help:      "The number of config reloads.",
+		}),
+		reloadDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "gofig",
+			Name:      "reload_duration_seconds",
+			Help:      "The duration of the config reloads.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+	}
+	registeredKeys := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "gofig",
+		Name:      "registered_keys",
+		Help:      "The number of registered keys in the config.",
+	}, func() float64 {
+		return float64(countRegisteredKeys(c))
+	})
+
+	for _, m := range []prometheus.Collector{
+		mc.reads, mc.reloads, mc.reloadDuration, registeredKeys} {
+		if err := reg.Register(m); err != nil {
+			return nil, err
+		}
+	}
+	return mc, nil
+}
+
+// countRegisteredKeys returns the number of the config's keys that belong to
+// a registration.
+func countRegisteredKeys(c types.Config) int {
+	n := 0
+	for _, k := range c.AllKeys() {
+		if _, err := c.GetKeyTags(k); err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// read records a read of the key.
+func (c *metricsConfig) read(k interface{}) {
+	c.reads.WithLabelValues(strings.ToLower(cast.ToString(k))).Inc()
+}
+
+func (c *metricsConfig) Reload() error {
+	start := time.Now()
+	err := c.Config.Reload()
+	c.reloadDuration.Observe(time.Since(start).Seconds())
+	c.reloads.Inc()
+	return err
+}
+
+func (c *metricsConfig) Get(k interface{}) interface{} {
+	c.read(k)
+	return c.Config.Get(k)
+}
+
+func (c *metricsConfig) GetString(k interface{}) string {
+	c.read(k)
+	return c.Config.GetString(k)
+}
+
+func (c *metricsConfig) GetBool(k interface{}) bool {
+	c.read(k)
+	return c.Config.GetBool(k)
+}
+
+func (c *metricsConfig) GetStringSlice(k interface{}) []string {
+	c.read(k)
+	return c.Config.GetStringSlice(k)
+}
+
+func (c *metricsConfig) GetStringSliceDelim(
+	k interface{}, delim string) []string {
+	c.read(k)
+	return c.Config.GetStringSliceDelim(k, delim)
+}
+
+func (c *metricsConfig) GetInt(k interface{}) int {
+	c.read(k)
+	return c.Config.GetInt(k)
+}
+
+func (c *metricsConfig) GetMap(k interface{}) map[string]interface{} {
+	c.read(k)
+	return c.Config.GetMap(k)
+}
+
+func (c *metricsConfig) GetMapString(k interface{}) map[string]string {
+	c.read(k)
+	return c.Config.GetMapString(k)
+}
+
+func (c *metricsConfig) GetCtx(
+	ctx context.Context, k interface{}) interface{} {
+	c.read(k)
+	return c.Config.GetCtx(ctx, k)
+}
+
+func (c *metricsConfig) GetStringCtx(
+	ctx context.Context, k interface{}) string {
+	c.read(k)
+	return c.Config.GetStringCtx(ctx, k)
+}
+
+func (c *metricsConfig) GetBoolCtx(ctx context.Context, k interface{}) bool {
+	c.read(k)
+	return c.Config.GetBoolCtx(ctx, k)
+}
+
+func (c *metricsConfig) GetStringSliceCtx(
+	ctx context.Context, k interface{}) []string {
+	c.read(k)
+	return c.Config.GetStringSliceCtx(ctx, k)
+}
+
+func (c *metricsConfig) GetIntCtx(ctx context.Context, k interface{}) int {
+	c.read(k)
+	return c.Config.GetIntCtx(ctx, k)
+}
+
+func (c *metricsConfig) TryGetString(k interface{}) (string, bool) {
+	c.read(k)
+	return c.Config.TryGetString(k)
+}
+
+func (c *metricsConfig) TryGetInt(k interface{}) (int, bool) {
+	c.read(k)
+	return c.Config.TryGetInt(k)
+}
+
+func (c *metricsConfig) TryGetBool(k interface{}) (bool, bool) {
+	c.read(k)
+	return c.Config.TryGetBool(k)
+}
+
+func (c *metricsConfig) GetStringOrDefault(k interface{}, def string) string {
+	c.read(k)
+	return c.Config.GetStringOrDefault(k, def)
+}
+
+func (c *metricsConfig) GetIntOrDefault(k interface{}, def int) int {
+	c.read(k)
+	return c.Config.GetIntOrDefault(k, def)
+}
+
+func (c *metricsConfig) GetBoolOrDefault(k interface{}, def bool) bool {
+	c.read(k)
+	return c.Config.GetBoolOrDefault(k, def)
+}
+
+func (c *metricsConfig) GetFloat64OrDefault(
+	k interface{}, def float64) float64 {
+	c.read(k)
+	return c.Config.GetFloat64OrDefault(k, def)
+}
@@ -0,0 +1,69 @@
+package prometheus
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/gofig/types"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	gofig.TestHook(t)
+	gofig.ClearRegistrations()
+	r := gofig.NewRegistration("Test Metrics")
+	r.Key(types.String, "", "localhost", "", "testMetrics.host")
+	r.Key(types.Int, "", 8080, "", "testMetrics.port")
+	gofig.Register(r)
+
+	filePath := filepath.Join(t.TempDir(), "config.yml")
+	if err := ioutil.WriteFile(
+		filePath, []byte("testMetrics:\n  name: app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := gofig.NewConfig(
+		gofig.WithGlobalConfig(false), gofig.WithUserConfig(false))
+	assert.NoError(t, c.ReadConfigFile(filePath))
+
+	reg := prometheus.NewRegistry()
+	mc, err := RegisterMetrics(c, reg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "localhost", mc.GetString("testMetrics.host"))
+	assert.Equal(t, "localhost", mc.GetString("testMetrics.Host"))
+	assert.Equal(t, 8080, mc.GetInt("testMetrics.port"))
+	assert.Equal(t, "app", mc.Get("testMetrics.name"))
+
+	m := mc.(*metricsConfig)
+	assert.Equal(t, 2.0,
+		testutil.ToFloat64(m.reads.WithLabelValues("testmetrics.host")))
+	assert.Equal(t, 1.0,
+		testutil.ToFloat64(m.reads.WithLabelValues("testmetrics.port")))
+
+	assert.NoError(t, mc.Reload())
+	assert.NoError(t, mc.Reload())
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.reloads))
+
+	mfs, err := reg.Gather()
+	assert.NoError(t, err)
+	for _, mf := range mfs {
+		switch mf.GetName() {
+		case "gofig_reload_duration_seconds":
+			assert.EqualValues(t,
+				2, mf.GetMetric()[0].GetHistogram().GetSampleCount())
+		case "gofig_registered_keys":
+			assert.EqualValues(t, 2, mf.GetMetric()[0].GetGauge().GetValue())
+		}
+	}
+	assert.Len(t, mfs, 4)
+
+	_, err = RegisterMetrics(c, reg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+// Package testing provides helpers for tests that use gofig configs as
+// fixtures. The package depends only on the gofig packages and the standard
+// library.
+package testing
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/gofig/types"
+)
+
+// NewTestConfig returns a config created from the inline YAML. The global
+// and user config files are not loaded. The gofig package's registrations
+// are saved with gofig.TestHook and restored when the test completes, so the
+// test may register keys freely. The test fails immediately if the YAML cannot
+// be read.
+func NewTestConfig(t testing.TB, yaml string) types.Config {
+	t.Helper()
+	gofig.TestHook(t)
+	c := gofig.NewConfig(
+		gofig.WithGlobalConfig(false), gofig.WithUserConfig(false))
+	if err := c.ReadConfig(strings.NewReader(yaml)); err != nil {
+		t.Fatalf("error reading test config: %v", err)
+	}
+	return c
+}
+
+// MustGet returns the value of the key. The test fails immediately if the key
+// is not set.
+func MustGet(t testing.TB, c types.Config, key string) interface{} {
+	t.Helper()
+	if !c.IsSet(key) {
+		t.Fatalf("key %q is not set", key)
+	}
+	return c.Get(key)
+}
+
+// AssertKeyEquals marks the test as failed if the value of the key is not
+// equal to the expected value. Values of different types are equal if the
+// actual value can be converted to the type of the expected value and the
+// converted value is equal to the expected value, so an int64 read from a
+// config file is equal to an expected int.
+func AssertKeyEquals(
+	t testing.TB, c types.Config, key string, expected interface{}) bool {
+
+	t.Helper()
+	actual := c.Get(key)
+	if valuesEqual(expected, actual) {
+		return true
+	}
+	t.Errorf("key %q is not equal to the expected value:\n%s",
+		key, diff(expected, actual))
+	return false
+}
+
+// valuesEqual returns a flag indicating whether or not the values are equal
+// or the actual value is equal to the expected value after it is converted to
+// the expected value's type.
+func valuesEqual(expected, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	if expected == nil || actual == nil {
+		return false
+	}
+	et := reflect.TypeOf(expected)
+	av := reflect.ValueOf(actual)
+	if !av.Type().ConvertibleTo(et) || isString(et) != isString(av.Type()) {
+		return false
+	}
+	return reflect.DeepEqual(expected, av.Convert(et).Interface())
+}
+
+func isString(t reflect.Type) bool {
+	return t.Kind() == reflect.String
+}
+
+// diff returns a description of the difference between the values with one
+// line per line of the values' formatted representations.
+func diff(expected, actual interface{}) string {
+	el := strings.Split(fmt.Sprintf("%#v", expected), "\n")
+	al := strings.Split(fmt.Sprintf("%#v", actual), "\n")
+	b := &strings.Builder{}
+	fmt.Fprintln(b, "--- expected")
+	fmt.Fprintln(b, "+++ actual")
+	for x := 0; x < len(el) || x < len(al); x++ {
+		switch {
+		case x >= len(al):
+			fmt.Fprintf(b, "-%s\n", el[x])
+		case x >= len(el):
+			fmt.Fprintf(b, "+%s\n", al[x])
+		case el[x] == al[x]:
+			fmt.Fprintf(b, " %s\n", el[x])
+		default:
+			fmt.Fprintf(b, "-%s\n+%s\n", el[x], al[x])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package testing
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/akutz/gofig"
+	"github.com/akutz/gofig/types"
+)
+
+// recorder is a testing.TB that records its failures instead of failing the
+// test.
+type recorder struct {
+	testing.TB
+	fatals []string
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+const testYAML = `testFixture:
+  host: localhost
+  port: 8080
+  tags:
+  - a
+  - b
+`
+
+func TestNewTestConfig(t *testing.T) {
+	t.Run("Register", func(t *testing.T) {
+		c := NewTestConfig(t, testYAML)
+		r := gofig.NewRegistration("Test Fixture")
+		r.Key(types.String, "", "info", "", "testFixture.logLevel")
+		gofig.Register(r)
+		c = NewTestConfig(t, testYAML)
+		AssertKeyEquals(t, c, "testFixture.logLevel", "info")
+	})
+	c := NewTestConfig(t, testYAML)
+	if c.IsSet("testFixture.logLevel") {
+		t.Fatal("registration was not removed")
+	}
+
+	rec := &recorder{TB: t}
+	NewTestConfig(rec, "testFixture: [")
+	if len(rec.fatals) != 1 {
+		t.Fatalf("expected one fatal error, got %v", rec.fatals)
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	c := NewTestConfig(t, testYAML)
+	if v := MustGet(t, c, "testFixture.host"); v != "localhost" {
+		t.Fatalf("unexpected value %v", v)
+	}
+
+	rec := &recorder{TB: t}
+	MustGet(rec, c, "testFixture.missing")
+	if len(rec.fatals) != 1 ||
+		!strings.Contains(rec.fatals[0], "testFixture.missing") {
+		t.Fatalf("unexpected fatal errors %v", rec.fatals)
+	}
+}
+
+func TestAssertKeyEquals(t *testing.T) {
+	c := NewTestConfig(t, testYAML)
+	AssertKeyEquals(t, c, "testFixture.host", "localhost")
+	AssertKeyEquals(t, c, "testFixture.port", 8080)
+	AssertKeyEquals(t, c, "testFixture.tags", []interface{}{"a", "b"})
+
+	rec := &recorder{TB: t}
+	if AssertKeyEquals(rec, c, "testFixture.host", "remote") {
+		t.Fatal("expected mismatch")
+	}
+	if AssertKeyEquals(rec, c, "testFixture.port", "8080") {
+		t.Fatal("expected mismatch")
+	}
+	if len(rec.errors) != 2 {
+		t.Fatalf("expected two errors, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], `-"remote"`) ||
+		!strings.Contains(rec.errors[0], `+"localhost"`) {
+		t.Fatalf("unexpected diff %s", rec.errors[0])
+	}
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"crypto/tls"
+	"time"
+)
+
 // ConfigKeyTypes is a type of configuration key.
 type ConfigKeyTypes int
 
@@ -16,8 +21,214 @@ const (
 	// SecureString is a key with a string value that is not included when the
 	// configuration is marshaled to JSON.
 	SecureString // 3
+
+	// StringEnum is a key with a string value that must be one of the key's
+	// allowed values.
+	StringEnum // 4
+
+	// Float64 is a key with a floating-point value
+	Float64 // 5
+
+	// Duration is a key with a time.Duration value. String values are parsed
+	// with time.ParseDuration.
+	Duration // 6
 )
 
+// String returns the name of the key type.
+func (k ConfigKeyTypes) String() string {
+	switch k {
+	case String:
+		return "String"
+	case Int:
+		return "Int"
+	case Bool:
+		return "Bool"
+	case SecureString:
+		return "SecureString"
+	case StringEnum:
+		return "StringEnum"
+	case Float64:
+		return "Float64"
+	case Duration:
+		return "Duration"
+	}
+	return ""
+}
+
+// SecureValue is the value reported in place of a secure key's actual value.
+const SecureValue = "******"
+
+// RedactedValue is the value with which a secure key's actual value is
+// replaced when the configuration is marshaled to masked JSON.
+const RedactedValue = "***REDACTED***"
+
+// ChangeKind is the kind of change made to a configuration key.
+type ChangeKind int
+
+const (
+	// Added indicates a key that was added
+	Added ChangeKind = iota // 0
+
+	// Removed indicates a key that was removed
+	Removed // 1
+
+	// Modified indicates a key whose value was modified
+	Modified // 2
+)
+
+// String returns the name of the change kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Modified:
+		return "Modified"
+	}
+	return ""
+}
+
+// DelimOpt may be included in the arguments of a ConfigRegistration's Key
+// function to specify the delimiter used to split the value of the key's
+// environment variable into a string slice.
+type DelimOpt string
+
+// TagOpt may be included in the arguments of a ConfigRegistration's Key
+// function to attach a tag, such as a UI label or a category, to the key.
+type TagOpt struct {
+	Key   string
+	Value string
+}
+
+// Tag returns a TagOpt with the specified name and value.
+func Tag(key, value string) TagOpt {
+	return TagOpt{Key: key, Value: value}
+}
+
+// MinOpt may be included in the arguments of a ConfigRegistration's Key
+// function to specify the minimum value of an Int, Float64, or Duration key.
+type MinOpt struct {
+	Value interface{}
+}
+
+// Min returns a MinOpt with the specified value.
+func Min(v interface{}) MinOpt {
+	return MinOpt{Value: v}
+}
+
+// MaxOpt may be included in the arguments of a ConfigRegistration's Key
+// function to specify the maximum value of an Int, Float64, or Duration key.
+type MaxOpt struct {
+	Value interface{}
+}
+
+// Max returns a MaxOpt with the specified value.
+func Max(v interface{}) MaxOpt {
+	return MaxOpt{Value: v}
+}
+
+// MinDuration returns a MinOpt that specifies the minimum value of a Duration
+// key.
+func MinDuration(d time.Duration) MinOpt {
+	return MinOpt{Value: d}
+}
+
+// MaxDuration returns a MaxOpt that specifies the maximum value of a Duration
+// key.
+func MaxDuration(d time.Duration) MaxOpt {
+	return MaxOpt{Value: d}
+}
+
+// ConfigSource is the source of a configuration key's value.
+type ConfigSource int
+
+const (
+	// Default indicates a value that is a registered default value, or a key
+	// that is not set
+	Default ConfigSource = iota // 0
+
+	// EnvVar indicates a value from an environment variable
+	EnvVar // 1
+
+	// Flag indicates a value from a command line flag
+	Flag // 2
+
+	// File indicates a value read from a config file or stream
+	File // 3
+
+	// Override indicates a value set with the Set function
+	Override // 4
+)
+
+// String returns the name of the config source.
+func (s ConfigSource) String() string {
+	switch s {
+	case Default:
+		return "Default"
+	case EnvVar:
+		return "EnvVar"
+	case Flag:
+		return "Flag"
+	case File:
+		return "File"
+	case Override:
+		return "Override"
+	}
+	return ""
+}
+
+// MergeStrategy is how a key's value from a config stream is resolved when the
+// key was already read from another config stream.
+type MergeStrategy int
+
+const (
+	// LastWins replaces the existing value with the new value
+	LastWins MergeStrategy = iota // 0
+
+	// FirstWins keeps the existing value
+	FirstWins // 1
+
+	// ErrorOnConflict fails the merge if the values are different
+	ErrorOnConflict // 2
+
+	// AppendSlices appends the new value to the existing value if both are
+	// slices, otherwise the new value replaces the existing value
+	AppendSlices // 3
+)
+
+// String returns the name of the merge strategy.
+func (s MergeStrategy) String() string {
+	switch s {
+	case LastWins:
+		return "LastWins"
+	case FirstWins:
+		return "FirstWins"
+	case ErrorOnConflict:
+		return "ErrorOnConflict"
+	case AppendSlices:
+		return "AppendSlices"
+	}
+	return ""
+}
+
+// ConfigChange describes a change made to a configuration key.
+type ConfigChange struct {
+
+	// Key is the dot-notation name of the key.
+	Key string
+
+	// Kind is the kind of change.
+	Kind ChangeKind
+
+	// OldValue is the key's previous value. It is SecureValue for secure
+	// keys.
+	OldValue interface{}
+
+	// NewValue is the key's new value. It is SecureValue for secure keys.
+	NewValue interface{}
+}
+
 // ConfigRegistration is an interface that describes a configuration
 // registration object.
 type ConfigRegistration interface {
@@ -31,13 +242,34 @@ type ConfigRegistration interface {
 	// SetYAML sets the registration's default yaml configuration.
 	SetYAML(y string)
 
+	// ProfileYAML returns the registration's default yaml configuration for
+	// the specified profile. The profile yaml is read after the yaml returned
+	// by the YAML function.
+	ProfileYAML(profile string) string
+
+	// SetProfileYAML sets the registration's default yaml configuration for
+	// each of the profiles in the map.
+	SetProfileYAML(profiles map[string]string)
+
 	// Key adds a key to the registration.
 	//
 	// The first vararg argument is the yaml name of the key, using a '.' as
 	// the nested separator. If the second two arguments are omitted they will
 	// be generated from the first argument. The second argument is the explicit
 	// name of the flag bound to this key. The third argument is the explicit
-	// name of the environment variable bound to thie key.
+	// name of the environment variable bound to thie key. Any additional
+	// arguments are aliases for the key.
+	//
+	// If the key type is StringEnum then a []string argument may be included
+	// anywhere after the first argument to specify the allowed values. A
+	// DelimOpt argument may be included anywhere after the first argument to
+	// specify the delimiter used to split the value of the key's environment
+	// variable into a string slice. Any number of TagOpt arguments may be
+	// included anywhere after the first argument to attach tags to the key.
+	// If the key type is Int, Float64, or Duration then MinOpt and MaxOpt
+	// arguments may be included anywhere after the first argument to specify
+	// the range of the key's value. Key panics if the default value is
+	// outside the range.
 	Key(
 		keyType ConfigKeyTypes,
 		short string,
@@ -48,6 +280,22 @@ type ConfigRegistration interface {
 	// Keys returns a channel on which a listener can receive the config
 	// registration's keys.
 	Keys() <-chan ConfigRegistrationKey
+
+	// Deprecate marks a key as deprecated in the Config instances that
+	// process this registration. The message is logged when the key is read.
+	Deprecate(k interface{}, message string)
+
+	// Deprecations returns a map of the registration's deprecated keys and
+	// their deprecation messages.
+	Deprecations() map[string]string
+
+	// Section sets the section to which the keys subsequently added to the
+	// registration belong. Sections group the keys by functional area when
+	// documentation is generated.
+	Section(name, description string)
+
+	// SectionDescription returns the description of the named section.
+	SectionDescription(name string) string
 }
 
 // ConfigRegistrationKey is an interfact that describes a cofniguration
@@ -60,4 +308,127 @@ type ConfigRegistrationKey interface {
 	KeyName() string
 	FlagName() string
 	EnvVarName() string
+	Aliases() []string
+
+	// AllowedValues returns the allowed values of a StringEnum key.
+	AllowedValues() []string
+
+	// Section returns the name of the section to which the key belongs. An
+	// empty string is returned if the key does not belong to a section.
+	Section() string
+
+	// Delim returns the delimiter used to split the value of the key's
+	// environment variable into a string slice. An empty string is returned
+	// if the key uses the default delimiter.
+	Delim() string
+
+	// Tags returns the tags attached to the key with TagOpt arguments.
+	Tags() map[string]string
+
+	// Min returns the minimum value of an Int, Float64, or Duration key. Nil
+	// is returned if the key has no minimum value.
+	Min() interface{}
+
+	// Max returns the maximum value of an Int, Float64, or Duration key. Nil
+	// is returned if the key has no maximum value.
+	Max() interface{}
 }
+
+// ChangeHandle is returned when registering a change callback and may be used
+// to remove the callback.
+type ChangeHandle interface {
+
+	// Stop removes the callback so that it is no longer invoked.
+	Stop()
+}
+
+// CancelFunc is returned when registering an observer and removes the
+// observer when called.
+type CancelFunc func()
+
+// ConfigTx is a transaction used to modify a configuration atomically.
+type ConfigTx interface {
+
+	// Set sets an override value when the transaction is committed.
+	Set(k interface{}, v interface{})
+}
+
+// ConfigSnapshot is a copy of a configuration's settings created with the
+// Snapshot function and used to restore the configuration with the Restore
+// function.
+type ConfigSnapshot struct {
+	settings map[string]interface{}
+}
+
+// NewConfigSnapshot returns a new ConfigSnapshot with the specified settings.
+func NewConfigSnapshot(settings map[string]interface{}) ConfigSnapshot {
+	return ConfigSnapshot{settings: settings}
+}
+
+// Settings returns the snapshot's settings. The returned map must not be
+// modified.
+func (s ConfigSnapshot) Settings() map[string]interface{} {
+	return s.settings
+}
+
+// Resolver resolves the values of keys from an external source, such as a
+// secrets manager.
+type Resolver interface {
+
+	// Resolve returns the value of the key.
+	Resolve(key string) (string, error)
+}
+
+// AuditEvent describes a change made to a configuration key.
+type AuditEvent struct {
+
+	// Timestamp is the time at which the change was made.
+	Timestamp time.Time
+
+	// Key is the dot-notation name of the key.
+	Key string
+
+	// OldValue is the key's previous value. It is SecureValue for secure
+	// keys.
+	OldValue interface{}
+
+	// NewValue is the key's new value. It is SecureValue for secure keys.
+	NewValue interface{}
+
+	// Source is the source of the change.
+	Source ConfigSource
+
+	// CallerInfo is the file and line, in the form "file:line", of the code
+	// outside of this package that made the change.
+	CallerInfo string
+}
+
+// AuditLog records the changes made to a config.
+type AuditLog interface {
+
+	// Record records the change. Record is invoked while the config is
+	// locked and so must not modify the config.
+	Record(event AuditEvent)
+}
+
+// HTTPOptions are the options used to read a configuration file over HTTP.
+type HTTPOptions struct {
+
+	// Timeout is the maximum amount of time the request may take.
+	Timeout time.Duration
+
+	// BearerToken is sent in the request's Authorization header if set.
+	BearerToken string
+
+	// Username and Password are used for HTTP basic authentication if the
+	// Username is set.
+	Username string
+	Password string
+
+	// Certificates are the client certificates presented to the server.
+	Certificates []tls.Certificate
+}
+
+// HTTPOption is an option used to configure the request that reads a
+// configuration file over HTTP.
+type HTTPOption func(o *HTTPOptions)
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"context"
 	"io"
+	"io/fs"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -16,6 +19,9 @@ type Config interface {
 	// DisableEnvVarSubstitution.
 	DisableEnvVarSubstitution(disable bool)
 
+	// StrictMode is the same as the global flag, StrictMode.
+	StrictMode(enabled bool)
+
 	// Parent gets the configuration's parent (if set).
 	Parent() Config
 
@@ -24,16 +30,53 @@ type Config interface {
 
 	// Scope returns a scoped view of the configuration. The specified scope
 	// string will be used to prefix all property retrievals via the Get
-	// and Set functions. Please note that the other functions will still
-	// operate as they would for the non-scoped configuration instance. This
-	// includes the AllSettings and AllKeys functions as well; they are *not*
-	// scoped.
+	// and Set functions. The AllKeys and AllSettings functions return only
+	// the keys and settings that belong to the scope, with the scope prefix
+	// removed. Please note that the other functions will still operate as
+	// they would for the non-scoped configuration instance.
 	Scope(scope interface{}) Config
 
+	// StrictScope returns a scoped view of the configuration like Scope. The
+	// EnvVars function, like the AllKeys and AllSettings functions, returns
+	// only the environment variables for the keys that belong to the scope,
+	// with the scope removed from the variables' names.
+	StrictScope(scope string) Config
+
 	// GetScope returns the config's current scope (if any).
 	GetScope() string
 
-	// GetString returns the value associated with the key as a string
+	// GetScopeChain returns the scopes from the root config to the current
+	// scope. For example, c.Scope("a").Scope("b") returns ["a", "b"]. An
+	// empty slice is returned for a config that is not scoped.
+	GetScopeChain() []string
+
+	// Extend returns a child config with its own settings. The values of the
+	// keys that are not set on the child are read from this Config instance,
+	// so changes made to this instance are visible to the child unless the
+	// child overrides them. Set on the child never modifies this instance.
+	// Copy on the child returns an independent config with this instance's
+	// settings and the child's settings merged together.
+	Extend() Config
+
+	// SubConfig returns a new, independent config that contains only the
+	// settings of the keys below the prefix, with the prefix removed. For
+	// example, the value of "database.host" is the value of "host" in the
+	// config returned by SubConfig("database"). Unlike Scope and Extend,
+	// the returned config does not read keys from this Config instance, so
+	// changes made to either config are not visible to the other.
+	SubConfig(prefix string) Config
+
+	// GetKeyTags returns the tags attached to the registered key. An error
+	// is returned if the key is not registered.
+	GetKeyTags(k interface{}) (map[string]string, error)
+
+	// GetProfile returns the name of the profile with which the config was
+	// created (if any).
+	GetProfile() string
+
+	// GetString returns the value associated with the key as a string. Any
+	// ${key} references in the value are replaced with the values of the
+	// referenced keys.
 	GetString(k interface{}) string
 
 	// GetBool returns the value associated with the key as a bool
@@ -43,46 +86,486 @@ type Config interface {
 	// slice.
 	GetStringSlice(k interface{}) []string
 
+	// GetStringSliceDelim returns the value associated with the key as a
+	// string slice. A string value is split into the slice using the
+	// specified delimiter instead of the delimiter used by GetStringSlice.
+	GetStringSliceDelim(k interface{}, delim string) []string
+
 	// GetInt returns the value associated with the key as an int
 	GetInt(k interface{}) int
 
+	// GetMap returns the nested values of the key as a map. Nil is returned
+	// if the key does not exist or if its value is not a map.
+	GetMap(k interface{}) map[string]interface{}
+
+	// GetMapString is the same as GetMap except that the values of the map
+	// are converted to strings.
+	GetMapString(k interface{}) map[string]string
+
 	// Get returns the value associated with the key
 	Get(k interface{}) interface{}
 
+	// GetStringCtx is the same as GetString except that if the context
+	// carries a trace span then the read is recorded as a child span with
+	// the key and the source of its value as attributes.
+	GetStringCtx(ctx context.Context, k interface{}) string
+
+	// GetBoolCtx is the same as GetBool except the read is traced like
+	// GetStringCtx.
+	GetBoolCtx(ctx context.Context, k interface{}) bool
+
+	// GetStringSliceCtx is the same as GetStringSlice except the read is
+	// traced like GetStringCtx.
+	GetStringSliceCtx(ctx context.Context, k interface{}) []string
+
+	// GetIntCtx is the same as GetInt except the read is traced like
+	// GetStringCtx.
+	GetIntCtx(ctx context.Context, k interface{}) int
+
+	// GetCtx is the same as Get except the read is traced like GetStringCtx.
+	GetCtx(ctx context.Context, k interface{}) interface{}
+
+	// TryGetString returns the value associated with the key as a string and
+	// a flag indicating whether or not the key exists and its value is not
+	// an empty string. The value is read the same way as GetString reads it,
+	// including template expansion, resolvers, and enum defaults, so a key
+	// that is set to an empty string returns ("", false) just as a key that
+	// is not set does.
+	TryGetString(k interface{}) (string, bool)
+
+	// TryGetInt returns the value associated with the key as an int and a
+	// flag indicating whether or not the key exists and its value is not
+	// zero. A key that is set to zero returns (0, false).
+	TryGetInt(k interface{}) (int, bool)
+
+	// TryGetBool returns the value associated with the key as a bool and a
+	// flag indicating whether or not the key exists and its value is not
+	// false. A key that is set to false returns (false, false).
+	TryGetBool(k interface{}) (bool, bool)
+
+	// GetStringOrDefault returns the value associated with the key as a
+	// string. If the key is not set then the key's registered default value
+	// is returned, or def if the key is not registered.
+	GetStringOrDefault(k interface{}, def string) string
+
+	// GetIntOrDefault returns the value associated with the key as an int.
+	// If the key is not set then the key's registered default value is
+	// returned, or def if the key is not registered.
+	GetIntOrDefault(k interface{}, def int) int
+
+	// GetBoolOrDefault returns the value associated with the key as a bool.
+	// If the key is not set then the key's registered default value is
+	// returned, or def if the key is not registered.
+	GetBoolOrDefault(k interface{}, def bool) bool
+
+	// GetFloat64OrDefault returns the value associated with the key as a
+	// float64. If the key is not set then the key's registered default value
+	// is returned, or def if the key is not registered.
+	GetFloat64OrDefault(k interface{}, def float64) float64
+
+	// GetByPath returns the value at the specified path. The path uses dot
+	// notation for nested keys and bracket notation for array elements, for
+	// example "endpoints.servers[0].host". An error is returned if the path
+	// is invalid, such as when an index is out of range or a value that
+	// should be a map or an array is not.
+	GetByPath(path string) (interface{}, error)
+
+	// SetByPath sets the value at the specified path. The path uses the same
+	// notation as GetByPath. If the path contains an array index then the
+	// value of the key that precedes the first index is replaced with a copy
+	// that contains the new value.
+	SetByPath(path string, v interface{}) error
+
+	// AccessStats returns a snapshot of the number of times each key was read
+	// with one of the Get functions since the config was created or since
+	// ResetStats was last called. If the package is built with the build tag
+	// nogofigstats then the statistics are not collected and AccessStats
+	// returns an empty map.
+	AccessStats() map[string]uint64
+
+	// ResetStats sets the access count of every key to zero.
+	ResetStats()
+
+	// Deprecate marks a key as deprecated. The message is logged when the
+	// key is read with one of the Get functions or IsSet, or when the key is
+	// present in a stream read with ReadConfig or ReadConfigFile.
+	Deprecate(k interface{}, message string)
+
 	// Set sets an override value
 	Set(k interface{}, v interface{})
 
+	// SetConfigVersion sets the version of the config's structure and applies
+	// the migrations registered with AddMigration if the version of the
+	// config's files is less than the specified version.
+	SetConfigVersion(v int)
+
+	// SetWithTTL sets an override value that expires once the specified
+	// duration elapses. Once expired, the key's value is the value it would
+	// have had if the override had never been set. Setting the key again
+	// resets or, in the case of Set, removes the expiration.
+	SetWithTTL(k interface{}, v interface{}, ttl time.Duration)
+
+	// GetOrFetch returns the value of the key if it is set. Otherwise the
+	// fetch function is invoked and its result is set with SetWithTTL and
+	// returned, or, if the ttl is zero or less, set without an expiration.
+	// Concurrent calls for the same key invoke the fetch function only once
+	// and share its result. The config is not locked while fetch is invoked.
+	GetOrFetch(
+		k interface{},
+		fetch func() (interface{}, error),
+		ttl time.Duration) (interface{}, error)
+
+	// ClearExpired removes the override values set with SetWithTTL that have
+	// expired. Expired values are also removed when keys are read and
+	// periodically in the background.
+	ClearExpired()
+
+	// Transaction invokes fn with a transaction and then applies the values
+	// set with the transaction to this Config instance at once, so readers
+	// never observe some of the values without the others. No values are
+	// applied if fn returns an error. The config's lock is held while fn is
+	// invoked, so fn must not modify the Config instance directly.
+	// Transaction returns ErrFrozen if the config is frozen.
+	Transaction(fn func(tx ConfigTx) error) error
+
 	// IsSet returns a flag indicating whether or not a key is set.
 	IsSet(k interface{}) bool
 
-	// Copy creates a copy of this Config instance
+	// GetSource returns the source of the key's value. The sources are
+	// checked in order of precedence: a value set with the Set function, a
+	// command line flag, an environment variable, and a config file or
+	// stream. If the value is from none of those sources then Default is
+	// returned.
+	GetSource(k interface{}) ConfigSource
+
+	// Unmarshal decodes this Config instance's settings into the provided
+	// struct using mapstructure field tags. SecureString values are decoded
+	// as empty strings.
+	Unmarshal(rawVal interface{}) error
+
+	// UnmarshalKey decodes the value associated with the key into the
+	// provided struct using mapstructure field tags. SecureString values are
+	// decoded as empty strings.
+	UnmarshalKey(k interface{}, rawVal interface{}) error
+
+	// Merge merges the settings from the other Config instance into this
+	// one. The merged settings have the same precedence as settings read
+	// with ReadConfig.
+	Merge(other Config) error
+
+	// SetMergeStrategy sets how the Merge, ReadConfig, and ReadConfigFile
+	// functions resolve the value of a key that was already read from another
+	// config stream or Config instance. The default strategy is LastWins.
+	SetMergeStrategy(s MergeStrategy)
+
+	// Reset removes the values set with the Set function. The values read
+	// from config files and streams, environment variables, and flags are
+	// retained. Reset returns ErrFrozen if the config is frozen.
+	Reset() error
+
+	// ResetToDefaults removes all of the values except the defaults from the
+	// registrations. ResetToDefaults returns ErrFrozen if the config is
+	// frozen.
+	ResetToDefaults() error
+
+	// Copy creates a copy of this Config instance. The copy of a frozen
+	// Config instance is also frozen.
 	Copy() (Config, error)
 
+	// ScopedCopy creates a copy of this Config instance that contains only
+	// the keys in its scope, with the scope removed from their names. The
+	// copy is independent of this Config instance. ScopedCopy is the same as
+	// Copy if this Config instance is not scoped.
+	ScopedCopy() (Config, error)
+
+	// DeepEqual returns a flag indicating whether or not this config and the
+	// other config have exactly the same keys with the same values. False is
+	// returned if the other config is nil.
+	DeepEqual(other Config) bool
+
+	// DeepEqualKeys returns a flag indicating whether or not the specified
+	// keys have the same values in this config and the other config. False
+	// is returned if the other config is nil.
+	DeepEqualKeys(other Config, keys []string) bool
+
+	// CloneWith creates a copy of this Config instance and sets the values
+	// of the overrides, keyed with dot-notation, on the copy. Unknown keys
+	// are accepted unless the config is in strict mode, in which case a
+	// *ValidationError is returned. The overrides are set even if the copy
+	// is frozen.
+	CloneWith(overrides map[string]interface{}) (Config, error)
+
+	// Snapshot returns a copy of all of this Config instance's settings.
+	Snapshot() ConfigSnapshot
+
+	// Restore discards the changes made to this Config instance since the
+	// snapshot was created by replacing the config's settings with the
+	// snapshot's settings. The registered flags and environment variable
+	// bindings are preserved. Restore returns ErrFrozen if the config is
+	// frozen.
+	Restore(s ConfigSnapshot) error
+
+	// Freeze prevents this Config instance from being modified. Once frozen,
+	// the Set function panics with ErrFrozen, and the ReadConfig,
+	// ReadConfigFile, ReadConfigFromURL, and Merge functions return
+	// ErrFrozen.
+	Freeze()
+
+	// Unfreeze allows a frozen Config instance to be modified again.
+	Unfreeze()
+
+	// IsFrozen returns a flag indicating whether or not this Config instance
+	// is frozen.
+	IsFrozen() bool
+
 	// ToJSON exports this Config instance to a JSON string
 	ToJSON() (string, error)
 
 	// ToJSONCompact exports this Config instance to a compact JSON string
 	ToJSONCompact() (string, error)
 
+	// MaskedToJSON exports this Config instance to a JSON string with the
+	// values of the secure keys replaced by RedactedValue.
+	MaskedToJSON() (string, error)
+
+	// MaskedToJSONCompact exports this Config instance to a compact JSON
+	// string with the values of the secure keys replaced by RedactedValue.
+	MaskedToJSONCompact() (string, error)
+
+	// ToYAML exports this Config instance to a YAML string
+	ToYAML() (string, error)
+
+	// ToTOML exports this Config instance to a TOML string
+	ToTOML() (string, error)
+
+	// ToProto exports this Config instance to a serialized protobuf message.
+	// The message is a google.protobuf.Struct with the config's settings as
+	// its fields. SecureString values are omitted.
+	ToProto() ([]byte, error)
+
 	// MarshalJSON implements the encoding/json.Marshaller interface. It allows
 	// this type to provide its own marshalling routine.
 	MarshalJSON() ([]byte, error)
 
-	// ReadConfig reads a configuration stream into the current config instance
+	// ReadConfig reads a configuration stream into the current config
+	// instance. The format of the stream, JSON, TOML, or YAML, is detected
+	// from its content. If the format cannot be detected the config type
+	// with which the instance was created is used. A gzip or zstd compressed
+	// stream is decompressed before it is read.
 	ReadConfig(in io.Reader) error
 
+	// ReadConfigFromReader is the same as ReadConfig except that the format
+	// of the stream, json, toml, yaml, or yml, is specified instead of being
+	// detected from its content.
+	ReadConfigFromReader(in io.Reader, format string) error
+
+	// ReadConfigFS reads the file at the path in the file system, such as
+	// one created with the embed package, into the current config instance.
+	// The file is read at the lowest priority, so its values are overridden
+	// by the values from the config files and streams read into the instance
+	// before or after it and by environment variables. The file is not read
+	// again when the instance is reloaded.
+	ReadConfigFS(fsys fs.FS, filePath string) error
+
 	// ReadConfigFile reads a configuration files into the current config
-	// instance
+	// instance. The format of the file is determined by its extension. If
+	// the file path is relative and does not exist then the file is searched
+	// for in the paths added with AddConfigPath.
+	//
+	// A YAML file may include other config files by listing their paths,
+	// which may be glob patterns, with the _include key. The paths are
+	// relative to the directory of the including file. The included files
+	// are merged in order, followed by the including file itself.
 	ReadConfigFile(filePath string) error
 
+	// Reload reads the config files and URLs previously read into the config
+	// again, in the order in which they were first read, and merges their
+	// current contents into the config. The change callbacks are invoked for
+	// the keys whose values changed. A file that no longer exists is skipped
+	// and the first error encountered is returned.
+	Reload() error
+
+	// SetWatchDebounce sets the window in which a burst of change events for
+	// a watched config file is coalesced into a single reload. The reload
+	// occurs once the window elapses without a new event for the file. A
+	// window that is not positive restores the default window of 200ms.
+	SetWatchDebounce(d time.Duration)
+
+	// LoadEnvFile reads the KEY=VALUE lines from the file and sets each of
+	// the environment variables that is not already set, so the values of
+	// the keys bound to those variables are read from the file. Blank lines
+	// and lines that begin with # are skipped.
+	LoadEnvFile(path string) error
+
+	// ReadSignedConfigFile reads a configuration file into the current config
+	// instance after verifying the file's settings match the hex-encoded
+	// HMAC-SHA256 signature in the signature file, such as one returned by
+	// SignConfigFile. An error is returned and the file is not read if
+	// verification fails.
+	ReadSignedConfigFile(filePath, sigPath string, key []byte) error
+
+	// ReadCompressedConfigFile reads a gzip or zstd compressed configuration
+	// file into the current config instance. The compression algorithm is
+	// determined by the file's content or its extension, such as .gz or
+	// .zst, and the format of the file is determined by the extension that
+	// precedes the compression extension, such as .yml in config.yml.gz.
+	ReadCompressedConfigFile(filePath string) error
+
+	// ReadConfigFromURL reads the configuration file at the specified URL
+	// into the current config instance. The format of the file is determined
+	// by the response's content type, the URL's extension, or the file's
+	// content, in that order. The URL is read again when the config's files
+	// are reloaded.
+	ReadConfigFromURL(url string, opts ...HTTPOption) error
+
+	// PollConfigURL reads the configuration file at the specified URL into
+	// the current config instance and then requests it again at the
+	// specified interval. The ETag of the last response is sent with each
+	// request so that the file is only read again if it has changed. The
+	// returned channel receives a value each time the file changes and is
+	// closed when polling stops.
+	PollConfigURL(
+		url string,
+		interval time.Duration,
+		opts ...HTTPOption) (<-chan struct{}, error)
+
+	// StopPolling stops polling the URLs polled with PollConfigURL and waits
+	// for any in-flight requests to complete.
+	StopPolling()
+
+	// AddConfigPath adds a path to search for config files. The config file,
+	// and the config file for the config's profile, are read from the path if
+	// they exist. AddConfigPath has no effect if the config is frozen or
+	// read-only.
+	AddConfigPath(path string)
+
+	// WriteConfigFile writes the configuration to the first config file that
+	// was read into this config instance. SecureString values are written as
+	// empty strings.
+	WriteConfigFile() error
+
+	// WriteConfigFileRaw is the same as WriteConfigFile except SecureString
+	// values are written as-is.
+	WriteConfigFileRaw() error
+
+	// WriteCompressedConfigFile writes the configuration to the specified
+	// path compressed with the algorithm determined by the path's extension,
+	// .gz for gzip or .zst for zstd, or the config's compression algorithm if
+	// the extension does not indicate one. The format is determined by the
+	// extension that precedes the compression extension. SecureString values
+	// are written as empty strings.
+	WriteCompressedConfigFile(filePath string) error
+
+	// WriteConfigAs writes the configuration to the specified path using the
+	// specified format: yml, yaml, json, or toml. SecureString values are
+	// written as empty strings.
+	WriteConfigAs(filePath, format string) error
+
 	// EnvVars returns an array of the initialized configuration keys as
 	// key=value strings where the key is configuration key's environment
 	// variable key and the value is the current value for that key.
 	EnvVars() []string
 
-	// AllKeys gets a list of all the keys present in this configuration.
+	// EnvVarsWithPrefix returns the same key=value strings as EnvVars, except
+	// the values of SecureString keys are excluded and each key is prefixed
+	// with the upper-cased prefix and an underscore, ex. the prefix "app"
+	// returns HOST=localhost as APP_HOST=localhost. The environment variables
+	// bound to the keys are not affected. An empty prefix adds no prefix.
+	EnvVarsWithPrefix(prefix string) []string
+
+	// LookupEnvVar returns the name of the environment variable bound to the
+	// key, its raw value, and a flag indicating whether or not the variable
+	// is set. The name of a registered key's variable is the one bound when
+	// the key was registered. The name of an unregistered key's variable is
+	// derived from the key's name. If the configuration is scoped the scoped
+	// key is looked up first, then the key in the parent configuration.
+	LookupEnvVar(k interface{}) (envVarName, rawValue string, found bool)
+
+	// ExportEnvFile writes the config's environment variables to the writer
+	// as KEY=VALUE lines suitable for use as a Docker .env file. Values that
+	// contain whitespace or special characters are quoted. The secure keys
+	// are omitted unless includeSecure is true.
+	ExportEnvFile(w io.Writer, includeSecure bool) error
+
+	// ExportShellScript writes the config's environment variables to the
+	// writer as export KEY=VALUE lines suitable for sourcing from a shell.
+	// Values that contain whitespace or special characters are quoted. The
+	// secure keys are omitted unless includeSecure is true.
+	ExportShellScript(w io.Writer, includeSecure bool) error
+
+	// ExportKubernetesConfigMap writes a Kubernetes ConfigMap manifest to the
+	// writer. The manifest's data contains the config's environment variables
+	// with the secure keys omitted.
+	ExportKubernetesConfigMap(name, namespace string, w io.Writer) error
+
+	// ExportKubernetesSecret writes a Kubernetes Secret manifest to the
+	// writer. The manifest's data contains only the environment variables of
+	// the config's secure keys.
+	ExportKubernetesSecret(name, namespace string, w io.Writer) error
+
+	// AllKeys gets a list of all the keys present in this configuration. If
+	// the configuration is scoped only the keys in the scope are returned.
 	AllKeys() []string
 
-	// AllSettings gets a map of this configuration's settings.
+	// KeysMatching returns the sorted names of the keys that match the glob
+	// pattern, using the semantics of the path.Match function. For example,
+	// "plugins.*.enabled" matches "plugins.ebs.enabled". Keys are matched
+	// against the full, unscoped key space even if the configuration is
+	// scoped.
+	KeysMatching(pattern string) []string
+
+	// KeysWithPrefix returns the sorted names of the keys that start with
+	// the prefix. Keys are matched against the full, unscoped key space even
+	// if the configuration is scoped.
+	KeysWithPrefix(prefix string) []string
+
+	// KeysWithSuffix returns the sorted names of the keys that end with the
+	// suffix. Keys are matched against the full, unscoped key space even if
+	// the configuration is scoped.
+	KeysWithSuffix(suffix string) []string
+
+	// AllSettings gets a map of this configuration's settings. If the
+	// configuration is scoped only the settings in the scope are returned.
 	AllSettings() map[string]interface{}
+
+	// Flatten gets a map of this configuration's settings keyed with
+	// dot-notation, such as "service.host". If the configuration is scoped
+	// only the settings in the scope are returned.
+	Flatten() map[string]interface{}
+
+	// AllSettingsFlat gets a map of this configuration's leaf settings keyed
+	// with lower-case dot-notation like the keys returned by AllKeys. It is
+	// equivalent to Flatten. If the configuration is scoped only the settings
+	// in the scope are returned, keyed relative to the scope.
+	AllSettingsFlat() map[string]interface{}
+
+	// OnChange registers a function that is invoked when the value of the
+	// specified key changes as the result of a Set or ReadConfig call. The
+	// function is invoked in its own goroutine.
+	OnChange(k interface{}, fn func(oldVal, newVal interface{})) ChangeHandle
+
+	// OnAnyChange registers a function that is invoked when the value of any
+	// key changes as the result of a Set or ReadConfig call. The function is
+	// invoked in its own goroutine.
+	OnAnyChange(fn func(k string, oldVal, newVal interface{})) ChangeHandle
+
+	// SetAuditLog sets the audit log that records every change made to a key
+	// by a Set, Transaction, ReadConfig, or ReadConfigFile call. A nil audit
+	// log disables auditing.
+	SetAuditLog(al AuditLog)
+
+	// Observe invokes fn with the current value of the key and then again
+	// with the key's new value whenever it changes as the result of a Set
+	// or ReadConfig call. The function is invoked from the goroutine that
+	// applies the change while the config's lock is held, so fn must not
+	// call any of this Config instance's functions, including the functions
+	// that read values. The returned function removes the observer.
+	Observe(k interface{}, fn func(v interface{})) CancelFunc
+
+	// WatchKey registers a function that is invoked with the new value of
+	// the key whenever it changes as the result of a Set call, a ReadConfig
+	// call, or a reload of the config's files. The function is invoked in its
+	// own goroutine. Any number of functions may watch the same key. The
+	// returned function stops the watch.
+	WatchKey(k interface{}, fn func(newVal interface{})) CancelFunc
 }